@@ -8,12 +8,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/eduardohass/kids-api/internal/auth"
 	"github.com/eduardohass/kids-api/internal/config"
 	"github.com/eduardohass/kids-api/internal/handlers"
+	"github.com/eduardohass/kids-api/internal/jobs"
+	"github.com/eduardohass/kids-api/internal/metrics"
+	"github.com/eduardohass/kids-api/internal/notifications"
+	"github.com/eduardohass/kids-api/internal/pii"
+	"github.com/eduardohass/kids-api/internal/precheckin"
+	"github.com/eduardohass/kids-api/internal/realtime"
 	"github.com/eduardohass/kids-api/internal/repository"
 	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/eduardohass/kids-api/internal/tenant"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 )
@@ -29,19 +38,235 @@ func main() {
 	}
 	defer db.Close()
 
+	// Métricas expostas em /metrics: pool de conexões e latência das consultas.
+	// Fora do desenvolvimento a coleta sempre exige o token
+	if cfg.MetricsToken == "" && cfg.Env != "development" {
+		log.Fatal("METRICS_TOKEN is required")
+	}
+	metricsRegistry := metrics.NewRegistry()
+	dbMetrics := metrics.NewDBMetrics(metricsRegistry, db.DB)
+
+	// Consultas restritas ao tenant da requisição (row-level security), medidas
+	// por repositório
+	scopedDB := tenant.NewDB(db)
+	repoDB := func(name string) *tenant.DB {
+		return scopedDB.WithObserver(dbMetrics.Observer(name))
+	}
+
 	// Configurar repositórios
-	childRepo := repository.NewChildRepository(db)
-	caretakerRepo := repository.NewCaretakerRepository(db)
-	volunteerRepo := repository.NewVolunteerRepository(db)
-	groupRepo := repository.NewGroupRepository(db)
-	needRepo := repository.NewNeedRepository(db)
-	allergyRepo := repository.NewAllergyRepository(db)
+	childRepo := repository.NewChildRepository(repoDB("child"))
+	caretakerRepo := repository.NewCaretakerRepository(repoDB("caretaker"))
+	volunteerRepo := repository.NewVolunteerRepository(repoDB("volunteer"))
+	groupRepo := repository.NewGroupRepository(repoDB("group"))
+	needRepo := repository.NewNeedRepository(repoDB("need"))
+	allergyRepo := repository.NewAllergyRepository(repoDB("allergy"))
+	searchRepo := repository.NewSearchRepository(repoDB("search"))
+	householdRepo := repository.NewHouseholdRepository(repoDB("household"))
+	attendanceRepo := repository.NewAttendanceRepository(repoDB("attendance"))
+	restrictionRepo := repository.NewRestrictionRepository(repoDB("restriction"))
+	auditRepo := repository.NewAuditRepository(repoDB("audit"))
+	pickupGrantRepo := repository.NewPickupGrantRepository(repoDB("pickup_grant"))
+	preCheckInRepo := repository.NewPreCheckInRepository(repoDB("precheckin"))
+	kioskRepo := repository.NewKioskRepository(repoDB("kiosk"))
+	notificationRepo := repository.NewNotificationRepository(repoDB("notification"))
+	jobRepo := repository.NewJobRepository(repoDB("job"))
+	groupEventRepo := repository.NewGroupEventRepository(repoDB("group_event"))
+	incidentRepo := repository.NewIncidentRepository(repoDB("incident"))
+	medicationRepo := repository.NewMedicationRepository(repoDB("medication"))
+	medicalProfileRepo := repository.NewMedicalProfileRepository(repoDB("medical_profile"))
+	rollCallRepo := repository.NewRollCallRepository(repoDB("roll_call"))
+	locationRepo := repository.NewLocationRepository(repoDB("location"))
+	roomRepo := repository.NewRoomRepository(repoDB("room"))
+	encryptionKeyRepo := repository.NewEncryptionKeyRepository(repoDB("encryption_key"))
+	piiRepo := repository.NewPIIRepository(repoDB("pii"))
+	tenantRepo := repository.NewTenantRepository(repoDB("tenant"))
+	dataSubjectRepo := repository.NewDataSubjectRepository(repoDB("data_subject"))
+	consentRepo := repository.NewConsentRepository(repoDB("consent"))
+	accessLogRepo := repository.NewAccessLogRepository(repoDB("access_log"))
+	retentionRepo := repository.NewRetentionRepository(repoDB("retention"))
+
+	// Configurar criptografia dos dados pessoais
+	encryptionService := services.NewEncryptionService(
+		encryptionKeyRepo,
+		piiRepo,
+		tenantRepo,
+		encryptionKeys(cfg),
+		cfg.PIIRotationBatchSize,
+	)
+	if cfg.PIIMasterKey != "" {
+		keyring, err := encryptionService.LoadKeyring(context.Background())
+		if err != nil {
+			log.Fatalf("Error loading encryption keys: %v", err)
+		}
+		pii.SetKeyring(keyring)
+		pii.SetLoader(func() (*pii.Keyring, error) {
+			return encryptionService.LoadKeyring(context.Background())
+		})
+	}
+
+	// "kids-api rotate-keys" cria uma nova chave de dados e recifra os dados pessoais
+	if len(os.Args) > 1 && os.Args[1] == "rotate-keys" {
+		runKeyRotation(encryptionService, cfg)
+		return
+	}
+
+	// Configurar fila de trabalhos em segundo plano
+	jobQueue := jobs.NewQueue(jobRepo)
+
+	// Configurar eventos em tempo real (LISTEN/NOTIFY entre réplicas)
+	broker := realtime.NewBroker()
+	listener, err := realtime.Listen(cfg.DatabaseURL, broker)
+	if err != nil {
+		log.Fatalf("Error listening for group events: %v", err)
+	}
+	defer listener.Close()
 
 	// Configurar serviços
+	accessLogService := services.NewAccessLogService(accessLogRepo, cfg.AccessHighVolumeEntities)
+	groupStreamService := services.NewGroupStreamService(groupEventRepo, groupRepo, broker)
 	childService := services.NewChildService(childRepo, needRepo, allergyRepo)
-	caretakerService := services.NewCaretakerService(caretakerRepo)
+	caretakerService := services.NewCaretakerService(caretakerRepo, accessLogService)
 	volunteerService := services.NewVolunteerService(volunteerRepo)
 	groupService := services.NewGroupService(groupRepo)
+	searchService := services.NewSearchService(searchRepo, accessLogService)
+	pickupGrantService := services.NewPickupGrantService(pickupGrantRepo, caretakerRepo)
+	meService := services.NewMeService(
+		caretakerRepo,
+		householdRepo,
+		childRepo,
+		needRepo,
+		allergyRepo,
+		attendanceRepo,
+		pickupGrantService,
+	)
+	consentService := services.NewConsentService(consentRepo, childRepo, caretakerRepo, meService)
+	attendanceService := services.NewAttendanceService(
+		attendanceRepo,
+		childRepo,
+		restrictionRepo,
+		caretakerRepo,
+		pickupGrantRepo,
+		auditRepo,
+		groupRepo,
+		roomRepo,
+		consentService,
+		groupStreamService,
+	)
+	restrictionService := services.NewRestrictionService(restrictionRepo, childRepo, auditRepo)
+	householdService := services.NewHouseholdService(householdRepo, attendanceService, accessLogService)
+	preCheckInSecret := cfg.PreCheckInSecret
+	if preCheckInSecret == "" {
+		if cfg.Env != "development" {
+			log.Fatal("PRECHECKIN_SECRET is required")
+		}
+		// Em desenvolvimento, os QR codes valem apenas enquanto o processo estiver no ar
+		log.Println("PRECHECKIN_SECRET not set, using a random secret")
+		preCheckInSecret = precheckin.RandomSecret()
+	}
+	preCheckInSigner := precheckin.NewSigner(preCheckInSecret, time.Duration(cfg.PreCheckInTTLMinutes)*time.Minute)
+	preCheckInService := services.NewPreCheckInService(preCheckInSigner, preCheckInRepo, meService, attendanceService)
+	kioskService := services.NewKioskService(kioskRepo, householdRepo, locationRepo)
+	notificationService := services.NewNotificationService(
+		notificationRepo,
+		attendanceRepo,
+		childRepo,
+		groupRepo,
+		caretakerRepo,
+		newNotifierRegistry(cfg),
+		jobQueue,
+		groupStreamService,
+	)
+
+	incidentService := services.NewIncidentService(
+		incidentRepo,
+		childRepo,
+		groupRepo,
+		volunteerRepo,
+		caretakerRepo,
+		householdRepo,
+		auditRepo,
+	)
+
+	medicationService := services.NewMedicationService(medicationRepo, childRepo, volunteerRepo, caretakerRepo)
+	medicalProfileService := services.NewMedicalProfileService(
+		medicalProfileRepo,
+		childRepo,
+		groupRepo,
+		caretakerRepo,
+		attendanceRepo,
+	)
+	rollCallService := services.NewRollCallService(
+		rollCallRepo,
+		locationRepo,
+		childRepo,
+		medicalProfileRepo,
+		auditRepo,
+		groupStreamService,
+		accessLogService,
+	)
+	locationService := services.NewLocationService(
+		locationRepo,
+		roomRepo,
+		groupRepo,
+		volunteerRepo,
+		attendanceRepo,
+	)
+
+	dataSubjectService := services.NewDataSubjectService(
+		dataSubjectRepo,
+		caretakerRepo,
+		householdRepo,
+		childRepo,
+		medicalProfileRepo,
+		attendanceRepo,
+		pickupGrantRepo,
+		medicationRepo,
+		incidentRepo,
+		consentRepo,
+		auditRepo,
+		jobQueue,
+		cfg.PhotoHosts,
+	)
+
+	if cfg.RetentionIntervalHours <= 0 {
+		log.Fatal("RETENTION_INTERVAL_HOURS must be positive")
+	}
+	retentionService := services.NewRetentionService(
+		retentionRepo,
+		childRepo,
+		dataSubjectRepo,
+		incidentRepo,
+		accessLogRepo,
+		groupEventRepo,
+		tenantRepo,
+		auditRepo,
+		jobQueue,
+		time.Duration(cfg.RetentionIntervalHours)*time.Hour,
+	)
+
+	// Indicadores do domínio (crianças presentes, notificações pendentes)
+	services.NewMetricsService(tenantRepo, attendanceRepo, notificationRepo).Register(metricsRegistry)
+
+	// Configurar worker da fila
+	worker := jobs.NewWorker(jobRepo, workerConfig(cfg))
+	worker.Handle(services.JobDeliverNotification, jobs.Typed(notificationService.Deliver))
+	worker.Handle(services.JobProcessDataRequest, jobs.Typed(dataSubjectService.Process))
+	worker.Handle(services.JobRunRetention, jobs.Typed(retentionService.ProcessRun))
+	worker.Handle(services.JobScheduleRetention, jobs.Typed(retentionService.RunScheduled))
+
+	// Cada campus precisa ter a execução periódica das regras de retenção na fila
+	if err := retentionService.Schedule(context.Background()); err != nil {
+		log.Printf("Error scheduling retention runs: %v", err)
+	}
+
+	// "kids-api worker" roda apenas o worker, sem o servidor HTTP
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		runWorker(worker, cfg)
+		return
+	}
+
+	// Configurar autenticação
+	authenticator := auth.NewAuthenticator(cfg.Auth0Domain, cfg.Auth0Audience)
 
 	// Configurar router
 	router := handlers.NewRouter(
@@ -49,6 +274,30 @@ func main() {
 		caretakerService,
 		volunteerService,
 		groupService,
+		searchService,
+		householdService,
+		attendanceService,
+		restrictionService,
+		pickupGrantService,
+		meService,
+		preCheckInService,
+		kioskService,
+		notificationService,
+		jobQueue,
+		groupStreamService,
+		incidentService,
+		medicationService,
+		medicalProfileService,
+		rollCallService,
+		locationService,
+		dataSubjectService,
+		consentService,
+		accessLogService,
+		retentionService,
+		authenticator,
+		metricsRegistry,
+		cfg.MetricsToken,
+		time.Duration(cfg.PurgeRetentionDays)*24*time.Hour,
 	)
 
 	// Configurar servidor HTTP
@@ -59,6 +308,8 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// Encerra as conexões SSE para que o Shutdown não espere por elas
+	srv.RegisterOnShutdown(broker.Close)
 
 	// Iniciar servidor em uma goroutine
 	go func() {
@@ -68,9 +319,14 @@ func main() {
 		}
 	}()
 
+	// Iniciar worker no mesmo processo
+	if cfg.WorkerInProcess {
+		worker.Start()
+	}
+
 	// Configurar graceful shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	<-c
 
 	// Criar um deadline para o shutdown
@@ -83,4 +339,109 @@ func main() {
 		log.Fatalf("Server shutdown error: %v", err)
 	}
 	log.Println("Server stopped")
+
+	if cfg.WorkerInProcess {
+		stopWorker(worker, cfg)
+	}
+}
+
+// runWorker executa apenas o worker até receber o sinal de término.
+func runWorker(worker *jobs.Worker, cfg *config.Config) {
+	worker.Start()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+
+	stopWorker(worker, cfg)
+}
+
+// stopWorker aguarda os trabalhos em execução terminarem (drain), até o limite configurado.
+func stopWorker(worker *jobs.Worker, cfg *config.Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.WorkerDrainSeconds)*time.Second)
+	defer cancel()
+
+	log.Println("Job worker draining...")
+	if err := worker.Stop(ctx); err != nil {
+		log.Printf("Job worker drain incomplete: %v", err)
+		return
+	}
+	log.Println("Job worker stopped")
+}
+
+// encryptionKeys lê as chaves de criptografia da configuração. Em
+// desenvolvimento, sem PII_MASTER_KEY, os dados pessoais ficam em texto puro.
+func encryptionKeys(cfg *config.Config) services.EncryptionKeys {
+	var keys services.EncryptionKeys
+	if cfg.PIIMasterKey == "" {
+		if cfg.Env != "development" {
+			log.Fatal("PII_MASTER_KEY is required")
+		}
+		log.Println("PII_MASTER_KEY not set, personal data will be stored unencrypted")
+		return keys
+	}
+
+	var err error
+	if keys.Master, err = pii.DecodeKey(cfg.PIIMasterKey); err != nil {
+		log.Fatalf("Invalid PII_MASTER_KEY: %v", err)
+	}
+	if cfg.PIIPreviousMasterKey != "" {
+		if keys.Previous, err = pii.DecodeKey(cfg.PIIPreviousMasterKey); err != nil {
+			log.Fatalf("Invalid PII_PREVIOUS_MASTER_KEY: %v", err)
+		}
+	}
+	if cfg.PIIBlindIndexKey == "" {
+		log.Fatal("PII_BLIND_INDEX_KEY is required when PII_MASTER_KEY is set")
+	}
+	if keys.BlindIndex, err = pii.DecodeKey(cfg.PIIBlindIndexKey); err != nil {
+		log.Fatalf("Invalid PII_BLIND_INDEX_KEY: %v", err)
+	}
+	return keys
+}
+
+// runKeyRotation executa a rotação de chaves e encerra o processo.
+func runKeyRotation(encryptionService services.EncryptionService, cfg *config.Config) {
+	if cfg.PIIMasterKey == "" {
+		log.Fatal("PII_MASTER_KEY is required to rotate keys")
+	}
+	if cfg.PIIRotationBatchSize <= 0 {
+		log.Fatal("PII_ROTATION_BATCH must be positive")
+	}
+
+	result, err := encryptionService.Rotate(context.Background())
+	if err != nil {
+		log.Fatalf("Key rotation failed: %v", err)
+	}
+	log.Printf("Key rotation finished: active key %d, %d keys rewrapped, %d values re-encrypted",
+		result.ActiveKeyID, result.Rewrapped, result.Reencrypted)
+}
+
+func workerConfig(cfg *config.Config) jobs.Config {
+	workerCfg := jobs.DefaultConfig()
+	if cfg.WorkerConcurrency > 0 {
+		workerCfg.Concurrency = cfg.WorkerConcurrency
+	}
+	return workerCfg
+}
+
+// newNotifierRegistry configura os canais de notificação. Canais sem
+// configuração usam o LogNotifier, que apenas registra as mensagens.
+func newNotifierRegistry(cfg *config.Config) *notifications.Registry {
+	var email notifications.Notifier = notifications.NewLogNotifier(notifications.ChannelEmail)
+	if cfg.SMTPHost != "" {
+		email = notifications.NewSMTPNotifier(notifications.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	}
+
+	var sms notifications.Notifier = notifications.NewLogNotifier(notifications.ChannelSMS)
+	if cfg.SMSWebhookURL != "" {
+		sms = notifications.NewWebhookNotifier(notifications.ChannelSMS, cfg.SMSWebhookURL, cfg.SMSWebhookToken)
+	}
+
+	return notifications.NewRegistry(email, sms)
 }
@@ -2,7 +2,12 @@
 package auth
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	jwtmiddleware "github.com/auth0/go-jwt-middleware"
 	jwt "github.com/form3tech-oss/jwt-go"
@@ -13,14 +18,33 @@ type Authenticator struct {
 	Domain     string
 	Audience   string
 	middleware *jwtmiddleware.JWTMiddleware
+
+	mu        sync.Mutex
+	certs     map[string]string
+	fetchedAt time.Time
+	// fetching é fechado quando a busca do JWKS em andamento termina.
+	fetching chan struct{}
 }
 
+// jwksMinRefresh é o intervalo mínimo entre buscas do JWKS. Tokens com um kid
+// desconhecido não disparam uma busca por requisição.
+const jwksMinRefresh = time.Minute
+
+// errUnknownKey is returned when the token was signed by a key not in the JWKS.
+var errUnknownKey = errors.New("unable to find appropriate key")
+
 // NewAuthenticator creates a new Authenticator instance configured with Auth0 settings.
 func NewAuthenticator(domain, audience string) *Authenticator {
-	return &Authenticator{
+	a := &Authenticator{
 		Domain:   domain,
 		Audience: audience,
 	}
+	a.middleware = jwtmiddleware.New(jwtmiddleware.Options{
+		ValidationKeyGetter: a.validationKey,
+		SigningMethod:       jwt.SigningMethodRS256,
+		UserProperty:        UserProperty,
+	})
+	return a
 }
 
 // GetMiddleware returns the JWT middleware handler for protecting routes.
@@ -28,8 +52,102 @@ func (a *Authenticator) GetMiddleware() func(http.Handler) http.Handler {
 	return a.middleware.Handler
 }
 
+// validationKey confere audience e issuer antes de devolver a chave pública do Auth0.
+func (a *Authenticator) validationKey(token *jwt.Token) (interface{}, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid claims")
+	}
+
+	if !claims.VerifyAudience(a.Audience, true) {
+		return nil, errors.New("invalid audience")
+	}
+
+	if !claims.VerifyIssuer("https://"+a.Domain+"/", true) {
+		return nil, errors.New("invalid issuer")
+	}
+
+	cert, err := a.getPemCert(token)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM([]byte(cert))
+}
+
+// jwks é o formato do documento publicado em /.well-known/jwks.json pelo Auth0.
+type jwks struct {
+	Keys []struct {
+		Kid string   `json:"kid"`
+		X5c []string `json:"x5c"`
+	} `json:"keys"`
+}
+
+// getPemCert obtém o certificado do kid do token no JWKS endpoint do Auth0. Os
+// certificados ficam em cache por kid; o endpoint só é consultado de novo
+// quando surge um kid novo (rotação de chaves), fora do lock, e requisições
+// simultâneas aguardam a mesma busca.
 func (a *Authenticator) getPemCert(token *jwt.Token) (string, error) {
-	// Implementar a lógica para obter o certificado do JWKS endpoint do Auth0
-	// ...
-	return "", nil
+	kid, _ := token.Header["kid"].(string)
+
+	for {
+		a.mu.Lock()
+		if cert, ok := a.certs[kid]; ok {
+			a.mu.Unlock()
+			return cert, nil
+		}
+		if fetching := a.fetching; fetching != nil {
+			a.mu.Unlock()
+			<-fetching
+			continue
+		}
+		if time.Since(a.fetchedAt) < jwksMinRefresh {
+			a.mu.Unlock()
+			return "", errUnknownKey
+		}
+		done := make(chan struct{})
+		a.fetching = done
+		a.mu.Unlock()
+
+		certs, err := a.fetchCerts()
+
+		a.mu.Lock()
+		if err == nil {
+			a.certs = certs
+			a.fetchedAt = time.Now()
+		}
+		a.fetching = nil
+		close(done)
+		a.mu.Unlock()
+
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// fetchCerts busca o JWKS e retorna os certificados por kid.
+func (a *Authenticator) fetchCerts() (map[string]string, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get("https://" + a.Domain + "/.well-known/jwks.json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks endpoint returned %s", resp.Status)
+	}
+
+	var keys jwks
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return nil, err
+	}
+
+	certs := make(map[string]string, len(keys.Keys))
+	for _, key := range keys.Keys {
+		if len(key.X5c) > 0 {
+			certs[key.Kid] = "-----BEGIN CERTIFICATE-----\n" + key.X5c[0] + "\n-----END CERTIFICATE-----"
+		}
+	}
+	return certs, nil
 }
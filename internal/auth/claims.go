@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"net/http"
+
+	jwt "github.com/form3tech-oss/jwt-go"
+)
+
+// UserProperty é a chave de contexto onde o middleware JWT armazena o token validado.
+const UserProperty = "user"
+
+// RolesClaim é o claim customizado (namespaced) onde o Auth0 envia os papéis do usuário.
+const RolesClaim = "https://kids-api/roles"
+
+// Papéis reconhecidos pela API.
+const (
+	// RoleAdmin identifica os administradores do ministério.
+	RoleAdmin = "admin"
+	// RoleCoordinator identifica coordenadores, autorizados a ver dados sensíveis como restrições de guarda.
+	RoleCoordinator = "coordinator"
+	// RoleMedical identifica a equipe de saúde, autorizada a ver fichas médicas.
+	RoleMedical = "medical"
+	// RoleVolunteer identifica os voluntários das salas (check-in, chamadas, ocorrências).
+	RoleVolunteer = "volunteer"
+)
+
+// StaffRoles são os papéis da equipe. Usuários sem nenhum deles (responsáveis)
+// só acessam o portal /me.
+var StaffRoles = []string{RoleAdmin, RoleCoordinator, RoleMedical, RoleVolunteer}
+
+// ClaimsFromRequest retorna os claims do token JWT validado para a requisição.
+func ClaimsFromRequest(r *http.Request) (jwt.MapClaims, bool) {
+	token, ok := r.Context().Value(UserProperty).(*jwt.Token)
+	if !ok || token == nil {
+		return nil, false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	return claims, ok
+}
+
+// Subject retorna o identificador (claim "sub") do usuário autenticado. Para
+// quiosques, retorna "device:<id>".
+func Subject(r *http.Request) string {
+	if device, ok := DeviceFromRequest(r); ok {
+		return "device:" + device.ID
+	}
+
+	claims, ok := ClaimsFromRequest(r)
+	if !ok {
+		return ""
+	}
+	sub, _ := claims["sub"].(string)
+	return sub
+}
+
+// Roles retorna os papéis do usuário autenticado.
+func Roles(r *http.Request) []string {
+	claims, ok := ClaimsFromRequest(r)
+	if !ok {
+		return nil
+	}
+
+	granted, _ := claims[RolesClaim].([]interface{})
+	roles := make([]string, 0, len(granted))
+	for _, value := range granted {
+		if name, ok := value.(string); ok {
+			roles = append(roles, name)
+		}
+	}
+	return roles
+}
+
+// HasRole verifica se o usuário autenticado possui algum dos papéis informados.
+func HasRole(r *http.Request, roles ...string) bool {
+	for _, name := range Roles(r) {
+		for _, role := range roles {
+			if name == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequireRole retorna um middleware que bloqueia requisições de usuários sem nenhum dos papéis informados.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasRole(r, roles...) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireStaff bloqueia usuários que não fazem parte da equipe.
+func RequireStaff(next http.Handler) http.Handler {
+	return RequireRole(StaffRoles...)(next)
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/eduardohass/kids-api/internal/models"
+)
+
+// DeviceTokenPrefix distingue credenciais de quiosque de JWTs de usuários.
+const DeviceTokenPrefix = "kd_"
+
+type deviceContextKey struct{}
+
+// DeviceVerifier valida credenciais de dispositivos de quiosque.
+type DeviceVerifier interface {
+	VerifyDeviceToken(ctx context.Context, token string) (*models.KioskDevice, error)
+}
+
+// DeviceFromRequest retorna o quiosque autenticado na requisição, se houver.
+func DeviceFromRequest(r *http.Request) (*models.KioskDevice, bool) {
+	device, ok := r.Context().Value(deviceContextKey{}).(*models.KioskDevice)
+	return device, ok && device != nil
+}
+
+// UserOrDevice retorna um middleware que aceita tanto o JWT de um usuário
+// (validado pelo Authenticator) quanto a credencial de um quiosque.
+func (a *Authenticator) UserOrDevice(devices DeviceVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		userHandler := a.GetMiddleware()(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !strings.HasPrefix(token, DeviceTokenPrefix) {
+				userHandler.ServeHTTP(w, r)
+				return
+			}
+
+			device, err := devices.VerifyDeviceToken(r.Context(), token)
+			if err != nil {
+				http.Error(w, "Invalid device credential", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), deviceContextKey{}, device)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UsersOnly bloqueia credenciais de quiosque, que só podem acessar as rotas de quiosque.
+func UsersOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := DeviceFromRequest(r); ok {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// DeviceOrStaff permite quiosques pareados e usuários da equipe; responsáveis
+// não operam o quiosque com o próprio login.
+func DeviceOrStaff(next http.Handler) http.Handler {
+	staffOnly := RequireStaff(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := DeviceFromRequest(r); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		staffOnly.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// TenantsClaim é o claim customizado com os tenants (campi) que o usuário pode acessar.
+const TenantsClaim = "https://kids-api/tenants"
+
+// TenantHeader escolhe o tenant da requisição quando o usuário tem acesso a mais de um.
+const TenantHeader = "X-Tenant-ID"
+
+// ResolveTenant define o tenant da requisição. Quiosques usam o tenant em que
+// foram registrados; usuários, o informado em TenantHeader, que precisa constar
+// em TenantsClaim (o cabeçalho é opcional quando o usuário tem um único tenant).
+// Deve rodar depois da autenticação.
+func ResolveTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested := strings.TrimSpace(r.Header.Get(TenantHeader))
+
+		var tenantID string
+		if device, ok := DeviceFromRequest(r); ok {
+			if requested != "" && requested != device.TenantID {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			tenantID = device.TenantID
+		} else {
+			allowed := userTenants(r)
+			switch {
+			case requested != "":
+				if !containsString(allowed, requested) {
+					http.Error(w, "Forbidden", http.StatusForbidden)
+					return
+				}
+				tenantID = requested
+			case len(allowed) == 1:
+				tenantID = allowed[0]
+			case len(allowed) == 0:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			default:
+				http.Error(w, TenantHeader+" header is required", http.StatusBadRequest)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), tenantID)))
+	})
+}
+
+func userTenants(r *http.Request) []string {
+	claims, ok := ClaimsFromRequest(r)
+	if !ok {
+		return nil
+	}
+
+	values, _ := claims[TenantsClaim].([]interface{})
+	tenants := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok && id != "" {
+			tenants = append(tenants, id)
+		}
+	}
+	return tenants
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
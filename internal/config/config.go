@@ -4,6 +4,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config armazena todas as configurações da aplicação
@@ -14,6 +15,48 @@ type Config struct {
 	Auth0Audience  string
 	Env            string
 	MigrationsPath string
+	// PurgeRetentionDays é o tempo mínimo que um registro excluído logicamente
+	// deve permanecer no banco antes de poder ser removido definitivamente.
+	PurgeRetentionDays int
+	// PreCheckInSecret assina os QR codes de pré-check-in (HMAC-SHA256).
+	PreCheckInSecret     string
+	PreCheckInTTLMinutes int
+	// Canais de notificação. Canais sem configuração apenas registram as
+	// mensagens no log.
+	SMTPHost        string
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPFrom        string
+	SMSWebhookURL   string
+	SMSWebhookToken string
+	// WorkerInProcess roda o worker da fila junto com o servidor HTTP. Desative
+	// ao rodar "kids-api worker" em um processo separado.
+	WorkerInProcess    bool
+	WorkerConcurrency  int
+	WorkerDrainSeconds int
+	// Criptografia de dados pessoais. PIIMasterKey (base64, 32 bytes) cifra as
+	// chaves de dados guardadas no banco; PIIPreviousMasterKey permite ler
+	// chaves ainda cifradas pela chave mestra anterior durante a rotação.
+	// PIIBlindIndexKey assina os índices cegos usados nas buscas.
+	PIIMasterKey         string
+	PIIPreviousMasterKey string
+	PIIBlindIndexKey     string
+	PIIRotationBatchSize int
+	// AccessHighVolumeEntities é o número de crianças, famílias ou responsáveis
+	// distintos lidos por uma pessoa no período a partir do qual o relatório de
+	// acessos aponta leitura em massa (0 desativa).
+	AccessHighVolumeEntities int
+	// RetentionIntervalHours é o intervalo entre as execuções periódicas das
+	// regras de retenção de cada campus.
+	RetentionIntervalHours int
+	// MetricsToken é exigido como token Bearer em /metrics. É obrigatório fora
+	// do ambiente de desenvolvimento, onde o endpoint pode ficar sem token.
+	MetricsToken string
+	// PhotoHosts são os hosts (separados por vírgula) de onde a exportação do
+	// titular baixa as fotos das crianças, sempre por HTTPS. Sem eles, a
+	// exportação traz apenas o endereço da foto.
+	PhotoHosts []string
 }
 
 // Load carrega as configurações das variáveis de ambiente
@@ -25,6 +68,34 @@ func Load() *Config {
 		Auth0Audience:  getEnv("AUTH0_AUDIENCE", ""),
 		Env:            getEnv("ENV", "development"),
 		MigrationsPath: getEnv("MIGRATIONS_PATH", "./internal/migrations"),
+
+		PurgeRetentionDays: getEnvInt("PURGE_RETENTION_DAYS", 30),
+
+		PreCheckInSecret:     getEnv("PRECHECKIN_SECRET", ""),
+		PreCheckInTTLMinutes: getEnvInt("PRECHECKIN_TTL_MINUTES", 180),
+
+		SMTPHost:        getEnv("SMTP_HOST", ""),
+		SMTPPort:        getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:    getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:    getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:        getEnv("SMTP_FROM", ""),
+		SMSWebhookURL:   getEnv("SMS_WEBHOOK_URL", ""),
+		SMSWebhookToken: getEnv("SMS_WEBHOOK_TOKEN", ""),
+
+		WorkerInProcess:    getEnvBool("WORKER_IN_PROCESS", true),
+		WorkerConcurrency:  getEnvInt("WORKER_CONCURRENCY", 4),
+		WorkerDrainSeconds: getEnvInt("WORKER_DRAIN_SECONDS", 30),
+
+		PIIMasterKey:         getEnv("PII_MASTER_KEY", ""),
+		PIIPreviousMasterKey: getEnv("PII_PREVIOUS_MASTER_KEY", ""),
+		PIIBlindIndexKey:     getEnv("PII_BLIND_INDEX_KEY", ""),
+		PIIRotationBatchSize: getEnvInt("PII_ROTATION_BATCH", 500),
+
+		AccessHighVolumeEntities: getEnvInt("ACCESS_HIGH_VOLUME_ENTITIES", 50),
+		RetentionIntervalHours:   getEnvInt("RETENTION_INTERVAL_HOURS", 24),
+
+		MetricsToken: getEnv("METRICS_TOKEN", ""),
+		PhotoHosts:   getEnvList("PHOTO_HOSTS"),
 	}
 }
 
@@ -43,3 +114,21 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	strValue := getEnv(key, "")
+	if value, err := strconv.ParseBool(strValue); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
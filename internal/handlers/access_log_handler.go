@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// AccessReasonHeader informa por que o cliente está lendo dados sensíveis
+// (um dos códigos de models.AccessReasons).
+const AccessReasonHeader = "X-Access-Reason"
+
+// AccessLogHandler records reads of sensitive data and serves the access log.
+type AccessLogHandler struct {
+	service services.AccessLogService
+}
+
+// NewAccessLogHandler creates a new AccessLogHandler instance.
+func NewAccessLogHandler(service services.AccessLogService) *AccessLogHandler {
+	return &AccessLogHandler{
+		service: service,
+	}
+}
+
+// Accessor identifica, no contexto da requisição, quem lê os dados e o motivo
+// informado, para que os serviços registrem as leituras. Sem o cabeçalho, o
+// motivo fica como não informado; códigos desconhecidos são recusados.
+func (h *AccessLogHandler) Accessor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reason := strings.TrimSpace(r.Header.Get(AccessReasonHeader))
+		if reason != "" && !services.ValidAccessReason(reason) {
+			http.Error(w, "invalid "+AccessReasonHeader+" header", http.StatusBadRequest)
+			return
+		}
+
+		endpoint := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				endpoint = template
+			}
+		}
+
+		accessor := models.Accessor{
+			Actor:    auth.Subject(r),
+			Roles:    auth.Roles(r),
+			Reason:   reason,
+			Endpoint: r.Method + " " + endpoint,
+		}
+		next.ServeHTTP(w, r.WithContext(services.WithAccessor(r.Context(), accessor)))
+	})
+}
+
+// Sensitive registra a leitura da entidade {id} da rota antes de executar o
+// handler: se o registro falhar, os dados não são devolvidos.
+func (h *AccessLogHandler) Sensitive(resource, entityType string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.service.RecordAccess(r.Context(), resource, entityType, mux.Vars(r)["id"]); err != nil {
+			http.Error(w, err.Error(), serviceErrorStatus(err))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// List handles GET /admin/access-log; ?actor=, ?resource=, ?entity_type=,
+// ?entity_id=, ?since= and ?until= (RFC 3339) filter the entries.
+func (h *AccessLogHandler) List(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	since, until, err := parsePeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 50
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	filter := models.AccessLogFilter{
+		Actor:      queryParams.Get("actor"),
+		Resource:   queryParams.Get("resource"),
+		EntityType: queryParams.Get("entity_type"),
+		EntityID:   queryParams.Get("entity_id"),
+		Since:      since,
+		Until:      until,
+	}
+
+	entries, err := h.service.List(r.Context(), filter, page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(entries)
+}
+
+// Report handles GET /admin/access-log/report; ?since= and ?until= (RFC 3339)
+// define the period, by default the last seven days.
+func (h *AccessLogHandler) Report(w http.ResponseWriter, r *http.Request) {
+	since, until, err := parsePeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.Report(r.Context(), since, until)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
+
+// parsePeriod lê os parâmetros since e until; ausentes, ficam zerados.
+func parsePeriod(r *http.Request) (time.Time, time.Time, error) {
+	var since, until time.Time
+	var err error
+
+	if value := r.URL.Query().Get("since"); value != "" {
+		if since, err = time.Parse(time.RFC3339, value); err != nil {
+			return since, until, err
+		}
+	}
+	if value := r.URL.Query().Get("until"); value != "" {
+		if until, err = time.Parse(time.RFC3339, value); err != nil {
+			return since, until, err
+		}
+	}
+	return since, until, nil
+}
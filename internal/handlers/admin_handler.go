@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// AdminHandler handles HTTP requests restricted to administrators.
+type AdminHandler struct {
+	childService     services.ChildService
+	caretakerService services.CaretakerService
+	volunteerService services.VolunteerService
+	groupService     services.GroupService
+	purgeRetention   time.Duration
+}
+
+// NewAdminHandler creates a new AdminHandler instance.
+func NewAdminHandler(
+	childService services.ChildService,
+	caretakerService services.CaretakerService,
+	volunteerService services.VolunteerService,
+	groupService services.GroupService,
+	purgeRetention time.Duration,
+) *AdminHandler {
+	return &AdminHandler{
+		childService:     childService,
+		caretakerService: caretakerService,
+		volunteerService: volunteerService,
+		groupService:     groupService,
+		purgeRetention:   purgeRetention,
+	}
+}
+
+// PurgeChild handles DELETE requests to permanently remove a soft-deleted child.
+func (h *AdminHandler) PurgeChild(w http.ResponseWriter, r *http.Request) {
+	h.purge(w, r, h.childService.PurgeChild)
+}
+
+// PurgeCaretaker handles DELETE requests to permanently remove a soft-deleted caretaker.
+func (h *AdminHandler) PurgeCaretaker(w http.ResponseWriter, r *http.Request) {
+	h.purge(w, r, h.caretakerService.PurgeCaretaker)
+}
+
+// PurgeVolunteer handles DELETE requests to permanently remove a soft-deleted volunteer.
+func (h *AdminHandler) PurgeVolunteer(w http.ResponseWriter, r *http.Request) {
+	h.purge(w, r, h.volunteerService.PurgeVolunteer)
+}
+
+// PurgeGroup handles DELETE requests to permanently remove a soft-deleted group.
+func (h *AdminHandler) PurgeGroup(w http.ResponseWriter, r *http.Request) {
+	h.purge(w, r, h.groupService.PurgeGroup)
+}
+
+func (h *AdminHandler) purge(
+	w http.ResponseWriter,
+	r *http.Request,
+	purgeFn func(ctx context.Context, id string, retention time.Duration) error,
+) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := purgeFn(r.Context(), id, h.purgeRetention); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, repository.ErrPurgeNotAllowed):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
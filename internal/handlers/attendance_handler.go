@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// AttendanceHandler handles HTTP requests related to check-in and check-out.
+type AttendanceHandler struct {
+	service services.AttendanceService
+}
+
+// NewAttendanceHandler creates a new AttendanceHandler instance.
+func NewAttendanceHandler(service services.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{
+		service: service,
+	}
+}
+
+// CheckIn handles POST requests to check in one or more children.
+func (h *AttendanceHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	var req models.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	records, err := h.service.CheckIn(r.Context(), req, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(records)
+}
+
+// CheckOut handles POST requests to release a checked-in child.
+func (h *AttendanceHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req models.CheckOutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	record, err := h.service.CheckOut(r.Context(), vars["id"], req, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(record)
+}
+
+// Get handles GET requests to retrieve an attendance record by ID.
+func (h *AttendanceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	record, err := h.service.GetAttendance(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(record)
+}
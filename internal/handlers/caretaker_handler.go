@@ -3,9 +3,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
 	"github.com/eduardohass/kids-api/internal/services"
 	"github.com/gorilla/mux"
 )
@@ -31,7 +33,7 @@ func (h *CaretakerHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.CreateCaretaker(r.Context(), &caretaker); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
@@ -50,6 +52,7 @@ func (h *CaretakerHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, caretaker.Version)
 	json.NewEncoder(w).Encode(caretaker)
 }
 
@@ -65,11 +68,63 @@ func (h *CaretakerHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	caretaker.ID = id
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+	caretaker.Version = version
+
 	if err := h.service.UpdateCaretaker(r.Context(), &caretaker); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, caretaker.Version)
+	json.NewEncoder(w).Encode(caretaker)
+}
+
+// Patch handles PATCH requests applying a JSON Merge Patch to a caretaker.
+func (h *CaretakerHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if !isMergePatch(r) {
+		http.Error(w, errUnsupportedPatch.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	current, err := h.service.GetCaretaker(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
+	if version != 0 && version != current.Version {
+		http.Error(w, repository.ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	var caretaker models.Caretaker
+	if err := applyMergePatch(current, r.Body, &caretaker); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	caretaker.ID = id
+	caretaker.Version = current.Version
+	if err := h.service.UpdateCaretaker(r.Context(), &caretaker); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, caretaker.Version)
 	json.NewEncoder(w).Encode(caretaker)
 }
 
@@ -86,6 +141,23 @@ func (h *CaretakerHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Restore handles POST requests to restore a soft-deleted caretaker.
+func (h *CaretakerHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.service.RestoreCaretaker(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // List handles GET requests to retrieve a list of caretakers.
 func (h *CaretakerHandler) List(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implementar paginação e filtros a partir dos query parameters
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// maxDeviceLength é o tamanho da coluna consent_records.device.
+const maxDeviceLength = 255
+
+// ConsentHandler handles the consent documents and the caretakers' consents.
+type ConsentHandler struct {
+	service services.ConsentService
+}
+
+// NewConsentHandler creates a new ConsentHandler instance.
+func NewConsentHandler(service services.ConsentService) *ConsentHandler {
+	return &ConsentHandler{
+		service: service,
+	}
+}
+
+// revokeConsentRequest is the optional body of the revoke endpoints.
+type revokeConsentRequest struct {
+	Reason string `json:"reason"`
+}
+
+// PublishDocument handles POST /admin/consent-documents.
+func (h *ConsentHandler) PublishDocument(w http.ResponseWriter, r *http.Request) {
+	var document models.ConsentDocument
+	if err := json.NewDecoder(r.Body).Decode(&document); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.PublishDocument(r.Context(), &document, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(document)
+}
+
+// ListDocuments handles GET /consent-documents; ?kind= filters by kind.
+func (h *ConsentHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	documents, err := h.service.ListDocuments(r.Context(), r.URL.Query().Get("kind"))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(documents)
+}
+
+// GetDocument handles GET /consent-documents/{id}.
+func (h *ConsentHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	document, err := h.service.GetDocument(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(document)
+}
+
+// List handles GET /children/{id}/consents.
+func (h *ConsentHandler) List(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	records, err := h.service.ListConsents(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(records)
+}
+
+// Record handles POST /children/{id}/consents (aceite colhido pela equipe).
+func (h *ConsentHandler) Record(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var record models.ConsentRecord
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	record.ChildID = vars["id"]
+	setConsentOrigin(r, &record)
+	if err := h.service.RecordConsent(r.Context(), &record, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(record)
+}
+
+// Revoke handles POST /consents/{id}/revoke.
+func (h *ConsentHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req revokeConsentRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	record, err := h.service.RevokeConsent(r.Context(), vars["id"], auth.Subject(r), req.Reason)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(record)
+}
+
+// ListMine handles GET /me/children/{id}/consents.
+func (h *ConsentHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	records, err := h.service.ListMyConsents(r.Context(), auth.Subject(r), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(records)
+}
+
+// RecordMine handles POST /me/children/{id}/consents.
+func (h *ConsentHandler) RecordMine(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var record models.ConsentRecord
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	setConsentOrigin(r, &record)
+	if err := h.service.RecordMyConsent(r.Context(), auth.Subject(r), vars["id"], &record); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(record)
+}
+
+// RevokeMine handles POST /me/consents/{id}/revoke.
+func (h *ConsentHandler) RevokeMine(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req revokeConsentRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	record, err := h.service.RevokeMyConsent(r.Context(), auth.Subject(r), vars["id"], req.Reason)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(record)
+}
+
+// setConsentOrigin registra de onde veio o aceite (IP e navegador/dispositivo).
+// O IP serve apenas como evidência; por isso o X-Forwarded-For do proxy é aceito.
+func setConsentOrigin(r *http.Request, record *models.ConsentRecord) {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	record.IPAddress = ip
+
+	device := r.UserAgent()
+	if len(device) > maxDeviceLength {
+		device = device[:maxDeviceLength]
+	}
+	record.Device = device
+}
@@ -3,21 +3,32 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/eduardohass/kids-api/internal/auth"
 	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
 	"github.com/eduardohass/kids-api/internal/services"
 	"github.com/gorilla/mux"
 )
 
 type ChildHandler struct {
-	childService services.ChildService
+	childService       services.ChildService
+	restrictionService services.RestrictionService
+	consentService     services.ConsentService
 }
 
-func NewChildHandler(childService services.ChildService) *ChildHandler {
+func NewChildHandler(
+	childService services.ChildService,
+	restrictionService services.RestrictionService,
+	consentService services.ConsentService,
+) *ChildHandler {
 	return &ChildHandler{
-		childService: childService,
+		childService:       childService,
+		restrictionService: restrictionService,
+		consentService:     consentService,
 	}
 }
 
@@ -31,7 +42,7 @@ func (h *ChildHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	err = h.childService.CreateChild(r.Context(), &child)
 	if err != nil {
-		http.Error(w, "Error creating child: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error creating child: "+err.Error(), serviceErrorStatus(err))
 		return
 	}
 
@@ -46,6 +57,10 @@ func (h *ChildHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	child, err := h.childService.GetChild(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "Child not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, "Error fetching child: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -55,6 +70,21 @@ func (h *ChildHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Alerta discreto de restrição de guarda, apenas para papéis autorizados
+	if auth.HasRole(r, auth.RoleAdmin, auth.RoleCoordinator) {
+		child.CustodyAlert, err = h.restrictionService.HasActiveRestriction(r.Context(), id)
+		if err != nil {
+			http.Error(w, "Error fetching child: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.consentService.ApplyStatus(r.Context(), child); err != nil {
+		http.Error(w, "Error fetching child: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	setETag(w, child.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(child)
 }
@@ -71,12 +101,65 @@ func (h *ChildHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	child.ID = id
+	child.Version, err = parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
 	err = h.childService.UpdateChild(r.Context(), &child)
 	if err != nil {
-		http.Error(w, "Error updating child: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error updating child: "+err.Error(), serviceErrorStatus(err))
 		return
 	}
 
+	setETag(w, child.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(child)
+}
+
+// Patch aplica um JSON Merge Patch sobre a criança atual, preservando os
+// campos que não foram enviados.
+func (h *ChildHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if !isMergePatch(r) {
+		http.Error(w, errUnsupportedPatch.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	current, err := h.childService.GetChild(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Error fetching child: "+err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	if version != 0 && version != current.Version {
+		http.Error(w, repository.ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	var child models.Child
+	if err := applyMergePatch(current, r.Body, &child); err != nil {
+		http.Error(w, "Error applying patch: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	child.ID = id
+	child.Version = current.Version
+	if err := h.childService.UpdateChild(r.Context(), &child); err != nil {
+		http.Error(w, "Error updating child: "+err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, child.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(child)
 }
@@ -94,6 +177,22 @@ func (h *ChildHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *ChildHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.childService.RestoreChild(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "Error restoring child: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error restoring child: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *ChildHandler) List(w http.ResponseWriter, r *http.Request) {
 	queryParams := r.URL.Query()
 
@@ -130,6 +229,11 @@ func (h *ChildHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.consentService.ApplyStatus(r.Context(), children...); err != nil {
+		http.Error(w, "Error listing children: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(children)
 }
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// DataSubjectHandler handles the export and erasure requests of caretakers.
+type DataSubjectHandler struct {
+	service services.DataSubjectService
+}
+
+// NewDataSubjectHandler creates a new DataSubjectHandler instance.
+func NewDataSubjectHandler(service services.DataSubjectService) *DataSubjectHandler {
+	return &DataSubjectHandler{
+		service: service,
+	}
+}
+
+// Create handles POST /admin/data-requests. O pedido é executado em segundo
+// plano; a resposta traz o pedido ainda pendente.
+func (h *DataSubjectHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request models.DataSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateRequest(r.Context(), &request, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(request)
+}
+
+// List handles GET /admin/data-requests; ?caretaker_id= filters by caretaker.
+func (h *DataSubjectHandler) List(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	requests, err := h.service.ListRequests(r.Context(), queryParams.Get("caretaker_id"), page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(requests)
+}
+
+// Get handles GET /admin/data-requests/{id}.
+func (h *DataSubjectHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	request, err := h.service.GetRequest(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(request)
+}
+
+// Archive handles GET /admin/data-requests/{id}/archive.
+func (h *DataSubjectHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	archive, err := h.service.GetArchive(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="data-export-%s.zip"`, vars["id"]))
+	w.Write(archive)
+}
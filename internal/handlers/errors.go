@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/precheckin"
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/eduardohass/kids-api/internal/services"
+)
+
+// serviceErrorStatus traduz erros retornados pelos serviços em status HTTP.
+func serviceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, repository.ErrAlreadyCheckedIn),
+		errors.Is(err, repository.ErrAlreadyCheckedOut),
+		errors.Is(err, repository.ErrAlreadyRedeemed),
+		errors.Is(err, repository.ErrRollCallInProgress),
+		errors.Is(err, repository.ErrConsentRevoked),
+		errors.Is(err, repository.ErrAlreadyVerified),
+		errors.Is(err, services.ErrRollCallClosed),
+		errors.Is(err, repository.ErrRoomFull),
+		errors.Is(err, services.ErrRoomFull),
+		errors.Is(err, services.ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrNoContact),
+		errors.Is(err, services.ErrConsentExpired):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrNoCaretakerProfile),
+		errors.Is(err, services.ErrNotDoseVolunteer):
+		return http.StatusForbidden
+	case errors.Is(err, precheckin.ErrExpiredToken):
+		return http.StatusGone
+	case errors.Is(err, precheckin.ErrInvalidToken),
+		errors.Is(err, services.ErrInvalidSecurityCode),
+		errors.Is(err, services.ErrPickupNotAuthorized),
+		errors.Is(err, services.ErrPickupRestricted):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errInvalidIfMatch is returned when the If-Match header cannot be parsed.
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// errMissingIfMatch is returned when an update is sent without If-Match.
+var errMissingIfMatch = errors.New("If-Match header is required")
+
+// setETag escreve o ETag derivado da versão do recurso.
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch extrai a versão esperada do cabeçalho If-Match, obrigatório em
+// PUT e PATCH. "*" desativa a verificação de versão explicitamente e retorna 0.
+func parseIfMatch(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, errMissingIfMatch
+	}
+	if value == "*" {
+		return 0, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, errInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
+
+// parseOptionalIfMatch é o parseIfMatch das ações em que a verificação de versão
+// é opcional: sem o cabeçalho, retorna 0.
+func parseOptionalIfMatch(r *http.Request) (int, error) {
+	if strings.TrimSpace(r.Header.Get("If-Match")) == "" {
+		return 0, nil
+	}
+	return parseIfMatch(r)
+}
+
+// ifMatchStatus traduz os erros de parseIfMatch: sem o cabeçalho, 428.
+func ifMatchStatus(err error) int {
+	if errors.Is(err, errMissingIfMatch) {
+		return http.StatusPreconditionRequired
+	}
+	return http.StatusBadRequest
+}
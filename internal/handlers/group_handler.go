@@ -3,9 +3,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
 	"github.com/eduardohass/kids-api/internal/services"
 	"github.com/gorilla/mux"
 )
@@ -28,7 +30,7 @@ func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.CreateGroup(r.Context(), &group); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
@@ -46,6 +48,7 @@ func (h *GroupHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, group.Version)
 	json.NewEncoder(w).Encode(group)
 }
 
@@ -60,11 +63,62 @@ func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	group.ID = id
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+	group.Version = version
+
 	if err := h.service.UpdateGroup(r.Context(), &group); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, group.Version)
+	json.NewEncoder(w).Encode(group)
+}
+
+func (h *GroupHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if !isMergePatch(r) {
+		http.Error(w, errUnsupportedPatch.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	current, err := h.service.GetGroup(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
+	if version != 0 && version != current.Version {
+		http.Error(w, repository.ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	var group models.Group
+	if err := applyMergePatch(current, r.Body, &group); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	group.ID = id
+	group.Version = current.Version
+	if err := h.service.UpdateGroup(r.Context(), &group); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, group.Version)
 	json.NewEncoder(w).Encode(group)
 }
 
@@ -80,6 +134,22 @@ func (h *GroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *GroupHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.service.RestoreGroup(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *GroupHandler) List(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implementar paginação e filtros a partir dos query parameters
 	page := 1
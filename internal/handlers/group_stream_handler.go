@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// sseHeartbeat mantém a conexão viva através de proxies e balanceadores.
+const sseHeartbeat = 15 * time.Second
+
+// GroupStreamHandler streams room events as Server-Sent Events.
+type GroupStreamHandler struct {
+	service services.GroupStreamService
+}
+
+// NewGroupStreamHandler creates a new GroupStreamHandler instance.
+func NewGroupStreamHandler(service services.GroupStreamService) *GroupStreamHandler {
+	return &GroupStreamHandler{
+		service: service,
+	}
+}
+
+// Stream handles GET /groups/{id}/stream. Clients reconnecting with the
+// Last-Event-ID header (or the last_event_id query parameter, for clients that
+// cannot set headers) first receive the events they missed.
+func (h *GroupStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	groupID := vars["id"]
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	lastID := lastEventID(r)
+
+	// Inscreve antes do replay para não perder eventos publicados entre os dois.
+	events, unsubscribe := h.service.Subscribe(groupID)
+	defer unsubscribe()
+
+	backlog, err := h.service.Replay(r.Context(), groupID, lastID)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	// A conexão SSE dura mais que o WriteTimeout do servidor.
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range backlog {
+		writeSSE(w, event)
+		lastID = event.ID
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				// Cliente lento ou servidor encerrando: o cliente reconecta
+				// com Last-Event-ID e recupera o que faltou.
+				return
+			}
+			if event.ID <= lastID {
+				continue
+			}
+			writeSSE(w, event)
+			lastID = event.ID
+			flusher.Flush()
+		}
+	}
+}
+
+func lastEventID(r *http.Request) int64 {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func writeSSE(w http.ResponseWriter, event *models.GroupEvent) {
+	data, _ := json.Marshal(event)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// HouseholdHandler handles HTTP requests related to household operations.
+type HouseholdHandler struct {
+	service services.HouseholdService
+}
+
+// NewHouseholdHandler creates a new HouseholdHandler instance.
+func NewHouseholdHandler(service services.HouseholdService) *HouseholdHandler {
+	return &HouseholdHandler{
+		service: service,
+	}
+}
+
+// Create handles POST requests to create a new household.
+func (h *HouseholdHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var household models.Household
+	if err := json.NewDecoder(r.Body).Decode(&household); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateHousehold(r.Context(), &household); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(household)
+}
+
+// Get handles GET requests returning the household with its full family tree.
+func (h *HouseholdHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	household, err := h.service.GetHousehold(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, household.Version)
+	json.NewEncoder(w).Encode(household)
+}
+
+// Update handles PUT requests to update an existing household.
+func (h *HouseholdHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var household models.Household
+	if err := json.NewDecoder(r.Body).Decode(&household); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	household.ID = id
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+	household.Version = version
+
+	if err := h.service.UpdateHousehold(r.Context(), &household); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, household.Version)
+	json.NewEncoder(w).Encode(household)
+}
+
+// Delete handles DELETE requests to remove a household.
+func (h *HouseholdHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.service.DeleteHousehold(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddChild handles PUT requests linking a child to the household.
+func (h *HouseholdHandler) AddChild(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.AddChild(r.Context(), vars["id"], vars["childId"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddCaretaker handles PUT requests linking a caretaker to the household.
+func (h *HouseholdHandler) AddCaretaker(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.AddCaretaker(r.Context(), vars["id"], vars["caretakerId"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckIn handles POST requests checking in all (or the listed) children of the household.
+func (h *HouseholdHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req models.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	records, err := h.service.CheckIn(r.Context(), vars["id"], req, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(records)
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// IncidentHandler handles HTTP requests related to incident and injury reports.
+type IncidentHandler struct {
+	service services.IncidentService
+}
+
+// NewIncidentHandler creates a new IncidentHandler instance.
+func NewIncidentHandler(service services.IncidentService) *IncidentHandler {
+	return &IncidentHandler{
+		service: service,
+	}
+}
+
+// Create handles POST requests opening a draft incident report.
+func (h *IncidentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var incident models.Incident
+	if err := json.NewDecoder(r.Body).Decode(&incident); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateIncident(r.Context(), &incident, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, incident.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(incident)
+}
+
+// Get handles GET requests to retrieve an incident report.
+func (h *IncidentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	incident, err := h.service.GetIncident(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, incident.Version)
+	json.NewEncoder(w).Encode(incident)
+}
+
+// List handles GET requests listing incident reports, filtered by child, group or status.
+func (h *IncidentHandler) List(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	filter := make(map[string]interface{})
+	for _, key := range []string{"child_id", "group_id", "status"} {
+		if value := queryParams.Get(key); value != "" {
+			filter[key] = value
+		}
+	}
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	incidents, err := h.service.ListIncidents(r.Context(), filter, page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(incidents)
+}
+
+// Update handles PUT requests editing a draft report.
+func (h *IncidentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var incident models.Incident
+	if err := json.NewDecoder(r.Body).Decode(&incident); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	incident.ID = vars["id"]
+	incident.Version, err = parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	if err := h.service.UpdateIncident(r.Context(), &incident); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, incident.Version)
+	json.NewEncoder(w).Encode(incident)
+}
+
+// Submit handles POST /incidents/{id}/submit.
+func (h *IncidentHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, func(id string, version int) (*models.Incident, error) {
+		return h.service.Submit(r.Context(), id, version, auth.Subject(r))
+	})
+}
+
+// Review handles POST /incidents/{id}/review, the reviewer sign-off.
+func (h *IncidentHandler) Review(w http.ResponseWriter, r *http.Request) {
+	var review models.IncidentReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.transition(w, r, func(id string, version int) (*models.Incident, error) {
+		return h.service.Review(r.Context(), id, version, review, auth.Subject(r))
+	})
+}
+
+// Close handles POST /incidents/{id}/close.
+func (h *IncidentHandler) Close(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, func(id string, version int) (*models.Incident, error) {
+		return h.service.Close(r.Context(), id, version, auth.Subject(r))
+	})
+}
+
+func (h *IncidentHandler) transition(w http.ResponseWriter, r *http.Request, apply func(id string, version int) (*models.Incident, error)) {
+	vars := mux.Vars(r)
+
+	version, err := parseOptionalIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	incident, err := apply(vars["id"], version)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, incident.Version)
+	json.NewEncoder(w).Encode(incident)
+}
+
+// Acknowledge handles POST /incidents/{id}/acknowledge, recording the
+// acknowledgement of a caretaker in person.
+func (h *IncidentHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req models.IncidentAcknowledgement
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	incident, err := h.service.Acknowledge(r.Context(), vars["id"], req.CaretakerID, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, incident.Version)
+	json.NewEncoder(w).Encode(incident)
+}
+
+// ListMine handles GET /me/incidents.
+func (h *IncidentHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	incidents, err := h.service.ListForCaretaker(r.Context(), auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(incidents)
+}
+
+// AcknowledgeMine handles POST /me/incidents/{id}/acknowledge.
+func (h *IncidentHandler) AcknowledgeMine(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	incident, err := h.service.AcknowledgeAsCaretaker(r.Context(), vars["id"], auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(incident)
+}
+
+// ExportPDF handles GET /incidents/{id}/pdf.
+func (h *IncidentHandler) ExportPDF(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	// Gera em memória para poder responder com erro antes de enviar o corpo.
+	var buf bytes.Buffer
+	if err := h.service.ExportPDF(r.Context(), vars["id"], &buf); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="incident-`+vars["id"]+`.pdf"`)
+	buf.WriteTo(w)
+}
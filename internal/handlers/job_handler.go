@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/jobs"
+	"github.com/gorilla/mux"
+)
+
+// JobHandler exposes the job queue to administrators, mainly to inspect and
+// retry dead-lettered jobs.
+type JobHandler struct {
+	queue *jobs.Queue
+}
+
+// NewJobHandler creates a new JobHandler instance.
+func NewJobHandler(queue *jobs.Queue) *JobHandler {
+	return &JobHandler{
+		queue: queue,
+	}
+}
+
+// List handles GET /admin/jobs?status= requests.
+func (h *JobHandler) List(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	pageSize := 50
+
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	list, err := h.queue.List(r.Context(), queryParams.Get("status"), pageSize, (page-1)*pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(list)
+}
+
+// Get handles GET /admin/jobs/{id}.
+func (h *JobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	job, err := h.queue.Get(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(job)
+}
+
+// Retry handles POST /admin/jobs/{id}/retry, moving a dead job back to the queue.
+func (h *JobHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.queue.Retry(r.Context(), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
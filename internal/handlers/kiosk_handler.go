@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// Limites do pareamento: o código tem 8 dígitos e vale 15 minutos, então
+// poucas tentativas por minuto tornam a adivinhação inviável.
+const (
+	kioskPairAttemptsPerClient = 5
+	kioskPairAttemptsTotal     = 30
+	kioskPairAttemptWindow     = time.Minute
+)
+
+// KioskHandler handles kiosk registration, pairing and the kiosk check-in flow.
+type KioskHandler struct {
+	service          services.KioskService
+	householdService services.HouseholdService
+}
+
+// NewKioskHandler creates a new KioskHandler instance.
+func NewKioskHandler(service services.KioskService, householdService services.HouseholdService) *KioskHandler {
+	return &KioskHandler{
+		service:          service,
+		householdService: householdService,
+	}
+}
+
+// Register handles POST /admin/kiosks, returning the one-time pairing code.
+func (h *KioskHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var device models.KioskDevice
+	if err := json.NewDecoder(r.Body).Decode(&device); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	device.CreatedBy = auth.Subject(r)
+	registration, err := h.service.Register(r.Context(), &device)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(registration)
+}
+
+// List handles GET /admin/kiosks.
+func (h *KioskHandler) List(w http.ResponseWriter, r *http.Request) {
+	devices, err := h.service.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(devices)
+}
+
+// Revoke handles DELETE /admin/kiosks/{id}, invalidating the device credential.
+func (h *KioskHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.Revoke(r.Context(), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Pair handles POST /kiosk/pair, exchanging a pairing code for the device credential.
+func (h *KioskHandler) Pair(w http.ResponseWriter, r *http.Request) {
+	var req models.KioskPairRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	credential, err := h.service.Pair(r.Context(), req.PairingCode)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(credential)
+}
+
+// Search handles GET /kiosk/search?phone= requests.
+func (h *KioskHandler) Search(w http.ResponseWriter, r *http.Request) {
+	families, err := h.service.SearchFamilies(r.Context(), r.URL.Query().Get("phone"))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(families)
+}
+
+// CheckIn handles POST /kiosk/households/{id}/checkin.
+func (h *KioskHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req models.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.LocationID = checkInLocation(r, req.LocationID)
+	records, err := h.householdService.CheckIn(r.Context(), vars["id"], req, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(records)
+}
+
+// checkInLocation retorna o local do check-in: quiosques sempre usam o local
+// para o qual foram cadastrados, ignorando o valor enviado.
+func checkInLocation(r *http.Request, requested string) string {
+	if device, ok := auth.DeviceFromRequest(r); ok {
+		return device.LocationID
+	}
+	return requested
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// LocationHandler handles locations, rooms and the per-event room assignments.
+type LocationHandler struct {
+	service services.LocationService
+}
+
+// NewLocationHandler creates a new LocationHandler instance.
+func NewLocationHandler(service services.LocationService) *LocationHandler {
+	return &LocationHandler{
+		service: service,
+	}
+}
+
+// CreateLocation handles POST /locations.
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var location models.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateLocation(r.Context(), &location); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(location)
+}
+
+// GetLocation handles GET /locations/{id}.
+func (h *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	location, err := h.service.GetLocation(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, location.Version)
+	json.NewEncoder(w).Encode(location)
+}
+
+// UpdateLocation handles PUT /locations/{id}.
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var location models.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	location.ID = vars["id"]
+	location.Version, err = parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	if err := h.service.UpdateLocation(r.Context(), &location); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, location.Version)
+	json.NewEncoder(w).Encode(location)
+}
+
+// DeleteLocation handles DELETE /locations/{id}.
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.DeleteLocation(r.Context(), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListLocations handles GET /locations.
+func (h *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	locations, err := h.service.ListLocations(r.Context(), page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(locations)
+}
+
+// CreateRoom handles POST /locations/{id}/rooms.
+func (h *LocationHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var room models.Room
+	if err := json.NewDecoder(r.Body).Decode(&room); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	room.LocationID = vars["id"]
+	if err := h.service.CreateRoom(r.Context(), &room); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(room)
+}
+
+// ListRooms handles GET /locations/{id}/rooms.
+func (h *LocationHandler) ListRooms(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rooms, err := h.service.ListRooms(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(rooms)
+}
+
+// GetRoom handles GET /rooms/{id}.
+func (h *LocationHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	room, err := h.service.GetRoom(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, room.Version)
+	json.NewEncoder(w).Encode(room)
+}
+
+// UpdateRoom handles PUT /rooms/{id}.
+func (h *LocationHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var room models.Room
+	if err := json.NewDecoder(r.Body).Decode(&room); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	room.ID = vars["id"]
+	room.Version, err = parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	if err := h.service.UpdateRoom(r.Context(), &room); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, room.Version)
+	json.NewEncoder(w).Encode(room)
+}
+
+// DeleteRoom handles DELETE /rooms/{id}.
+func (h *LocationHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.DeleteRoom(r.Context(), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAssignments handles GET /events/{id}/room-assignments.
+func (h *LocationHandler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	assignments, err := h.service.ListAssignments(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(assignments)
+}
+
+// AssignRooms handles PUT /events/{id}/groups/{group_id}/rooms.
+func (h *LocationHandler) AssignRooms(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		RoomIDs []string `json:"room_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	assignments, err := h.service.AssignRooms(r.Context(), vars["id"], vars["group_id"], req.RoomIDs)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(assignments)
+}
+
+// SetRoomStaff handles PUT /events/{id}/rooms/{room_id}/staff.
+func (h *LocationHandler) SetRoomStaff(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req struct {
+		VolunteerIDs []string `json:"volunteer_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	occupancy, err := h.service.SetRoomStaff(r.Context(), vars["id"], vars["room_id"], req.VolunteerIDs)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(occupancy)
+}
+
+// RoomOccupancy handles GET /events/{id}/rooms/{room_id}.
+func (h *LocationHandler) RoomOccupancy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	occupancy, err := h.service.RoomOccupancy(r.Context(), vars["id"], vars["room_id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(occupancy)
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// MeHandler handles the /me endpoints, always scoped to the authenticated caretaker.
+type MeHandler struct {
+	service services.MeService
+}
+
+// NewMeHandler creates a new MeHandler instance.
+func NewMeHandler(service services.MeService) *MeHandler {
+	return &MeHandler{
+		service: service,
+	}
+}
+
+// GetProfile handles GET /me.
+func (h *MeHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	caretaker, err := h.service.GetProfile(r.Context(), auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, caretaker.Version)
+	json.NewEncoder(w).Encode(caretaker)
+}
+
+// UpdateProfile handles PUT /me.
+func (h *MeHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	var caretaker models.Caretaker
+	if err := json.NewDecoder(r.Body).Decode(&caretaker); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+	caretaker.Version = version
+
+	if err := h.service.UpdateProfile(r.Context(), auth.Subject(r), &caretaker); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, caretaker.Version)
+	json.NewEncoder(w).Encode(caretaker)
+}
+
+// GetHousehold handles GET /me/household.
+func (h *MeHandler) GetHousehold(w http.ResponseWriter, r *http.Request) {
+	household, err := h.service.GetHousehold(r.Context(), auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(household)
+}
+
+// ListChildren handles GET /me/children.
+func (h *MeHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	household, err := h.service.GetHousehold(r.Context(), auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(household.Children)
+}
+
+// GetChild handles GET /me/children/{id}, including needs and allergies.
+func (h *MeHandler) GetChild(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	child, err := h.service.GetChild(r.Context(), auth.Subject(r), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(child)
+}
+
+// AddNeed handles POST /me/children/{id}/needs.
+func (h *MeHandler) AddNeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var need models.Need
+	if err := json.NewDecoder(r.Body).Decode(&need); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.AddNeed(r.Context(), auth.Subject(r), vars["id"], &need); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(need)
+}
+
+// RemoveNeed handles DELETE /me/children/{id}/needs/{needId}.
+func (h *MeHandler) RemoveNeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RemoveNeed(r.Context(), auth.Subject(r), vars["id"], vars["needId"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddAllergy handles POST /me/children/{id}/allergies.
+func (h *MeHandler) AddAllergy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var allergy models.Allergy
+	if err := json.NewDecoder(r.Body).Decode(&allergy); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.AddAllergy(r.Context(), auth.Subject(r), vars["id"], &allergy); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(allergy)
+}
+
+// RemoveAllergy handles DELETE /me/children/{id}/allergies/{allergyId}.
+func (h *MeHandler) RemoveAllergy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RemoveAllergy(r.Context(), auth.Subject(r), vars["id"], vars["allergyId"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPickupGrants handles GET /me/pickup-grants.
+func (h *MeHandler) ListPickupGrants(w http.ResponseWriter, r *http.Request) {
+	grants, err := h.service.ListPickupGrants(r.Context(), auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(grants)
+}
+
+// CreatePickupGrant handles POST /me/children/{id}/pickup-grants.
+func (h *MeHandler) CreatePickupGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var grant models.PickupGrant
+	if err := json.NewDecoder(r.Body).Decode(&grant); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	grant.ChildID = vars["id"]
+	if err := h.service.CreatePickupGrant(r.Context(), auth.Subject(r), &grant); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(grant)
+}
+
+// RevokePickupGrant handles DELETE /me/pickup-grants/{id}.
+func (h *MeHandler) RevokePickupGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RevokePickupGrant(r.Context(), auth.Subject(r), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAttendance handles GET /me/attendance with page/page_size pagination.
+func (h *MeHandler) ListAttendance(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		pageNum, err := strconv.Atoi(pageStr)
+		if err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		pageSizeNum, err := strconv.Atoi(pageSizeStr)
+		if err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	records, err := h.service.ListAttendance(r.Context(), auth.Subject(r), page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(records)
+}
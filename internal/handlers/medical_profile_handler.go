@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// MedicalProfileHandler handles the child medical profile and the emergency roster.
+type MedicalProfileHandler struct {
+	service services.MedicalProfileService
+}
+
+// NewMedicalProfileHandler creates a new MedicalProfileHandler instance.
+func NewMedicalProfileHandler(service services.MedicalProfileService) *MedicalProfileHandler {
+	return &MedicalProfileHandler{
+		service: service,
+	}
+}
+
+// Get handles GET /children/{id}/medical-profile.
+func (h *MedicalProfileHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	profile, err := h.service.GetProfile(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	if profile.Version > 0 {
+		setETag(w, profile.Version)
+	}
+	json.NewEncoder(w).Encode(profile)
+}
+
+// Update handles PUT /children/{id}/medical-profile.
+func (h *MedicalProfileHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var profile models.MedicalProfile
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	profile.Version, err = parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	profile.ChildID = vars["id"]
+	if err := h.service.UpdateProfile(r.Context(), &profile, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, profile.Version)
+	json.NewEncoder(w).Encode(profile)
+}
+
+// Roster handles GET /groups/{id}/emergency-roster; ?format=csv returns a spreadsheet.
+func (h *MedicalProfileHandler) Roster(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	roster, err := h.service.EmergencyRoster(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	if r.URL.Query().Get("format") != "csv" {
+		json.NewEncoder(w).Encode(roster)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="emergency-roster.csv"`)
+	writeRosterCSV(w, roster)
+}
+
+// writeRosterCSV escreve uma linha por criança; listas ficam separadas por "; "
+// e os contatos seguem a ordem de prioridade.
+func writeRosterCSV(w http.ResponseWriter, roster []*models.RosterEntry) {
+	out := csv.NewWriter(w)
+	out.Write([]string{
+		"child_name", "birth_date", "security_code", "checked_in_at",
+		"allergies", "blood_type", "conditions", "dietary_restrictions",
+		"doctor", "insurance", "emergency_contacts", "notes",
+	})
+
+	for _, entry := range roster {
+		allergies := make([]string, 0, len(entry.Allergies))
+		for _, allergy := range entry.Allergies {
+			item := string(allergy.Description)
+			if allergy.Severity != "" {
+				item += " (" + allergy.Severity + ")"
+			}
+			allergies = append(allergies, item)
+		}
+
+		profile := entry.Medical
+		if profile == nil {
+			profile = &models.MedicalProfile{}
+		}
+
+		contacts := make([]string, 0, len(profile.EmergencyContacts))
+		for _, contact := range profile.EmergencyContacts {
+			item := contact.Name
+			if contact.Relationship != "" {
+				item += " (" + contact.Relationship + ")"
+			}
+			item += " " + string(contact.Phone)
+			if contact.AltPhone != "" {
+				item += " / " + string(contact.AltPhone)
+			}
+			contacts = append(contacts, item)
+		}
+
+		out.Write([]string{
+			entry.ChildName,
+			entry.BirthDate.Format("2006-01-02"),
+			entry.SecurityCode,
+			entry.CheckedInAt.Format(time.RFC3339),
+			strings.Join(allergies, "; "),
+			profile.BloodType,
+			strings.Join(profile.Conditions, "; "),
+			strings.Join(profile.DietaryRestrictions, "; "),
+			strings.TrimSpace(profile.DoctorName + " " + string(profile.DoctorPhone)),
+			strings.TrimSpace(profile.InsuranceProvider + " " + string(profile.InsurancePolicyNumber)),
+			strings.Join(contacts, "; "),
+			string(profile.Notes),
+		})
+	}
+	out.Flush()
+}
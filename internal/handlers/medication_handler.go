@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// MedicationHandler handles medication orders and the administration log.
+type MedicationHandler struct {
+	service services.MedicationService
+}
+
+// NewMedicationHandler creates a new MedicationHandler instance.
+func NewMedicationHandler(service services.MedicationService) *MedicationHandler {
+	return &MedicationHandler{
+		service: service,
+	}
+}
+
+// CreateOrder handles POST /children/{id}/medications.
+func (h *MedicationHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var order models.MedicationOrder
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	order.ChildID = vars["id"]
+	if err := h.service.CreateOrder(r.Context(), &order, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+// ListOrders handles GET /children/{id}/medications; ?all=true includes discontinued orders.
+func (h *MedicationHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	includeDiscontinued, _ := strconv.ParseBool(r.URL.Query().Get("all"))
+	orders, err := h.service.ListOrders(r.Context(), vars["id"], includeDiscontinued)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(orders)
+}
+
+// GetOrder handles GET /medications/{id}.
+func (h *MedicationHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	order, err := h.service.GetOrder(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(order)
+}
+
+// DiscontinueOrder handles DELETE /medications/{id}.
+func (h *MedicationHandler) DiscontinueOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.DiscontinueOrder(r.Context(), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Administer handles POST /medications/{id}/administrations.
+func (h *MedicationHandler) Administer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var administration models.MedicationAdministration
+	if err := json.NewDecoder(r.Body).Decode(&administration); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Administer(r.Context(), vars["id"], &administration, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(administration)
+}
+
+// VerifyAdministration handles POST /medication-administrations/{id}/verify.
+func (h *MedicationHandler) VerifyAdministration(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	administration, err := h.service.Verify(r.Context(), vars["id"], auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(administration)
+}
+
+// ListAdministrations handles GET /medications/{id}/administrations.
+func (h *MedicationHandler) ListAdministrations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	administrations, err := h.service.ListAdministrations(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(administrations)
+}
+
+// ChildLog handles GET /children/{id}/medication-log.
+func (h *MedicationHandler) ChildLog(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	queryParams := r.URL.Query()
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	administrations, err := h.service.ChildLog(r.Context(), vars["id"], page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(administrations)
+}
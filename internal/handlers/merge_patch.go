@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// mergePatchContentType é o media type definido pela RFC 7396.
+const mergePatchContentType = "application/merge-patch+json"
+
+// errUnsupportedPatch is returned when a PATCH request is not a JSON Merge Patch.
+var errUnsupportedPatch = errors.New("PATCH requires Content-Type " + mergePatchContentType)
+
+// isMergePatch verifica se a requisição declara o media type de JSON Merge Patch.
+func isMergePatch(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == mergePatchContentType
+}
+
+// applyMergePatch aplica o documento de patch lido de body sobre current e
+// decodifica o resultado em dst. Campos ausentes no patch mantêm o valor atual
+// e campos com null são removidos (voltam ao valor zero).
+func applyMergePatch(current interface{}, body io.Reader, dst interface{}) error {
+	var patch interface{}
+	if err := json.NewDecoder(body).Decode(&patch); err != nil {
+		return err
+	}
+
+	original, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(original, &document); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(document, patch))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(merged, dst)
+}
+
+// mergePatch implementa o algoritmo MergePatch da RFC 7396.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
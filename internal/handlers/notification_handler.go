@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// NotificationHandler handles paging caretakers and tracking delivery.
+type NotificationHandler struct {
+	service services.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance.
+func NewNotificationHandler(service services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+	}
+}
+
+// PageParent handles POST /attendance/{id}/page-parent.
+func (h *NotificationHandler) PageParent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req models.PageParentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	notification, err := h.service.PageParent(r.Context(), vars["id"], req, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(notification)
+}
+
+// ListByAttendance handles GET /attendance/{id}/notifications.
+func (h *NotificationHandler) ListByAttendance(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	notifications, err := h.service.ListByAttendance(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(notifications)
+}
+
+// Get handles GET /notifications/{id}, exposing the delivery status.
+func (h *NotificationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	notification, err := h.service.GetNotification(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(notification)
+}
+
+// Retry handles POST /notifications/{id}/retry for failed deliveries.
+func (h *NotificationHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	notification, err := h.service.Retry(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(notification)
+}
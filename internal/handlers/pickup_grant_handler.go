@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// PickupGrantHandler handles HTTP requests related to temporary pickup grants.
+type PickupGrantHandler struct {
+	service services.PickupGrantService
+}
+
+// NewPickupGrantHandler creates a new PickupGrantHandler instance.
+func NewPickupGrantHandler(service services.PickupGrantService) *PickupGrantHandler {
+	return &PickupGrantHandler{
+		service: service,
+	}
+}
+
+// Create handles POST requests to grant a person temporary pickup rights.
+func (h *PickupGrantHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var grant models.PickupGrant
+	if err := json.NewDecoder(r.Body).Decode(&grant); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	grant.ChildID = vars["id"]
+	if err := h.service.CreateGrant(r.Context(), &grant); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(grant)
+}
+
+// List handles GET requests to list the pickup grants of a child.
+func (h *PickupGrantHandler) List(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	grants, err := h.service.ListGrants(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(grants)
+}
+
+// Revoke handles DELETE requests to revoke a pickup grant.
+func (h *PickupGrantHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RevokeGrant(r.Context(), vars["id"]); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+)
+
+// PreCheckInHandler handles QR pre-check-in issuance and kiosk redemption.
+type PreCheckInHandler struct {
+	service services.PreCheckInService
+}
+
+// NewPreCheckInHandler creates a new PreCheckInHandler instance.
+func NewPreCheckInHandler(service services.PreCheckInService) *PreCheckInHandler {
+	return &PreCheckInHandler{
+		service: service,
+	}
+}
+
+// PreCheckIn handles POST /me/precheckin, returning the signed QR payload.
+func (h *PreCheckInHandler) PreCheckIn(w http.ResponseWriter, r *http.Request) {
+	var req models.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	preCheckIn, err := h.service.PreCheckIn(r.Context(), auth.Subject(r), req)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(preCheckIn)
+}
+
+// KioskCheckIn handles POST /kiosk/checkin, completing a pre-check-in from a scanned QR.
+func (h *PreCheckInHandler) KioskCheckIn(w http.ResponseWriter, r *http.Request) {
+	var req models.KioskCheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	records, err := h.service.Redeem(r.Context(), req.Token, checkInLocation(r, req.LocationID), auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(records)
+}
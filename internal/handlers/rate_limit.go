@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// attemptLimiter limita as tentativas em janelas fixas, por cliente (endereço
+// IP) e no total, para conter tentativas distribuídas. Fica em memória: cada
+// réplica conta separadamente.
+type attemptLimiter struct {
+	perClient int
+	total     int
+	window    time.Duration
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[string]int
+	sum         int
+}
+
+func newAttemptLimiter(perClient, total int, window time.Duration) *attemptLimiter {
+	return &attemptLimiter{
+		perClient: perClient,
+		total:     total,
+		window:    window,
+		counts:    make(map[string]int),
+	}
+}
+
+// allow registra a tentativa do cliente, se ainda houver cota na janela.
+func (l *attemptLimiter) allow(client string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.windowStart) >= l.window {
+		l.windowStart = now
+		l.counts = make(map[string]int)
+		l.sum = 0
+	}
+
+	if l.counts[client] >= l.perClient || l.sum >= l.total {
+		return false
+	}
+	l.counts[client]++
+	l.sum++
+	return true
+}
+
+// Limit responde 429 quando o cliente esgota as tentativas da janela.
+func (l *attemptLimiter) Limit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !l.allow(clientIP(r), time.Now()) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(l.window.Seconds())))
+			http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// clientIP usa o endereço da conexão; cabeçalhos como X-Forwarded-For podem
+// ser forjados pelo próprio cliente.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// RestrictionHandler handles HTTP requests related to custody restrictions.
+type RestrictionHandler struct {
+	service services.RestrictionService
+}
+
+// NewRestrictionHandler creates a new RestrictionHandler instance.
+func NewRestrictionHandler(service services.RestrictionService) *RestrictionHandler {
+	return &RestrictionHandler{
+		service: service,
+	}
+}
+
+// Create handles POST requests to add a restriction to a child.
+func (h *RestrictionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var restriction models.ChildRestriction
+	if err := json.NewDecoder(r.Body).Decode(&restriction); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	restriction.ChildID = vars["id"]
+	restriction.CreatedBy = auth.Subject(r)
+	if err := h.service.CreateRestriction(r.Context(), &restriction); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(restriction)
+}
+
+// List handles GET requests to list the restrictions of a child.
+func (h *RestrictionHandler) List(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	restrictions, err := h.service.ListRestrictions(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(restrictions)
+}
+
+// Revoke handles DELETE requests to revoke a restriction.
+func (h *RestrictionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RevokeRestriction(r.Context(), vars["id"], auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// RetentionHandler handles the retention policies and their runs.
+type RetentionHandler struct {
+	service services.RetentionService
+}
+
+// NewRetentionHandler creates a new RetentionHandler instance.
+func NewRetentionHandler(service services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		service: service,
+	}
+}
+
+// retentionRunRequest is the body of POST /admin/retention-runs. Sem
+// dry_run, a execução é apenas um relatório.
+type retentionRunRequest struct {
+	DryRun *bool `json:"dry_run"`
+}
+
+// ListPolicies handles GET /admin/retention-policies.
+func (h *RetentionHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.service.ListPolicies(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(policies)
+}
+
+// UpdatePolicy handles PUT /admin/retention-policies/{rule}.
+func (h *RetentionHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var policy models.RetentionPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	policy.Rule = vars["rule"]
+
+	if err := h.service.UpdatePolicy(r.Context(), &policy, auth.Subject(r)); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(policy)
+}
+
+// CreateRun handles POST /admin/retention-runs. A execução roda em segundo
+// plano; a resposta traz a execução ainda pendente.
+func (h *RetentionHandler) CreateRun(w http.ResponseWriter, r *http.Request) {
+	var request retentionRunRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	dryRun := request.DryRun == nil || *request.DryRun
+	run, err := h.service.RequestRun(r.Context(), dryRun, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(run)
+}
+
+// ListRuns handles GET /admin/retention-runs.
+func (h *RetentionHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	runs, err := h.service.ListRuns(r.Context(), page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(runs)
+}
+
+// GetRun handles GET /admin/retention-runs/{id}.
+func (h *RetentionHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	run, err := h.service.GetRun(r.Context(), vars["id"])
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(run)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/services"
+	"github.com/gorilla/mux"
+)
+
+// RollCallHandler handles the evacuation roll call.
+type RollCallHandler struct {
+	service services.RollCallService
+}
+
+// NewRollCallHandler creates a new RollCallHandler instance.
+func NewRollCallHandler(service services.RollCallService) *RollCallHandler {
+	return &RollCallHandler{
+		service: service,
+	}
+}
+
+// Start handles POST /admin/roll-calls.
+func (h *RollCallHandler) Start(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		LocationID string `json:"location_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.service.Start(r.Context(), req.LocationID, auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(summary)
+}
+
+// Close handles POST /admin/roll-calls/{id}/close.
+func (h *RollCallHandler) Close(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	summary, err := h.service.Close(r.Context(), vars["id"], auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(summary)
+}
+
+// List handles GET /roll-calls; ?status=open filters the roll calls in progress.
+func (h *RollCallHandler) List(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
+			page = pageNum
+		}
+	}
+
+	pageSize := 20
+	if pageSizeStr := queryParams.Get("page_size"); pageSizeStr != "" {
+		if pageSizeNum, err := strconv.Atoi(pageSizeStr); err == nil && pageSizeNum > 0 {
+			pageSize = pageSizeNum
+		}
+	}
+
+	rollCalls, err := h.service.List(r.Context(), queryParams.Get("status"), page, pageSize)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(rollCalls)
+}
+
+// Summary handles GET /roll-calls/{id}. Only the roles with access to the
+// medical profile receive the allergies and medical data of the missing children.
+func (h *RollCallHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	includeMedical := auth.HasRole(r, auth.RoleAdmin, auth.RoleCoordinator, auth.RoleMedical)
+	summary, err := h.service.Summary(r.Context(), vars["id"], includeMedical)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(summary)
+}
+
+// MarkAccounted handles POST /roll-calls/{id}/children/{child_id}/accounted.
+func (h *RollCallHandler) MarkAccounted(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	entry, err := h.service.MarkAccounted(r.Context(), vars["id"], vars["child_id"], auth.Subject(r))
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(entry)
+}
@@ -3,6 +3,13 @@
 package handlers
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/jobs"
+	"github.com/eduardohass/kids-api/internal/metrics"
+	"github.com/eduardohass/kids-api/internal/models"
 	"github.com/eduardohass/kids-api/internal/services"
 	"github.com/gorilla/mux"
 )
@@ -13,48 +20,289 @@ func NewRouter(
 	caretakerService services.CaretakerService,
 	volunteerService services.VolunteerService,
 	groupService services.GroupService,
+	searchService services.SearchService,
+	householdService services.HouseholdService,
+	attendanceService services.AttendanceService,
+	restrictionService services.RestrictionService,
+	pickupGrantService services.PickupGrantService,
+	meService services.MeService,
+	preCheckInService services.PreCheckInService,
+	kioskService services.KioskService,
+	notificationService services.NotificationService,
+	jobQueue *jobs.Queue,
+	groupStreamService services.GroupStreamService,
+	incidentService services.IncidentService,
+	medicationService services.MedicationService,
+	medicalProfileService services.MedicalProfileService,
+	rollCallService services.RollCallService,
+	locationService services.LocationService,
+	dataSubjectService services.DataSubjectService,
+	consentService services.ConsentService,
+	accessLogService services.AccessLogService,
+	retentionService services.RetentionService,
+	authenticator *auth.Authenticator,
+	metricsRegistry *metrics.Registry,
+	metricsToken string,
+	purgeRetention time.Duration,
 ) *mux.Router {
 	r := mux.NewRouter()
 
+	// Duração das requisições por template de rota; rotas inexistentes são
+	// agrupadas em um único label
+	instrument := metrics.HTTPMiddleware(metricsRegistry)
+	r.Use(instrument)
+	r.NotFoundHandler = instrument(http.NotFoundHandler())
+	r.MethodNotAllowedHandler = instrument(http.HandlerFunc(methodNotAllowed))
+
 	// Health check route (public)
 	r.HandleFunc("/health", HealthHandler).Methods("GET")
 
+	// Métricas: exigem METRICS_TOKEN, exceto em desenvolvimento
+	r.Handle("/metrics", metricsRegistry.Handler(metricsToken)).Methods("GET")
+
 	// Handlers
-	childHandler := NewChildHandler(childService)
+	childHandler := NewChildHandler(childService, restrictionService, consentService)
 	caretakerHandler := NewCaretakerHandler(caretakerService)
 	volunteerHandler := NewVolunteerHandler(volunteerService)
 	groupHandler := NewGroupHandler(groupService)
+	searchHandler := NewSearchHandler(searchService)
+	householdHandler := NewHouseholdHandler(householdService)
+	attendanceHandler := NewAttendanceHandler(attendanceService)
+	restrictionHandler := NewRestrictionHandler(restrictionService)
+	pickupGrantHandler := NewPickupGrantHandler(pickupGrantService)
+	meHandler := NewMeHandler(meService)
+	preCheckInHandler := NewPreCheckInHandler(preCheckInService)
+	kioskHandler := NewKioskHandler(kioskService, householdService)
+	notificationHandler := NewNotificationHandler(notificationService)
+	jobHandler := NewJobHandler(jobQueue)
+	groupStreamHandler := NewGroupStreamHandler(groupStreamService)
+	incidentHandler := NewIncidentHandler(incidentService)
+	medicationHandler := NewMedicationHandler(medicationService)
+	medicalProfileHandler := NewMedicalProfileHandler(medicalProfileService)
+	rollCallHandler := NewRollCallHandler(rollCallService)
+	locationHandler := NewLocationHandler(locationService)
+	dataSubjectHandler := NewDataSubjectHandler(dataSubjectService)
+	consentHandler := NewConsentHandler(consentService)
+	accessLogHandler := NewAccessLogHandler(accessLogService)
+	retentionHandler := NewRetentionHandler(retentionService)
+	adminHandler := NewAdminHandler(childService, caretakerService, volunteerService, groupService, purgeRetention)
+
+	// Pareamento de quiosque (público, protegido pelo código de uso único e
+	// por um limite de tentativas)
+	pairLimiter := newAttemptLimiter(kioskPairAttemptsPerClient, kioskPairAttemptsTotal, kioskPairAttemptWindow)
+	r.HandleFunc("/api/v1/kiosk/pair", pairLimiter.Limit(kioskHandler.Pair)).Methods("POST")
+
+	// API routes: aceitam o JWT do usuário ou a credencial de um quiosque
+	v1 := r.PathPrefix("/api/v1").Subrouter()
+	v1.Use(authenticator.UserOrDevice(kioskService))
+	v1.Use(auth.ResolveTenant)
+	v1.Use(accessLogHandler.Accessor)
+
+	// Quiosque de auto atendimento (usuários ou dispositivos pareados)
+	kiosk := v1.PathPrefix("/kiosk").Subrouter()
+	kiosk.Use(auth.DeviceOrStaff)
+	kiosk.HandleFunc("/search", kioskHandler.Search).Methods("GET")
+	kiosk.HandleFunc("/checkin", preCheckInHandler.KioskCheckIn).Methods("POST")
+	kiosk.HandleFunc("/households/{id}/checkin", kioskHandler.CheckIn).Methods("POST")
+
+	// Demais rotas: somente usuários (responsáveis e equipe)
+	api := v1.PathPrefix("").Subrouter()
+	api.Use(auth.UsersOnly)
 
-	// API routes
-	api := r.PathPrefix("/api/v1").Subrouter()
+	// Portal do responsável (escopo restrito à própria família)
+	me := api.PathPrefix("/me").Subrouter()
+	me.HandleFunc("", meHandler.GetProfile).Methods("GET")
+	me.HandleFunc("", meHandler.UpdateProfile).Methods("PUT")
+	me.HandleFunc("/household", meHandler.GetHousehold).Methods("GET")
+	me.HandleFunc("/children", meHandler.ListChildren).Methods("GET")
+	me.HandleFunc("/children/{id}", meHandler.GetChild).Methods("GET")
+	me.HandleFunc("/children/{id}/needs", meHandler.AddNeed).Methods("POST")
+	me.HandleFunc("/children/{id}/needs/{needId}", meHandler.RemoveNeed).Methods("DELETE")
+	me.HandleFunc("/children/{id}/allergies", meHandler.AddAllergy).Methods("POST")
+	me.HandleFunc("/children/{id}/allergies/{allergyId}", meHandler.RemoveAllergy).Methods("DELETE")
+	me.HandleFunc("/children/{id}/pickup-grants", meHandler.CreatePickupGrant).Methods("POST")
+	me.HandleFunc("/pickup-grants", meHandler.ListPickupGrants).Methods("GET")
+	me.HandleFunc("/pickup-grants/{id}", meHandler.RevokePickupGrant).Methods("DELETE")
+	me.HandleFunc("/attendance", meHandler.ListAttendance).Methods("GET")
+	me.HandleFunc("/precheckin", preCheckInHandler.PreCheckIn).Methods("POST")
+	me.HandleFunc("/incidents", incidentHandler.ListMine).Methods("GET")
+	me.HandleFunc("/incidents/{id}/acknowledge", incidentHandler.AcknowledgeMine).Methods("POST")
+	me.HandleFunc("/children/{id}/consents", consentHandler.ListMine).Methods("GET")
+	me.HandleFunc("/children/{id}/consents", consentHandler.RecordMine).Methods("POST")
+	me.HandleFunc("/consents/{id}/revoke", consentHandler.RevokeMine).Methods("POST")
+
+	// Textos dos termos, lidos pela equipe e pelos responsáveis
+	api.HandleFunc("/consent-documents", consentHandler.ListDocuments).Methods("GET")
+	api.HandleFunc("/consent-documents/{id}", consentHandler.GetDocument).Methods("GET")
+
+	// Demais rotas: somente a equipe
+	staff := api.PathPrefix("").Subrouter()
+	staff.Use(auth.RequireStaff)
 
 	// Rotas para crianças
-	api.HandleFunc("/children", childHandler.Create).Methods("POST")
-	api.HandleFunc("/children", childHandler.List).Methods("GET")
-	api.HandleFunc("/children/{id}", childHandler.Get).Methods("GET")
-	api.HandleFunc("/children/{id}", childHandler.Update).Methods("PUT")
-	api.HandleFunc("/children/{id}", childHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/children", childHandler.Create).Methods("POST")
+	staff.HandleFunc("/children", childHandler.List).Methods("GET")
+	staff.HandleFunc("/children/{id}", childHandler.Get).Methods("GET")
+	staff.HandleFunc("/children/{id}", childHandler.Update).Methods("PUT")
+	staff.HandleFunc("/children/{id}", childHandler.Patch).Methods("PATCH")
+	staff.HandleFunc("/children/{id}", childHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/children/{id}/restore", childHandler.Restore).Methods("POST")
 
 	// Rotas para responsáveis
-	api.HandleFunc("/caretakers", caretakerHandler.Create).Methods("POST")
-	api.HandleFunc("/caretakers", caretakerHandler.List).Methods("GET")
-	api.HandleFunc("/caretakers/{id}", caretakerHandler.Get).Methods("GET")
-	api.HandleFunc("/caretakers/{id}", caretakerHandler.Update).Methods("PUT")
-	api.HandleFunc("/caretakers/{id}", caretakerHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/caretakers", caretakerHandler.Create).Methods("POST")
+	staff.HandleFunc("/caretakers", caretakerHandler.List).Methods("GET")
+	staff.HandleFunc("/caretakers/{id}", caretakerHandler.Get).Methods("GET")
+	staff.HandleFunc("/caretakers/{id}", caretakerHandler.Update).Methods("PUT")
+	staff.HandleFunc("/caretakers/{id}", caretakerHandler.Patch).Methods("PATCH")
+	staff.HandleFunc("/caretakers/{id}", caretakerHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/caretakers/{id}/restore", caretakerHandler.Restore).Methods("POST")
 
 	// Rotas para voluntários
-	api.HandleFunc("/volunteers", volunteerHandler.Create).Methods("POST")
-	api.HandleFunc("/volunteers", volunteerHandler.List).Methods("GET")
-	api.HandleFunc("/volunteers/{id}", volunteerHandler.Get).Methods("GET")
-	api.HandleFunc("/volunteers/{id}", volunteerHandler.Update).Methods("PUT")
-	api.HandleFunc("/volunteers/{id}", volunteerHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/volunteers", volunteerHandler.Create).Methods("POST")
+	staff.HandleFunc("/volunteers", volunteerHandler.List).Methods("GET")
+	staff.HandleFunc("/volunteers/{id}", volunteerHandler.Get).Methods("GET")
+	staff.HandleFunc("/volunteers/{id}", volunteerHandler.Update).Methods("PUT")
+	staff.HandleFunc("/volunteers/{id}", volunteerHandler.Patch).Methods("PATCH")
+	staff.HandleFunc("/volunteers/{id}", volunteerHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/volunteers/{id}/restore", volunteerHandler.Restore).Methods("POST")
 
 	// Rotas para grupos
-	api.HandleFunc("/groups", groupHandler.Create).Methods("POST")
-	api.HandleFunc("/groups", groupHandler.List).Methods("GET")
-	api.HandleFunc("/groups/{id}", groupHandler.Get).Methods("GET")
-	api.HandleFunc("/groups/{id}", groupHandler.Update).Methods("PUT")
-	api.HandleFunc("/groups/{id}", groupHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/groups", groupHandler.Create).Methods("POST")
+	staff.HandleFunc("/groups", groupHandler.List).Methods("GET")
+	staff.HandleFunc("/groups/{id}", groupHandler.Get).Methods("GET")
+	staff.HandleFunc("/groups/{id}", groupHandler.Update).Methods("PUT")
+	staff.HandleFunc("/groups/{id}", groupHandler.Patch).Methods("PATCH")
+	staff.HandleFunc("/groups/{id}", groupHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/groups/{id}/restore", groupHandler.Restore).Methods("POST")
+	staff.HandleFunc("/groups/{id}/stream", groupStreamHandler.Stream).Methods("GET")
+
+	// Rotas para famílias
+	staff.HandleFunc("/households", householdHandler.Create).Methods("POST")
+	staff.HandleFunc("/households/{id}", householdHandler.Get).Methods("GET")
+	staff.HandleFunc("/households/{id}", householdHandler.Update).Methods("PUT")
+	staff.HandleFunc("/households/{id}", householdHandler.Delete).Methods("DELETE")
+	staff.HandleFunc("/households/{id}/children/{childId}", householdHandler.AddChild).Methods("PUT")
+	staff.HandleFunc("/households/{id}/caretakers/{caretakerId}", householdHandler.AddCaretaker).Methods("PUT")
+	staff.HandleFunc("/households/{id}/checkin", householdHandler.CheckIn).Methods("POST")
+
+	// Rotas de presença (check-in)
+	staff.HandleFunc("/attendance", attendanceHandler.CheckIn).Methods("POST")
+	staff.HandleFunc("/attendance/{id}", attendanceHandler.Get).Methods("GET")
+	staff.HandleFunc("/attendance/{id}/checkout", attendanceHandler.CheckOut).Methods("POST")
+	staff.HandleFunc("/attendance/{id}/page-parent", notificationHandler.PageParent).Methods("POST")
+	staff.HandleFunc("/attendance/{id}/notifications", notificationHandler.ListByAttendance).Methods("GET")
+
+	// Notificações aos responsáveis
+	staff.HandleFunc("/notifications/{id}", notificationHandler.Get).Methods("GET")
+	staff.HandleFunc("/notifications/{id}/retry", notificationHandler.Retry).Methods("POST")
+
+	// Autorizações temporárias de retirada
+	staff.HandleFunc("/children/{id}/pickup-grants", accessLogHandler.Sensitive(models.AccessPickupGrants, models.AccessEntityChild, pickupGrantHandler.List)).Methods("GET")
+
+	// Termos de consentimento (autorização de imagem e termo de cuidado)
+	staff.HandleFunc("/children/{id}/consents", consentHandler.List).Methods("GET")
+	staff.HandleFunc("/children/{id}/consents", consentHandler.Record).Methods("POST")
+	staff.HandleFunc("/consents/{id}/revoke", consentHandler.Revoke).Methods("POST")
+
+	// Medicamentos e registro de administração
+	staff.HandleFunc("/children/{id}/medications", accessLogHandler.Sensitive(models.AccessMedication, models.AccessEntityChild, medicationHandler.ListOrders)).Methods("GET")
+	staff.HandleFunc("/children/{id}/medications", medicationHandler.CreateOrder).Methods("POST")
+	staff.HandleFunc("/children/{id}/medication-log", accessLogHandler.Sensitive(models.AccessMedication, models.AccessEntityChild, medicationHandler.ChildLog)).Methods("GET")
+	staff.HandleFunc("/medications/{id}", accessLogHandler.Sensitive(models.AccessMedication, models.AccessEntityMedication, medicationHandler.GetOrder)).Methods("GET")
+	staff.HandleFunc("/medications/{id}", medicationHandler.DiscontinueOrder).Methods("DELETE")
+	staff.HandleFunc("/medications/{id}/administrations", accessLogHandler.Sensitive(models.AccessMedication, models.AccessEntityMedication, medicationHandler.ListAdministrations)).Methods("GET")
+	staff.HandleFunc("/medications/{id}/administrations", medicationHandler.Administer).Methods("POST")
+	staff.HandleFunc("/medication-administrations/{id}/verify", medicationHandler.VerifyAdministration).Methods("POST")
+
+	// Relatórios de incidentes
+	staff.HandleFunc("/incidents", incidentHandler.Create).Methods("POST")
+	staff.HandleFunc("/incidents", incidentHandler.List).Methods("GET")
+	staff.HandleFunc("/incidents/{id}", accessLogHandler.Sensitive(models.AccessIncident, models.AccessEntityIncident, incidentHandler.Get)).Methods("GET")
+	staff.HandleFunc("/incidents/{id}", incidentHandler.Update).Methods("PUT")
+	staff.HandleFunc("/incidents/{id}/submit", incidentHandler.Submit).Methods("POST")
+	staff.HandleFunc("/incidents/{id}/acknowledge", incidentHandler.Acknowledge).Methods("POST")
+	staff.HandleFunc("/incidents/{id}/pdf", accessLogHandler.Sensitive(models.AccessIncident, models.AccessEntityIncident, incidentHandler.ExportPDF)).Methods("GET")
+
+	// Locais, salas e distribuição dos grupos por evento
+	staff.HandleFunc("/locations", locationHandler.CreateLocation).Methods("POST")
+	staff.HandleFunc("/locations", locationHandler.ListLocations).Methods("GET")
+	staff.HandleFunc("/locations/{id}", locationHandler.GetLocation).Methods("GET")
+	staff.HandleFunc("/locations/{id}", locationHandler.UpdateLocation).Methods("PUT")
+	staff.HandleFunc("/locations/{id}", locationHandler.DeleteLocation).Methods("DELETE")
+	staff.HandleFunc("/locations/{id}/rooms", locationHandler.CreateRoom).Methods("POST")
+	staff.HandleFunc("/locations/{id}/rooms", locationHandler.ListRooms).Methods("GET")
+	staff.HandleFunc("/rooms/{id}", locationHandler.GetRoom).Methods("GET")
+	staff.HandleFunc("/rooms/{id}", locationHandler.UpdateRoom).Methods("PUT")
+	staff.HandleFunc("/rooms/{id}", locationHandler.DeleteRoom).Methods("DELETE")
+	staff.HandleFunc("/events/{id}/room-assignments", locationHandler.ListAssignments).Methods("GET")
+	staff.HandleFunc("/events/{id}/groups/{group_id}/rooms", locationHandler.AssignRooms).Methods("PUT")
+	staff.HandleFunc("/events/{id}/rooms/{room_id}", locationHandler.RoomOccupancy).Methods("GET")
+	staff.HandleFunc("/events/{id}/rooms/{room_id}/staff", locationHandler.SetRoomStaff).Methods("PUT")
+
+	// Chamada de emergência (evacuação)
+	staff.HandleFunc("/roll-calls", rollCallHandler.List).Methods("GET")
+	staff.HandleFunc("/roll-calls/{id}", rollCallHandler.Summary).Methods("GET")
+	staff.HandleFunc("/roll-calls/{id}/children/{child_id}/accounted", rollCallHandler.MarkAccounted).Methods("POST")
+
+	// Restrições de guarda (somente papéis autorizados)
+	custody := staff.PathPrefix("").Subrouter()
+	custody.Use(auth.RequireRole(auth.RoleAdmin, auth.RoleCoordinator))
+	custody.HandleFunc("/children/{id}/restrictions", accessLogHandler.Sensitive(models.AccessRestrictions, models.AccessEntityChild, restrictionHandler.List)).Methods("GET")
+	custody.HandleFunc("/children/{id}/restrictions", restrictionHandler.Create).Methods("POST")
+	custody.HandleFunc("/restrictions/{id}", restrictionHandler.Revoke).Methods("DELETE")
+	// A equipe registra autorizações de retirada em nome do responsável; o
+	// próprio responsável usa /me/children/{id}/pickup-grants
+	custody.HandleFunc("/children/{id}/pickup-grants", pickupGrantHandler.Create).Methods("POST")
+	custody.HandleFunc("/pickup-grants/{id}", pickupGrantHandler.Revoke).Methods("DELETE")
+
+	// Revisão de incidentes (somente coordenação)
+	review := staff.PathPrefix("").Subrouter()
+	review.Use(auth.RequireRole(auth.RoleAdmin, auth.RoleCoordinator))
+	review.HandleFunc("/incidents/{id}/review", incidentHandler.Review).Methods("POST")
+	review.HandleFunc("/incidents/{id}/close", incidentHandler.Close).Methods("POST")
+
+	// Fichas médicas e lista de emergência (acesso mais restrito que o cadastro da criança)
+	medical := staff.PathPrefix("").Subrouter()
+	medical.Use(auth.RequireRole(auth.RoleAdmin, auth.RoleCoordinator, auth.RoleMedical))
+	medical.HandleFunc("/children/{id}/medical-profile", accessLogHandler.Sensitive(models.AccessMedicalProfile, models.AccessEntityChild, medicalProfileHandler.Get)).Methods("GET")
+	medical.HandleFunc("/children/{id}/medical-profile", medicalProfileHandler.Update).Methods("PUT")
+	medical.HandleFunc("/groups/{id}/emergency-roster", accessLogHandler.Sensitive(models.AccessEmergencyRoster, models.AccessEntityGroup, medicalProfileHandler.Roster)).Methods("GET")
+
+	// Busca entre famílias
+	staff.HandleFunc("/search", searchHandler.Search).Methods("GET")
+
+	// Rotas administrativas (exclusão definitiva)
+	admin := staff.PathPrefix("/admin").Subrouter()
+	admin.Use(auth.RequireRole(auth.RoleAdmin))
+	admin.HandleFunc("/children/{id}", adminHandler.PurgeChild).Methods("DELETE")
+	admin.HandleFunc("/caretakers/{id}", adminHandler.PurgeCaretaker).Methods("DELETE")
+	admin.HandleFunc("/volunteers/{id}", adminHandler.PurgeVolunteer).Methods("DELETE")
+	admin.HandleFunc("/groups/{id}", adminHandler.PurgeGroup).Methods("DELETE")
+	admin.HandleFunc("/roll-calls", rollCallHandler.Start).Methods("POST")
+	admin.HandleFunc("/roll-calls/{id}/close", rollCallHandler.Close).Methods("POST")
+	admin.HandleFunc("/kiosks", kioskHandler.Register).Methods("POST")
+	admin.HandleFunc("/kiosks", kioskHandler.List).Methods("GET")
+	admin.HandleFunc("/kiosks/{id}", kioskHandler.Revoke).Methods("DELETE")
+	admin.HandleFunc("/jobs", jobHandler.List).Methods("GET")
+	admin.HandleFunc("/jobs/{id}", jobHandler.Get).Methods("GET")
+	admin.HandleFunc("/jobs/{id}/retry", jobHandler.Retry).Methods("POST")
+	admin.HandleFunc("/consent-documents", consentHandler.PublishDocument).Methods("POST")
+	admin.HandleFunc("/data-requests", dataSubjectHandler.Create).Methods("POST")
+	admin.HandleFunc("/data-requests", dataSubjectHandler.List).Methods("GET")
+	admin.HandleFunc("/data-requests/{id}", dataSubjectHandler.Get).Methods("GET")
+	admin.HandleFunc("/data-requests/{id}/archive", accessLogHandler.Sensitive(models.AccessDataExport, models.AccessEntityDataRequest, dataSubjectHandler.Archive)).Methods("GET")
+	admin.HandleFunc("/access-log", accessLogHandler.List).Methods("GET")
+	admin.HandleFunc("/access-log/report", accessLogHandler.Report).Methods("GET")
+	admin.HandleFunc("/retention-policies", retentionHandler.ListPolicies).Methods("GET")
+	admin.HandleFunc("/retention-policies/{rule}", retentionHandler.UpdatePolicy).Methods("PUT")
+	admin.HandleFunc("/retention-runs", retentionHandler.CreateRun).Methods("POST")
+	admin.HandleFunc("/retention-runs", retentionHandler.ListRuns).Methods("GET")
+	admin.HandleFunc("/retention-runs/{id}", retentionHandler.GetRun).Methods("GET")
 
 	return r
 }
+
+// methodNotAllowed responde como o handler padrão do mux, que não é exportado.
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusMethodNotAllowed)
+}
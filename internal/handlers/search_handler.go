@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/eduardohass/kids-api/internal/services"
+)
+
+type SearchHandler struct {
+	service services.SearchService
+}
+
+func NewSearchHandler(service services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+// Search handles GET /search?q= requests across children, caretakers and volunteers.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	limit := 0
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		if limitNum, err := strconv.Atoi(limitStr); err == nil {
+			limit = limitNum
+		}
+	}
+
+	results, err := h.service.Search(r.Context(), queryParams.Get("q"), limit)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
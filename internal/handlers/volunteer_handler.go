@@ -3,9 +3,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
 	"github.com/eduardohass/kids-api/internal/services"
 	"github.com/gorilla/mux"
 )
@@ -28,7 +30,7 @@ func (h *VolunteerHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.CreateVolunteer(r.Context(), &volunteer); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
@@ -46,6 +48,7 @@ func (h *VolunteerHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, volunteer.Version)
 	json.NewEncoder(w).Encode(volunteer)
 }
 
@@ -60,11 +63,62 @@ func (h *VolunteerHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	volunteer.ID = id
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+	volunteer.Version = version
+
 	if err := h.service.UpdateVolunteer(r.Context(), &volunteer); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, volunteer.Version)
+	json.NewEncoder(w).Encode(volunteer)
+}
+
+func (h *VolunteerHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if !isMergePatch(r) {
+		http.Error(w, errUnsupportedPatch.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), ifMatchStatus(err))
+		return
+	}
+
+	current, err := h.service.GetVolunteer(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
+	if version != 0 && version != current.Version {
+		http.Error(w, repository.ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	var volunteer models.Volunteer
+	if err := applyMergePatch(current, r.Body, &volunteer); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	volunteer.ID = id
+	volunteer.Version = current.Version
+	if err := h.service.UpdateVolunteer(r.Context(), &volunteer); err != nil {
+		http.Error(w, err.Error(), serviceErrorStatus(err))
+		return
+	}
+
+	setETag(w, volunteer.Version)
 	json.NewEncoder(w).Encode(volunteer)
 }
 
@@ -80,6 +134,22 @@ func (h *VolunteerHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *VolunteerHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.service.RestoreVolunteer(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *VolunteerHandler) List(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implementar paginação e filtros a partir dos query parameters
 	page := 1
@@ -0,0 +1,89 @@
+// Package jobs implements a durable background job queue on top of Postgres.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// DefaultMaxAttempts é o número de tentativas antes de um trabalho ir para a dead letter.
+const DefaultMaxAttempts = 5
+
+// Option customizes a job at enqueue time.
+type Option func(*models.Job)
+
+// RunAt agenda o trabalho para um momento futuro.
+func RunAt(at time.Time) Option {
+	return func(job *models.Job) {
+		job.RunAt = at
+	}
+}
+
+// RunIn agenda o trabalho para daqui a d.
+func RunIn(d time.Duration) Option {
+	return RunAt(time.Now().Add(d))
+}
+
+// MaxAttempts define quantas vezes o trabalho será tentado.
+func MaxAttempts(n int) Option {
+	return func(job *models.Job) {
+		job.MaxAttempts = n
+	}
+}
+
+// Queue enfileira trabalhos para os workers.
+type Queue struct {
+	repo repository.JobRepository
+}
+
+// NewQueue creates a new Queue instance.
+func NewQueue(repo repository.JobRepository) *Queue {
+	return &Queue{repo: repo}
+}
+
+// Enqueue grava o trabalho na fila; payload é serializado em JSON.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...Option) (*models.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{
+		Type:        jobType,
+		Payload:     raw,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	if err := q.repo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// List retorna os trabalhos da fila, opcionalmente filtrados pela situação.
+func (q *Queue) List(ctx context.Context, status string, limit, offset int) ([]*models.Job, error) {
+	return q.repo.List(ctx, status, limit, offset)
+}
+
+// Get retorna um trabalho pelo ID.
+func (q *Queue) Get(ctx context.Context, id string) (*models.Job, error) {
+	return q.repo.GetByID(ctx, id)
+}
+
+// Retry devolve um trabalho da dead letter para a fila.
+func (q *Queue) Retry(ctx context.Context, id string) error {
+	return q.repo.Requeue(ctx, id)
+}
+
+// HasQueued informa se já existe um trabalho do tipo aguardando execução no tenant.
+func (q *Queue) HasQueued(ctx context.Context, jobType string) (bool, error) {
+	return q.repo.HasQueued(ctx, jobType)
+}
@@ -0,0 +1,218 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// Handler processa um trabalho. Um erro faz o trabalho ser tentado de novo,
+// com backoff, até esgotar as tentativas.
+type Handler func(ctx context.Context, job *models.Job) error
+
+// Typed adapta uma função que recebe o payload já decodificado em T.
+func Typed[T any](fn func(ctx context.Context, job *models.Job, payload T) error) Handler {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decode %s payload: %w", job.Type, err)
+		}
+		return fn(ctx, job, payload)
+	}
+}
+
+// Config holds the worker settings.
+type Config struct {
+	// Concurrency é o número de trabalhos executados em paralelo.
+	Concurrency int
+	// PollInterval é a espera entre consultas quando a fila está vazia.
+	PollInterval time.Duration
+	// LockTimeout é o tempo após o qual um trabalho "running" é considerado
+	// abandonado e pode ser reservado por outro worker.
+	LockTimeout time.Duration
+	// JobTimeout limita a execução de cada handler. Precisa ser menor que
+	// LockTimeout, para que o handler seja cancelado antes de o trabalho
+	// poder ser reservado por outro worker.
+	JobTimeout time.Duration
+	// BaseBackoff é a espera antes da segunda tentativa; dobra a cada falha.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultConfig returns the worker settings used when none are given.
+func DefaultConfig() Config {
+	return Config{
+		Concurrency:  4,
+		PollInterval: time.Second,
+		LockTimeout:  5 * time.Minute,
+		JobTimeout:   4 * time.Minute,
+		BaseBackoff:  10 * time.Second,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+// Worker consome a fila de trabalhos.
+type Worker struct {
+	repo     repository.JobRepository
+	cfg      Config
+	id       string
+	handlers map[string]Handler
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWorker creates a new Worker instance. A JobTimeout that is not shorter
+// than LockTimeout is replaced by a margin below it.
+func NewWorker(repo repository.JobRepository, cfg Config) *Worker {
+	if cfg.JobTimeout <= 0 || cfg.JobTimeout >= cfg.LockTimeout {
+		cfg.JobTimeout = cfg.LockTimeout * 4 / 5
+	}
+
+	hostname, _ := os.Hostname()
+	return &Worker{
+		repo:     repo,
+		cfg:      cfg,
+		id:       fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		handlers: make(map[string]Handler),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Handle registra o handler de um tipo de trabalho. Deve ser chamado antes de Start.
+func (w *Worker) Handle(jobType string, handler Handler) {
+	w.handlers[jobType] = handler
+}
+
+// Start inicia os goroutines de processamento.
+func (w *Worker) Start() {
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop()
+	}
+	log.Printf("Job worker %s started with concurrency %d", w.id, w.cfg.Concurrency)
+}
+
+// Stop para de reservar novos trabalhos e aguarda os que estão em execução
+// terminarem (drain). Se ctx expirar antes, os trabalhos interrompidos voltam
+// à fila quando o lock expirar.
+func (w *Worker) Stop(ctx context.Context) error {
+	close(w.stop)
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) loop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		processed, err := w.processNext()
+		if err != nil {
+			log.Printf("Job worker error: %v", err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-w.stop:
+			return
+		case <-time.After(w.cfg.PollInterval):
+		}
+	}
+}
+
+// processNext reserva e executa um trabalho. Retorna false quando a fila está vazia.
+func (w *Worker) processNext() (bool, error) {
+	// O trabalho em execução não é cancelado no Stop: o drain espera ele terminar.
+	ctx := context.Background()
+
+	job, err := w.repo.Claim(ctx, w.id, w.cfg.LockTimeout)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = w.run(ctx, job)
+	if err == nil {
+		err = w.repo.Complete(ctx, job)
+	} else {
+		job.LastError = err.Error()
+		err = w.repo.Fail(ctx, job, time.Now().Add(w.backoff(job.Attempts)))
+	}
+	// O lock expirou e outro worker reservou o trabalho: o resultado desta
+	// tentativa é descartado para não sobrescrever o da nova execução
+	if errors.Is(err, repository.ErrJobLockLost) {
+		log.Printf("Job %s (%s) attempt %d finished after its lock was lost; outcome discarded", job.ID, job.Type, job.Attempts)
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	if job.Status == models.JobDead {
+		log.Printf("Job %s (%s) moved to dead letter after %d attempts: %s", job.ID, job.Type, job.Attempts, job.LastError)
+	}
+	return true, nil
+}
+
+func (w *Worker) run(ctx context.Context, job *models.Job) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.JobTimeout)
+	defer cancel()
+
+	// O trabalho roda no tenant de quem o enfileirou
+	if job.TenantID != "" {
+		ctx = tenant.WithID(ctx, job.TenantID)
+	}
+
+	return handler(ctx, job)
+}
+
+// backoff retorna a espera antes da próxima tentativa: BaseBackoff * 2^(n-1),
+// limitada a MaxBackoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	d := float64(w.cfg.BaseBackoff) * math.Pow(2, float64(attempts-1))
+	if d > float64(w.cfg.MaxBackoff) {
+		return w.cfg.MaxBackoff
+	}
+	return time.Duration(d)
+}
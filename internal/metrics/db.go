@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// DBMetrics exposes the connection pool statistics and the query latency of
+// each repository.
+type DBMetrics struct {
+	queryDuration *prometheus.HistogramVec
+}
+
+// NewDBMetrics registra as estatísticas do pool de conexões de db (séries
+// go_sql_*, com db_name="kids") e o histograma de latência das consultas.
+func NewDBMetrics(reg *Registry, db *sql.DB) *DBMetrics {
+	reg.MustRegister(collectors.NewDBStatsCollector(db, "kids"))
+
+	return &DBMetrics{
+		queryDuration: reg.NewHistogram(
+			"db_query_duration_seconds",
+			"Duration of database queries by repository and outcome.",
+			DefaultBuckets,
+			"repository", "outcome",
+		),
+	}
+}
+
+// Observer retorna a função que mede as consultas do repositório informado,
+// para ser usada com tenant.DB.WithObserver.
+func (m *DBMetrics) Observer(repository string) func(time.Duration, error) {
+	ok := m.queryDuration.WithLabelValues(repository, "ok")
+	failed := m.queryDuration.WithLabelValues(repository, "error")
+
+	return func(elapsed time.Duration, err error) {
+		if err != nil {
+			failed.Observe(elapsed.Seconds())
+			return
+		}
+		ok.Observe(elapsed.Seconds())
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// UnmatchedRoute é o label das requisições que não casaram com nenhuma rota,
+// para que caminhos arbitrários não criem novas séries.
+const UnmatchedRoute = "unmatched"
+
+// HTTPMiddleware mede a duração das requisições por método, template da rota
+// do mux e status. Deve ser usado no router raiz; para contar também os 404 e
+// 405, envolva os handlers NotFoundHandler e MethodNotAllowedHandler.
+func HTTPMiddleware(reg *Registry) mux.MiddlewareFunc {
+	duration := reg.NewHistogram(
+		"http_request_duration_seconds",
+		"Duration of HTTP requests by method, route template and status.",
+		DefaultBuckets,
+		"method", "route", "status",
+	)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(recorder, r)
+
+			duration.WithLabelValues(
+				r.Method,
+				routeTemplate(r),
+				strconv.Itoa(recorder.status),
+			).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return UnmatchedRoute
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return UnmatchedRoute
+	}
+	return template
+}
+
+// statusRecorder guarda o status enviado pelo handler. Repassa Flush para que
+// os streams SSE continuem funcionando.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Flush() {
+	r.wroteHeader = true
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap permite que o http.ResponseController alcance o ResponseWriter original.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,133 @@
+// Package metrics exposes application metrics in the Prometheus format, using
+// the official client library.
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultBuckets são os limites, em segundos, usados para latências.
+var DefaultBuckets = prometheus.DefBuckets
+
+// collectTimeout limita a leitura das métricas coletadas do banco a cada
+// coleta; a biblioteca não repassa o contexto da requisição.
+const collectTimeout = 10 * time.Second
+
+// Sample is one value of a metric collected at scrape time; Labels follows
+// the order of the label names given at registration.
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// CollectFunc returns the current samples of a gauge. It runs on every
+// scrape.
+type CollectFunc func(ctx context.Context) ([]Sample, error)
+
+// Registry holds the registered metrics and serves them on /metrics.
+type Registry struct {
+	reg *prometheus.Registry
+}
+
+// NewRegistry creates a new Registry with the Go runtime and process
+// collectors already registered.
+func NewRegistry() *Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return &Registry{reg: reg}
+}
+
+// NewHistogram registra um histograma com os buckets (limites superiores, em
+// ordem crescente) e os nomes de label informados.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    name,
+		Help:    help,
+		Buckets: buckets,
+	}, labels)
+	r.reg.MustRegister(h)
+	return h
+}
+
+// GaugeFunc registra um gauge cujo valor é lido por collect a cada coleta.
+func (r *Registry) GaugeFunc(name, help string, labels []string, collect CollectFunc) {
+	r.reg.MustRegister(&funcCollector{
+		desc:    prometheus.NewDesc(name, help, labels, nil),
+		name:    name,
+		collect: collect,
+	})
+}
+
+// MustRegister registra coletores prontos da biblioteca.
+func (r *Registry) MustRegister(cs ...prometheus.Collector) {
+	r.reg.MustRegister(cs...)
+}
+
+// Handler serve as métricas. Com token, a coleta precisa enviar
+// "Authorization: Bearer <token>".
+func (r *Registry) Handler(token string) http.Handler {
+	metrics := promhttp.HandlerFor(r.reg, promhttp.HandlerOpts{
+		// Uma métrica com erro não derruba a coleta das demais
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if token != "" && !validToken(req, token) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		metrics.ServeHTTP(w, req)
+	})
+}
+
+func validToken(r *http.Request, token string) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	provided := strings.TrimPrefix(header, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// funcCollector é um gauge lido de uma CollectFunc a cada coleta.
+type funcCollector struct {
+	desc    *prometheus.Desc
+	name    string
+	collect CollectFunc
+}
+
+func (c *funcCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *funcCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	samples, err := c.collect(ctx)
+	if err != nil {
+		// A métrica fica sem amostras nesta coleta; as demais seguem normais
+		log.Printf("Error collecting metric %s: %v", c.name, err)
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for _, sample := range samples {
+		metric, err := prometheus.NewConstMetric(c.desc, prometheus.GaugeValue, sample.Value, sample.Labels...)
+		if err != nil {
+			metric = prometheus.NewInvalidMetric(c.desc, err)
+		}
+		ch <- metric
+	}
+}
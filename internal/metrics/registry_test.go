@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T, h http.Handler, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	h := NewRegistry().Handler("segredo")
+
+	if rec := scrape(t, h, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("without token: status = %d, want 401", rec.Code)
+	}
+	if rec := scrape(t, h, "outro"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status = %d, want 401", rec.Code)
+	}
+	if rec := scrape(t, h, "segredo"); rec.Code != http.StatusOK {
+		t.Errorf("right token: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestGaugeFuncAndHistogram(t *testing.T) {
+	reg := NewRegistry()
+	reg.GaugeFunc("kids_test_present", "Children present.", []string{"tenant"}, func(ctx context.Context) ([]Sample, error) {
+		return []Sample{{Labels: []string{"campus-a"}, Value: 3}}, nil
+	})
+	reg.GaugeFunc("kids_test_broken", "Always fails.", nil, func(ctx context.Context) ([]Sample, error) {
+		return nil, errors.New("database unavailable")
+	})
+	reg.NewHistogram("kids_test_duration_seconds", "Duration.", DefaultBuckets, "route").
+		WithLabelValues("/children/{id}").Observe(0.2)
+
+	rec := scrape(t, reg.Handler(""), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 even with a failing gauge", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`kids_test_present{tenant="campus-a"} 3`,
+		`kids_test_duration_seconds_bucket{route="/children/{id}",le="0.25"} 1`,
+		`kids_test_duration_seconds_count{route="/children/{id}"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape does not contain %q", want)
+		}
+	}
+}
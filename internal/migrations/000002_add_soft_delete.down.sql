@@ -0,0 +1,10 @@
+-- migrations/000002_add_soft_delete.down.sql
+DROP INDEX IF EXISTS idx_groups_ativos;
+DROP INDEX IF EXISTS idx_volunteers_ativos;
+DROP INDEX IF EXISTS idx_caretakers_ativos;
+DROP INDEX IF EXISTS idx_children_ativos;
+
+ALTER TABLE groups DROP COLUMN deleted_at;
+ALTER TABLE volunteers DROP COLUMN deleted_at;
+ALTER TABLE caretakers DROP COLUMN deleted_at;
+ALTER TABLE children DROP COLUMN deleted_at;
@@ -0,0 +1,11 @@
+-- migrations/000002_add_soft_delete.up.sql
+ALTER TABLE children ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE caretakers ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE volunteers ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE groups ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Índices parciais: a maioria das consultas filtra apenas registros ativos
+CREATE INDEX idx_children_ativos ON children(id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_caretakers_ativos ON caretakers(id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_volunteers_ativos ON volunteers(id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_groups_ativos ON groups(id) WHERE deleted_at IS NULL;
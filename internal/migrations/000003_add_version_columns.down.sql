@@ -0,0 +1,5 @@
+-- migrations/000003_add_version_columns.down.sql
+ALTER TABLE groups DROP COLUMN version;
+ALTER TABLE volunteers DROP COLUMN version;
+ALTER TABLE caretakers DROP COLUMN version;
+ALTER TABLE children DROP COLUMN version;
@@ -0,0 +1,6 @@
+-- migrations/000003_add_version_columns.up.sql
+-- Versão usada no controle de concorrência otimista (ETag / If-Match)
+ALTER TABLE children ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE caretakers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE volunteers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE groups ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+-- migrations/000004_add_search_indexes.down.sql
+DROP INDEX IF EXISTS idx_volunteers_email_trgm;
+DROP INDEX IF EXISTS idx_volunteers_nome_trgm;
+DROP INDEX IF EXISTS idx_caretakers_telefone_last4;
+DROP INDEX IF EXISTS idx_caretakers_email_trgm;
+DROP INDEX IF EXISTS idx_caretakers_nome_trgm;
+DROP INDEX IF EXISTS idx_children_nome_trgm;
+
+DROP FUNCTION IF EXISTS f_phone_last4(text);
+DROP FUNCTION IF EXISTS f_unaccent(text);
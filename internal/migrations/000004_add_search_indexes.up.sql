@@ -0,0 +1,21 @@
+-- migrations/000004_add_search_indexes.up.sql
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() não é IMMUTABLE, então não pode ser usada diretamente em índices
+CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text AS $$
+    SELECT public.unaccent('public.unaccent', $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Últimos 4 dígitos do telefone, ignorando formatação
+CREATE OR REPLACE FUNCTION f_phone_last4(text) RETURNS text AS $$
+    SELECT right(regexp_replace($1, '\D', '', 'g'), 4)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Índices trigram para busca aproximada (complementam idx_children_nome etc.)
+CREATE INDEX idx_children_nome_trgm ON children USING GIN (f_unaccent(lower(nome)) gin_trgm_ops);
+CREATE INDEX idx_caretakers_nome_trgm ON caretakers USING GIN (f_unaccent(lower(nome)) gin_trgm_ops);
+CREATE INDEX idx_caretakers_email_trgm ON caretakers USING GIN (lower(email) gin_trgm_ops);
+CREATE INDEX idx_caretakers_telefone_last4 ON caretakers(f_phone_last4(telefone));
+CREATE INDEX idx_volunteers_nome_trgm ON volunteers USING GIN (f_unaccent(lower(nome)) gin_trgm_ops);
+CREATE INDEX idx_volunteers_email_trgm ON volunteers USING GIN (lower(email) gin_trgm_ops);
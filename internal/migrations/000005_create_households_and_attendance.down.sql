@@ -0,0 +1,7 @@
+-- migrations/000005_create_households_and_attendance.down.sql
+DROP TABLE IF EXISTS attendance;
+
+ALTER TABLE caretakers DROP COLUMN household_id;
+ALTER TABLE children DROP COLUMN household_id;
+
+DROP TABLE IF EXISTS households;
@@ -0,0 +1,36 @@
+-- migrations/000005_create_households_and_attendance.up.sql
+CREATE TABLE households (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    primary_contact_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    notes TEXT,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE children ADD COLUMN household_id UUID REFERENCES households(id) ON DELETE SET NULL;
+ALTER TABLE caretakers ADD COLUMN household_id UUID REFERENCES households(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_children_household_id ON children(household_id);
+CREATE INDEX idx_caretakers_household_id ON caretakers(household_id);
+
+CREATE TABLE attendance (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    group_id UUID REFERENCES groups(id) ON DELETE SET NULL,
+    event_id UUID,
+    security_code VARCHAR(10) NOT NULL,
+    checked_in_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    checked_in_by VARCHAR(255),
+    checked_out_at TIMESTAMP WITH TIME ZONE,
+    checked_out_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Uma criança só pode ter um check-in em aberto por vez
+CREATE UNIQUE INDEX idx_attendance_open_child ON attendance(child_id) WHERE checked_out_at IS NULL;
+CREATE INDEX idx_attendance_group_open ON attendance(group_id) WHERE checked_out_at IS NULL;
+CREATE INDEX idx_attendance_event_id ON attendance(event_id);
@@ -0,0 +1,6 @@
+-- migrations/000006_create_custody_restrictions.down.sql
+ALTER TABLE attendance DROP COLUMN released_to_name;
+ALTER TABLE attendance DROP COLUMN released_to_caretaker_id;
+
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS child_restrictions;
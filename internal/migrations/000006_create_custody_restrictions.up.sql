@@ -0,0 +1,33 @@
+-- migrations/000006_create_custody_restrictions.up.sql
+CREATE TABLE child_restrictions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    restricted_caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    restricted_name VARCHAR(255),
+    document_reference VARCHAR(255),
+    notes TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (restricted_caretaker_id IS NOT NULL OR restricted_name IS NOT NULL)
+);
+
+CREATE INDEX idx_child_restrictions_child_id ON child_restrictions(child_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor VARCHAR(255),
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID,
+    details JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+
+ALTER TABLE attendance ADD COLUMN released_to_caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL;
+ALTER TABLE attendance ADD COLUMN released_to_name VARCHAR(255);
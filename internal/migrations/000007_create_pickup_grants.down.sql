@@ -0,0 +1,4 @@
+-- migrations/000007_create_pickup_grants.down.sql
+ALTER TABLE attendance DROP COLUMN pickup_grant_id;
+
+DROP TABLE IF EXISTS pickup_grants;
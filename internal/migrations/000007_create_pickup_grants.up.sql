@@ -0,0 +1,21 @@
+-- migrations/000007_create_pickup_grants.up.sql
+CREATE TABLE pickup_grants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    granted_by_caretaker_id UUID NOT NULL REFERENCES caretakers(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50),
+    photo_url TEXT,
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    event_id UUID,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Autorização vale para uma janela de datas ou para um evento específico
+    CHECK (event_id IS NOT NULL OR (valid_from IS NOT NULL AND valid_until IS NOT NULL))
+);
+
+CREATE INDEX idx_pickup_grants_child_id ON pickup_grants(child_id) WHERE revoked_at IS NULL;
+
+ALTER TABLE attendance ADD COLUMN pickup_grant_id UUID REFERENCES pickup_grants(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- migrations/000008_create_precheckin_redemptions.down.sql
+DROP TABLE IF EXISTS precheckin_redemptions;
@@ -0,0 +1,10 @@
+-- migrations/000008_create_precheckin_redemptions.up.sql
+-- Nonces de pré-check-in já utilizados, para impedir reuso do QR code
+CREATE TABLE precheckin_redemptions (
+    nonce VARCHAR(64) PRIMARY KEY,
+    caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_precheckin_redemptions_expires_at ON precheckin_redemptions(expires_at);
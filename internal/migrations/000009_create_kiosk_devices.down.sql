@@ -0,0 +1,4 @@
+-- migrations/000009_create_kiosk_devices.down.sql
+ALTER TABLE attendance DROP COLUMN location_id;
+
+DROP TABLE IF EXISTS kiosk_devices;
@@ -0,0 +1,20 @@
+-- migrations/000009_create_kiosk_devices.up.sql
+CREATE TABLE kiosk_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    location_id UUID,
+    pairing_code_hash VARCHAR(64),
+    pairing_expires_at TIMESTAMP WITH TIME ZONE,
+    token_hash VARCHAR(64) UNIQUE,
+    paired_at TIMESTAMP WITH TIME ZONE,
+    last_seen_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_kiosk_devices_pairing_code_hash ON kiosk_devices(pairing_code_hash) WHERE pairing_code_hash IS NOT NULL;
+
+-- Local onde o check-in foi feito (quiosque ou recepção)
+ALTER TABLE attendance ADD COLUMN location_id UUID;
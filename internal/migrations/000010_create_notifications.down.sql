@@ -0,0 +1,2 @@
+-- migrations/000010_create_notifications.down.sql
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,2 @@
+-- migrations/000012_create_group_events.down.sql
+DROP TABLE IF EXISTS group_events;
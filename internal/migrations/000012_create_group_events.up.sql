@@ -0,0 +1,11 @@
+-- migrations/000012_create_group_events.up.sql
+-- Eventos do painel da sala; o ID sequencial é o Last-Event-ID do SSE
+CREATE TABLE group_events (
+    id BIGSERIAL PRIMARY KEY,
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_group_events_group ON group_events(group_id, id);
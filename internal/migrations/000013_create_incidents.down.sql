@@ -0,0 +1,3 @@
+-- migrations/000013_create_incidents.down.sql
+DROP TABLE IF EXISTS incident_volunteers;
+DROP TABLE IF EXISTS incidents;
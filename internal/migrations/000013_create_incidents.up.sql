@@ -0,0 +1,34 @@
+-- migrations/000013_create_incidents.up.sql
+CREATE TABLE incidents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    group_id UUID REFERENCES groups(id) ON DELETE SET NULL,
+    event_id UUID,
+    type VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL,
+    first_aid TEXT,
+    witnesses TEXT[] NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    reported_by VARCHAR(255),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_notes TEXT,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    acknowledged_by_caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Voluntários que registraram ou atenderam a ocorrência
+CREATE TABLE incident_volunteers (
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    volunteer_id UUID NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    PRIMARY KEY (incident_id, volunteer_id)
+);
+
+CREATE INDEX idx_incidents_child ON incidents(child_id);
+CREATE INDEX idx_incidents_status ON incidents(status);
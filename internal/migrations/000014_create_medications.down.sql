@@ -0,0 +1,3 @@
+-- migrations/000014_create_medications.down.sql
+DROP TABLE IF EXISTS medication_administrations;
+DROP TABLE IF EXISTS medication_orders;
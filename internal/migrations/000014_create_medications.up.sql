@@ -0,0 +1,38 @@
+-- migrations/000014_create_medications.up.sql
+CREATE TABLE medication_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    drug VARCHAR(255) NOT NULL,
+    dose VARCHAR(100) NOT NULL,
+    route VARCHAR(50),
+    schedule VARCHAR(255) NOT NULL,
+    as_needed BOOLEAN NOT NULL DEFAULT FALSE,
+    instructions TEXT,
+    -- Autorização assinada pelo responsável
+    consent_document_url TEXT NOT NULL,
+    consent_caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    consent_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    discontinued_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE medication_administrations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES medication_orders(id) ON DELETE CASCADE,
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    dose_given VARCHAR(100) NOT NULL,
+    administered_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    administered_by_volunteer_id UUID NOT NULL REFERENCES volunteers(id),
+    verified_by_volunteer_id UUID NOT NULL REFERENCES volunteers(id),
+    notes TEXT,
+    recorded_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Regra das duas pessoas: quem administra não pode ser quem confere
+    CONSTRAINT chk_medication_two_person CHECK (administered_by_volunteer_id <> verified_by_volunteer_id)
+);
+
+CREATE INDEX idx_medication_orders_child ON medication_orders(child_id);
+CREATE INDEX idx_medication_administrations_order ON medication_administrations(order_id, administered_at DESC);
+CREATE INDEX idx_medication_administrations_child ON medication_administrations(child_id, administered_at DESC);
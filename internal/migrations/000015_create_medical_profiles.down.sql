@@ -0,0 +1,3 @@
+-- migrations/000015_create_medical_profiles.down.sql
+DROP TABLE IF EXISTS child_emergency_contacts;
+DROP TABLE IF EXISTS child_medical_profiles;
@@ -0,0 +1,33 @@
+-- migrations/000015_create_medical_profiles.up.sql
+CREATE TABLE child_medical_profiles (
+    child_id UUID PRIMARY KEY REFERENCES children(id) ON DELETE CASCADE,
+    blood_type VARCHAR(3),
+    doctor_name VARCHAR(255),
+    doctor_phone VARCHAR(20),
+    insurance_provider VARCHAR(255),
+    insurance_policy_number VARCHAR(100),
+    conditions TEXT[] NOT NULL DEFAULT '{}',
+    dietary_restrictions TEXT[] NOT NULL DEFAULT '{}',
+    notes TEXT,
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Contatos de emergência em ordem de prioridade; podem ou não ser responsáveis cadastrados
+CREATE TABLE child_emergency_contacts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    caretaker_id UUID REFERENCES caretakers(id) ON DELETE CASCADE,
+    name VARCHAR(255),
+    relationship VARCHAR(50),
+    phone VARCHAR(20),
+    alt_phone VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (child_id, position),
+    CONSTRAINT chk_emergency_contact_identity CHECK (caretaker_id IS NOT NULL OR (name IS NOT NULL AND phone IS NOT NULL))
+);
+
+CREATE INDEX idx_child_emergency_contacts_child ON child_emergency_contacts(child_id, position);
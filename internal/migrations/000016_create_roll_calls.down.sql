@@ -0,0 +1,3 @@
+-- migrations/000016_create_roll_calls.down.sql
+DROP TABLE IF EXISTS roll_call_entries;
+DROP TABLE IF EXISTS roll_calls;
@@ -0,0 +1,25 @@
+-- migrations/000016_create_roll_calls.up.sql
+CREATE TABLE roll_calls (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    location_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    started_by VARCHAR(255) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_by VARCHAR(255),
+    closed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Apenas uma chamada de emergência aberta por local
+CREATE UNIQUE INDEX idx_roll_calls_open_location ON roll_calls(location_id) WHERE status = 'open';
+
+-- Fotografia das presenças no momento em que a chamada foi aberta
+CREATE TABLE roll_call_entries (
+    roll_call_id UUID NOT NULL REFERENCES roll_calls(id) ON DELETE CASCADE,
+    attendance_id UUID NOT NULL REFERENCES attendance(id) ON DELETE CASCADE,
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    group_id UUID REFERENCES groups(id) ON DELETE SET NULL,
+    accounted_by VARCHAR(255),
+    accounted_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (roll_call_id, attendance_id),
+    UNIQUE (roll_call_id, child_id)
+);
@@ -0,0 +1,33 @@
+-- migrations/000017_add_tenants.down.sql
+ALTER TABLE jobs DROP COLUMN tenant_id;
+ALTER TABLE kiosk_devices DROP COLUMN tenant_id;
+
+ALTER TABLE volunteers DROP CONSTRAINT volunteers_tenant_auth0_id_key;
+ALTER TABLE volunteers DROP CONSTRAINT volunteers_tenant_email_key;
+ALTER TABLE volunteers ADD CONSTRAINT volunteers_auth0_id_key UNIQUE (auth0_id);
+ALTER TABLE volunteers ADD CONSTRAINT volunteers_email_key UNIQUE (email);
+ALTER TABLE caretakers DROP CONSTRAINT caretakers_tenant_auth0_id_key;
+ALTER TABLE caretakers DROP CONSTRAINT caretakers_tenant_email_key;
+ALTER TABLE caretakers ADD CONSTRAINT caretakers_auth0_id_key UNIQUE (auth0_id);
+ALTER TABLE caretakers ADD CONSTRAINT caretakers_email_key UNIQUE (email);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'groups', 'needs', 'allergies', 'children', 'children_needs', 'children_allergies',
+        'caretakers', 'children_caretakers', 'volunteers', 'households', 'attendance',
+        'child_restrictions', 'audit_log', 'pickup_grants', 'precheckin_redemptions',
+        'notifications', 'group_events', 'incidents', 'incident_volunteers',
+        'medication_orders', 'medication_administrations', 'child_medical_profiles',
+        'child_emergency_contacts', 'roll_calls', 'roll_call_entries'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN tenant_id', t);
+    END LOOP;
+END $$;
+
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,61 @@
+-- migrations/000017_add_tenants.up.sql
+CREATE TABLE tenants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Tenant que recebe os dados cadastrados antes da separação por campus
+INSERT INTO tenants (id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'Default');
+
+-- Tabelas isoladas por row-level security. O tenant vem de app.tenant_id,
+-- definido pela API em cada transação; sem ele, nenhuma linha é visível e
+-- nenhum INSERT é aceito. FORCE aplica as políticas também ao dono das tabelas
+-- (a API não deve conectar com um superusuário ou papel com BYPASSRLS).
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'groups', 'needs', 'allergies', 'children', 'children_needs', 'children_allergies',
+        'caretakers', 'children_caretakers', 'volunteers', 'households', 'attendance',
+        'child_restrictions', 'audit_log', 'pickup_grants', 'precheckin_redemptions',
+        'notifications', 'group_events', 'incidents', 'incident_volunteers',
+        'medication_orders', 'medication_administrations', 'child_medical_profiles',
+        'child_emergency_contacts', 'roll_calls', 'roll_call_entries'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id UUID REFERENCES tenants(id)', t);
+        EXECUTE format('UPDATE %I SET tenant_id = %L', t, '00000000-0000-0000-0000-000000000001');
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET NOT NULL', t);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET DEFAULT NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid', t);
+        EXECUTE format('CREATE INDEX %I ON %I(tenant_id)', 'idx_' || t || '_tenant', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format(
+            'CREATE POLICY tenant_isolation ON %I
+                USING (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)
+                WITH CHECK (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)',
+            t
+        );
+    END LOOP;
+END $$;
+
+-- E-mail e login passam a ser únicos por campus
+ALTER TABLE caretakers DROP CONSTRAINT caretakers_email_key;
+ALTER TABLE caretakers DROP CONSTRAINT caretakers_auth0_id_key;
+ALTER TABLE caretakers ADD CONSTRAINT caretakers_tenant_email_key UNIQUE (tenant_id, email);
+ALTER TABLE caretakers ADD CONSTRAINT caretakers_tenant_auth0_id_key UNIQUE (tenant_id, auth0_id);
+ALTER TABLE volunteers DROP CONSTRAINT volunteers_email_key;
+ALTER TABLE volunteers DROP CONSTRAINT volunteers_auth0_id_key;
+ALTER TABLE volunteers ADD CONSTRAINT volunteers_tenant_email_key UNIQUE (tenant_id, email);
+ALTER TABLE volunteers ADD CONSTRAINT volunteers_tenant_auth0_id_key UNIQUE (tenant_id, auth0_id);
+
+-- Quiosques e trabalhos da fila são lidos antes de o tenant ser conhecido
+-- (credencial do quiosque, worker); guardam o tenant sem RLS.
+ALTER TABLE kiosk_devices ADD COLUMN tenant_id UUID REFERENCES tenants(id);
+UPDATE kiosk_devices SET tenant_id = '00000000-0000-0000-0000-000000000001';
+ALTER TABLE kiosk_devices ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE kiosk_devices ALTER COLUMN tenant_id SET DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid;
+
+ALTER TABLE jobs ADD COLUMN tenant_id UUID REFERENCES tenants(id);
+ALTER TABLE jobs ALTER COLUMN tenant_id SET DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid;
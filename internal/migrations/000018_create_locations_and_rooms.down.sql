@@ -0,0 +1,10 @@
+-- migrations/000018_create_locations_and_rooms.down.sql
+ALTER TABLE roll_calls DROP CONSTRAINT IF EXISTS fk_roll_calls_location;
+ALTER TABLE kiosk_devices DROP CONSTRAINT IF EXISTS fk_kiosk_devices_location;
+ALTER TABLE attendance DROP CONSTRAINT IF EXISTS fk_attendance_location;
+DROP INDEX IF EXISTS idx_attendance_open_room;
+ALTER TABLE attendance DROP COLUMN IF EXISTS room_id;
+DROP TABLE IF EXISTS room_staff;
+DROP TABLE IF EXISTS room_assignments;
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS locations;
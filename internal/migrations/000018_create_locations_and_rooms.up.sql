@@ -0,0 +1,81 @@
+-- migrations/000018_create_locations_and_rooms.up.sql
+CREATE TABLE locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    notes TEXT,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Sala física; a capacidade e a proporção crianças/voluntário valem para a
+-- sala, independentemente de quantos grupos ela recebe
+CREATE TABLE rooms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    floor VARCHAR(50),
+    capacity INTEGER NOT NULL DEFAULT 0,
+    children_per_volunteer INTEGER NOT NULL DEFAULT 0,
+    wheelchair_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    accessibility_notes TEXT,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_rooms_location ON rooms(location_id);
+
+-- Salas usadas por cada grupo em um evento: dois grupos podem dividir uma
+-- sala e um grupo pode ocupar várias (preenchidas na ordem de position)
+CREATE TABLE room_assignments (
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    event_id UUID NOT NULL,
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (event_id, group_id, room_id)
+);
+
+CREATE INDEX idx_room_assignments_room ON room_assignments(event_id, room_id);
+
+-- Voluntários escalados na sala durante o evento (base da proporção)
+CREATE TABLE room_staff (
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    event_id UUID NOT NULL,
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    volunteer_id UUID NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, room_id, volunteer_id)
+);
+
+ALTER TABLE attendance ADD COLUMN room_id UUID REFERENCES rooms(id) ON DELETE SET NULL;
+CREATE INDEX idx_attendance_open_room ON attendance(room_id) WHERE checked_out_at IS NULL;
+
+-- Locais já gravados antes desta migração não são validados (NOT VALID)
+ALTER TABLE attendance ADD CONSTRAINT fk_attendance_location
+    FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE SET NULL NOT VALID;
+ALTER TABLE kiosk_devices ADD CONSTRAINT fk_kiosk_devices_location
+    FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE SET NULL NOT VALID;
+ALTER TABLE roll_calls ADD CONSTRAINT fk_roll_calls_location
+    FOREIGN KEY (location_id) REFERENCES locations(id) NOT VALID;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['locations', 'rooms', 'room_assignments', 'room_staff'] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format(
+            'CREATE POLICY tenant_isolation ON %I
+                USING (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)
+                WITH CHECK (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)',
+            t
+        );
+    END LOOP;
+END $$;
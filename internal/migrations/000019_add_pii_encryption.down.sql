@@ -0,0 +1,26 @@
+-- migrations/000019_add_pii_encryption.down.sql
+-- Os valores precisam estar em texto puro antes de reverter (as colunas voltam
+-- aos tamanhos originais).
+ALTER TABLE caretakers DROP CONSTRAINT caretakers_tenant_email_index_key;
+ALTER TABLE caretakers ADD CONSTRAINT caretakers_tenant_email_key UNIQUE (tenant_id, email);
+
+DROP INDEX IF EXISTS idx_caretakers_phone_last4_index;
+DROP INDEX IF EXISTS idx_caretakers_phone_index;
+DROP INDEX IF EXISTS idx_caretakers_email_index;
+CREATE INDEX idx_caretakers_email_trgm ON caretakers USING GIN (lower(email) gin_trgm_ops);
+CREATE INDEX idx_caretakers_telefone_last4 ON caretakers(f_phone_last4(telefone));
+
+ALTER TABLE caretakers DROP COLUMN phone_last4_index;
+ALTER TABLE caretakers DROP COLUMN phone_index;
+ALTER TABLE caretakers DROP COLUMN email_index;
+
+ALTER TABLE child_emergency_contacts ALTER COLUMN alt_phone TYPE VARCHAR(20);
+ALTER TABLE child_emergency_contacts ALTER COLUMN phone TYPE VARCHAR(20);
+ALTER TABLE child_medical_profiles ALTER COLUMN insurance_policy_number TYPE VARCHAR(100);
+ALTER TABLE child_medical_profiles ALTER COLUMN doctor_phone TYPE VARCHAR(20);
+ALTER TABLE pickup_grants ALTER COLUMN phone TYPE VARCHAR(50);
+ALTER TABLE child_restrictions ALTER COLUMN document_reference TYPE VARCHAR(255);
+ALTER TABLE caretakers ALTER COLUMN telefone TYPE VARCHAR(50);
+ALTER TABLE caretakers ALTER COLUMN email TYPE VARCHAR(255);
+
+DROP TABLE IF EXISTS encryption_keys;
@@ -0,0 +1,38 @@
+-- migrations/000019_add_pii_encryption.up.sql
+-- Chaves de dados, cifradas (wrapped) pela chave mestra da configuração
+CREATE TABLE encryption_keys (
+    id SERIAL PRIMARY KEY,
+    wrapped_key BYTEA NOT NULL,
+    master_key_id VARCHAR(16) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_encryption_keys_active ON encryption_keys(active) WHERE active;
+
+-- Valores cifrados são maiores que os originais
+ALTER TABLE caretakers ALTER COLUMN email TYPE TEXT;
+ALTER TABLE caretakers ALTER COLUMN telefone TYPE TEXT;
+ALTER TABLE child_restrictions ALTER COLUMN document_reference TYPE TEXT;
+ALTER TABLE pickup_grants ALTER COLUMN phone TYPE TEXT;
+ALTER TABLE child_medical_profiles ALTER COLUMN doctor_phone TYPE TEXT;
+ALTER TABLE child_medical_profiles ALTER COLUMN insurance_policy_number TYPE TEXT;
+ALTER TABLE child_emergency_contacts ALTER COLUMN phone TYPE TEXT;
+ALTER TABLE child_emergency_contacts ALTER COLUMN alt_phone TYPE TEXT;
+
+-- Índices cegos (HMAC) para buscar responsáveis por e-mail e telefone cifrados.
+-- São preenchidos na gravação e, para os registros antigos, pelo comando
+-- "kids-api rotate-keys", que também cifra os valores ainda em texto puro.
+ALTER TABLE caretakers ADD COLUMN email_index VARCHAR(64);
+ALTER TABLE caretakers ADD COLUMN phone_index VARCHAR(64);
+ALTER TABLE caretakers ADD COLUMN phone_last4_index VARCHAR(64);
+
+DROP INDEX IF EXISTS idx_caretakers_email_trgm;
+DROP INDEX IF EXISTS idx_caretakers_telefone_last4;
+CREATE INDEX idx_caretakers_email_index ON caretakers(email_index);
+CREATE INDEX idx_caretakers_phone_index ON caretakers(phone_index);
+CREATE INDEX idx_caretakers_phone_last4_index ON caretakers(phone_last4_index);
+
+-- A unicidade do e-mail passa a valer sobre o índice cego (o texto cifrado muda a cada gravação)
+ALTER TABLE caretakers DROP CONSTRAINT caretakers_tenant_email_key;
+ALTER TABLE caretakers ADD CONSTRAINT caretakers_tenant_email_index_key UNIQUE (tenant_id, email_index);
@@ -0,0 +1,2 @@
+-- migrations/000020_create_data_subject_requests.down.sql
+DROP TABLE IF EXISTS data_subject_requests;
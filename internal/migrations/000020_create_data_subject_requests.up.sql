@@ -0,0 +1,27 @@
+-- migrations/000020_create_data_subject_requests.up.sql
+-- Pedidos do titular (LGPD): exportação dos dados ou anonimização
+CREATE TABLE data_subject_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    -- Mantido após a exclusão definitiva do responsável, como prova do atendimento ao pedido
+    caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('export', 'erasure')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    reason TEXT,
+    requested_by VARCHAR(255) NOT NULL,
+    result JSONB,
+    -- Arquivo zip da exportação (data.json + fotos)
+    archive BYTEA,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_data_subject_requests_caretaker ON data_subject_requests(caretaker_id, created_at DESC);
+
+ALTER TABLE data_subject_requests ENABLE ROW LEVEL SECURITY;
+ALTER TABLE data_subject_requests FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON data_subject_requests
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
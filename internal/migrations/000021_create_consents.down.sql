@@ -0,0 +1,3 @@
+-- migrations/000021_create_consents.down.sql
+DROP TABLE IF EXISTS consent_records;
+DROP TABLE IF EXISTS consent_documents;
@@ -0,0 +1,51 @@
+-- migrations/000021_create_consents.up.sql
+-- Termos versionados (autorização de imagem, termo de cuidado). Cada nova
+-- versão exige um novo aceite; os aceites anteriores ficam desatualizados.
+CREATE TABLE consent_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('media_release', 'care_terms')),
+    version INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    published_by VARCHAR(255) NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (tenant_id, kind, version)
+);
+
+-- Resposta de um responsável a uma versão do termo, para uma criança.
+-- granted = FALSE registra a recusa (por exemplo, não autorizar fotos).
+CREATE TABLE consent_records (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    document_id UUID NOT NULL REFERENCES consent_documents(id),
+    child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    caretaker_id UUID REFERENCES caretakers(id) ON DELETE SET NULL,
+    granted BOOLEAN NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    ip_address VARCHAR(64),
+    device VARCHAR(255),
+    recorded_by VARCHAR(255) NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by VARCHAR(255),
+    revocation_reason TEXT
+);
+
+CREATE INDEX idx_consent_records_child ON consent_records(child_id, accepted_at DESC);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['consent_documents', 'consent_records'] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format(
+            'CREATE POLICY tenant_isolation ON %I
+                USING (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)
+                WITH CHECK (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)',
+            t
+        );
+    END LOOP;
+END $$;
@@ -0,0 +1,2 @@
+-- migrations/000022_create_sensitive_access_log.down.sql
+DROP TABLE IF EXISTS sensitive_access_log;
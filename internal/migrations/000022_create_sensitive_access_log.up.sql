@@ -0,0 +1,25 @@
+-- migrations/000022_create_sensitive_access_log.up.sql
+-- Registro de leituras de dados sensíveis (ficha médica, restrições de guarda,
+-- contatos). Complementa o audit_log, que registra apenas alterações.
+CREATE TABLE sensitive_access_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    actor VARCHAR(255) NOT NULL,
+    roles TEXT[] NOT NULL DEFAULT '{}',
+    resource VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    endpoint VARCHAR(255),
+    accessed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_sensitive_access_log_actor ON sensitive_access_log(actor, accessed_at);
+CREATE INDEX idx_sensitive_access_log_entity ON sensitive_access_log(entity_type, entity_id, accessed_at);
+CREATE INDEX idx_sensitive_access_log_accessed_at ON sensitive_access_log(accessed_at);
+
+ALTER TABLE sensitive_access_log ENABLE ROW LEVEL SECURITY;
+ALTER TABLE sensitive_access_log FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON sensitive_access_log
+    USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid)
+    WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
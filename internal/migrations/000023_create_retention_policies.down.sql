@@ -0,0 +1,4 @@
+-- migrations/000023_create_retention_policies.down.sql
+DROP INDEX IF EXISTS idx_attendance_child_checked_in;
+DROP TABLE IF EXISTS retention_runs;
+DROP TABLE IF EXISTS retention_policies;
@@ -0,0 +1,50 @@
+-- migrations/000023_create_retention_policies.up.sql
+-- Regras de retenção por campus. Cada regra tem uma ação fixa (anonimizar,
+-- excluir, remover a foto) e vale após after_days sem atividade.
+CREATE TABLE retention_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    rule VARCHAR(50) NOT NULL CHECK (rule IN (
+        'inactive_child_anonymize', 'incident_draft_purge', 'inactive_child_photo', 'access_log_purge'
+    )),
+    after_days INTEGER NOT NULL CHECK (after_days > 0),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (tenant_id, rule)
+);
+
+-- Execuções das regras. dry_run = TRUE apenas relata o que seria afetado.
+CREATE TABLE retention_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants(id),
+    dry_run BOOLEAN NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    requested_by VARCHAR(255) NOT NULL,
+    result JSONB,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_retention_runs_created_at ON retention_runs(created_at DESC);
+
+-- Busca das crianças sem atividade recente
+CREATE INDEX idx_attendance_child_checked_in ON attendance(child_id, checked_in_at DESC);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['retention_policies', 'retention_runs'] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format(
+            'CREATE POLICY tenant_isolation ON %I
+                USING (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)
+                WITH CHECK (tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)',
+            t
+        );
+    END LOOP;
+END $$;
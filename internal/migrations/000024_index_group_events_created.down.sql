@@ -0,0 +1,2 @@
+-- migrations/000024_index_group_events_created.down.sql
+DROP INDEX IF EXISTS idx_group_events_created;
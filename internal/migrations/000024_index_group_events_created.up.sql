@@ -0,0 +1,3 @@
+-- migrations/000024_index_group_events_created.up.sql
+-- Remoção periódica dos eventos que já saíram da janela de replay
+CREATE INDEX idx_group_events_created ON group_events(created_at);
@@ -0,0 +1,4 @@
+-- migrations/000025_add_medication_verification.down.sql
+ALTER TABLE medication_administrations
+    DROP COLUMN IF EXISTS verified_by,
+    DROP COLUMN IF EXISTS verified_at;
@@ -0,0 +1,9 @@
+-- migrations/000025_add_medication_verification.up.sql
+-- A conferência da dose passa a ser confirmada pelo segundo voluntário, com a
+-- própria identidade, depois do registro
+ALTER TABLE medication_administrations
+    ADD COLUMN verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN verified_by VARCHAR(255);
+
+-- Registros anteriores já nomeavam os dois voluntários no momento da dose
+UPDATE medication_administrations SET verified_at = created_at;
@@ -0,0 +1,20 @@
+-- migrations/000026_encrypt_caretaker_and_medical_data.down.sql
+-- Exige que as condições estejam em texto puro: valores cifrados não são JSON
+ALTER TABLE child_medical_profiles ADD COLUMN conditions_array TEXT[] NOT NULL DEFAULT '{}';
+UPDATE child_medical_profiles
+    SET conditions_array = ARRAY(SELECT json_array_elements_text(conditions::json));
+ALTER TABLE child_medical_profiles DROP COLUMN conditions;
+ALTER TABLE child_medical_profiles RENAME COLUMN conditions_array TO conditions;
+
+ALTER TABLE allergies RENAME COLUMN updated_at TO atualizado_em;
+ALTER TABLE allergies RENAME COLUMN created_at TO criado_em;
+ALTER TABLE allergies RENAME COLUMN severity TO gravidade;
+ALTER TABLE allergies RENAME COLUMN description TO descricao;
+ALTER TABLE allergies RENAME COLUMN type TO tipo;
+
+ALTER TABLE caretakers DROP COLUMN IF EXISTS address;
+ALTER TABLE caretakers RENAME COLUMN updated_at TO atualizado_em;
+ALTER TABLE caretakers RENAME COLUMN created_at TO criado_em;
+ALTER TABLE caretakers RENAME COLUMN phone_type TO tipo_telefone;
+ALTER TABLE caretakers RENAME COLUMN phone TO telefone;
+ALTER TABLE caretakers RENAME COLUMN name TO nome;
@@ -0,0 +1,20 @@
+-- migrations/000026_encrypt_caretaker_and_medical_data.up.sql
+-- Os repositórios já usam os nomes em inglês; o endereço do responsável é gravado cifrado
+ALTER TABLE caretakers RENAME COLUMN nome TO name;
+ALTER TABLE caretakers RENAME COLUMN telefone TO phone;
+ALTER TABLE caretakers RENAME COLUMN tipo_telefone TO phone_type;
+ALTER TABLE caretakers RENAME COLUMN criado_em TO created_at;
+ALTER TABLE caretakers RENAME COLUMN atualizado_em TO updated_at;
+ALTER TABLE caretakers ADD COLUMN address TEXT;
+
+ALTER TABLE allergies RENAME COLUMN tipo TO type;
+ALTER TABLE allergies RENAME COLUMN descricao TO description;
+ALTER TABLE allergies RENAME COLUMN gravidade TO severity;
+ALTER TABLE allergies RENAME COLUMN criado_em TO created_at;
+ALTER TABLE allergies RENAME COLUMN atualizado_em TO updated_at;
+
+-- As condições médicas passam a ser uma lista JSON gravada cifrada (pii.Strings).
+-- Os valores existentes ficam em texto puro até o "kids-api rotate-keys".
+ALTER TABLE child_medical_profiles ALTER COLUMN conditions DROP DEFAULT;
+ALTER TABLE child_medical_profiles ALTER COLUMN conditions TYPE TEXT USING array_to_json(conditions)::text;
+ALTER TABLE child_medical_profiles ALTER COLUMN conditions SET DEFAULT '[]';
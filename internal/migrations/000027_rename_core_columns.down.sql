@@ -0,0 +1,40 @@
+-- migrations/000027_rename_core_columns.down.sql
+ALTER TABLE volunteers ALTER COLUMN phone DROP NOT NULL;
+ALTER TABLE volunteers ALTER COLUMN phone DROP DEFAULT;
+ALTER TABLE volunteers DROP COLUMN IF EXISTS availability;
+ALTER TABLE volunteers DROP COLUMN IF EXISTS skills;
+ALTER TABLE volunteers RENAME COLUMN updated_at TO atualizado_em;
+ALTER TABLE volunteers RENAME COLUMN created_at TO criado_em;
+ALTER TABLE volunteers RENAME COLUMN background_checked_at TO data_verificacao;
+ALTER TABLE volunteers RENAME COLUMN background_checked TO verificacao_background;
+ALTER TABLE volunteers RENAME COLUMN photo_url TO foto_url;
+ALTER TABLE volunteers RENAME COLUMN birth_date TO data_nascimento;
+ALTER TABLE volunteers RENAME COLUMN phone TO telefone;
+ALTER TABLE volunteers RENAME COLUMN name TO nome;
+
+ALTER TABLE children RENAME COLUMN updated_at TO atualizado_em;
+ALTER TABLE children RENAME COLUMN created_at TO criado_em;
+ALTER TABLE children RENAME COLUMN group_id TO grupo_id;
+ALTER TABLE children RENAME COLUMN photo_url TO foto_url;
+ALTER TABLE children RENAME COLUMN gender TO sexo;
+ALTER TABLE children RENAME COLUMN birth_date TO data_nascimento;
+ALTER TABLE children RENAME COLUMN name TO nome;
+
+ALTER TABLE needs ALTER COLUMN description DROP NOT NULL;
+ALTER TABLE needs ALTER COLUMN description DROP DEFAULT;
+ALTER TABLE needs RENAME COLUMN updated_at TO atualizado_em;
+ALTER TABLE needs RENAME COLUMN created_at TO criado_em;
+ALTER TABLE needs RENAME COLUMN description TO descricao;
+ALTER TABLE needs RENAME COLUMN type TO tipo;
+
+-- As faixas etárias livres não voltam a ser números: os grupos ficam sem limites de idade
+ALTER TABLE groups ADD COLUMN idade_minima INTEGER;
+ALTER TABLE groups ADD COLUMN idade_maxima INTEGER;
+ALTER TABLE groups DROP COLUMN IF EXISTS capacity;
+ALTER TABLE groups DROP COLUMN IF EXISTS age_range;
+ALTER TABLE groups ALTER COLUMN description DROP NOT NULL;
+ALTER TABLE groups ALTER COLUMN description DROP DEFAULT;
+ALTER TABLE groups RENAME COLUMN updated_at TO atualizado_em;
+ALTER TABLE groups RENAME COLUMN created_at TO criado_em;
+ALTER TABLE groups RENAME COLUMN description TO descricao;
+ALTER TABLE groups RENAME COLUMN name TO nome;
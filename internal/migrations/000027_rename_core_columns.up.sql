@@ -0,0 +1,47 @@
+-- migrations/000027_rename_core_columns.up.sql
+-- As tabelas da primeira migração usavam nomes em português; os repositórios
+-- e os modelos usam os nomes em inglês
+ALTER TABLE groups RENAME COLUMN nome TO name;
+ALTER TABLE groups RENAME COLUMN descricao TO description;
+ALTER TABLE groups RENAME COLUMN criado_em TO created_at;
+ALTER TABLE groups RENAME COLUMN atualizado_em TO updated_at;
+ALTER TABLE groups ADD COLUMN age_range VARCHAR(50) NOT NULL DEFAULT '';
+-- Zero indica que o grupo não tem limite próprio (vale o das salas)
+ALTER TABLE groups ADD COLUMN capacity INTEGER NOT NULL DEFAULT 0;
+UPDATE groups SET age_range = idade_minima || '-' || idade_maxima;
+UPDATE groups SET description = '' WHERE description IS NULL;
+ALTER TABLE groups ALTER COLUMN description SET DEFAULT '';
+ALTER TABLE groups ALTER COLUMN description SET NOT NULL;
+ALTER TABLE groups DROP COLUMN idade_minima;
+ALTER TABLE groups DROP COLUMN idade_maxima;
+
+ALTER TABLE needs RENAME COLUMN tipo TO type;
+ALTER TABLE needs RENAME COLUMN descricao TO description;
+ALTER TABLE needs RENAME COLUMN criado_em TO created_at;
+ALTER TABLE needs RENAME COLUMN atualizado_em TO updated_at;
+UPDATE needs SET description = '' WHERE description IS NULL;
+ALTER TABLE needs ALTER COLUMN description SET DEFAULT '';
+ALTER TABLE needs ALTER COLUMN description SET NOT NULL;
+
+ALTER TABLE children RENAME COLUMN nome TO name;
+ALTER TABLE children RENAME COLUMN data_nascimento TO birth_date;
+ALTER TABLE children RENAME COLUMN sexo TO gender;
+ALTER TABLE children RENAME COLUMN foto_url TO photo_url;
+ALTER TABLE children RENAME COLUMN grupo_id TO group_id;
+ALTER TABLE children RENAME COLUMN criado_em TO created_at;
+ALTER TABLE children RENAME COLUMN atualizado_em TO updated_at;
+UPDATE children SET photo_url = NULL WHERE photo_url = '';
+
+ALTER TABLE volunteers RENAME COLUMN nome TO name;
+ALTER TABLE volunteers RENAME COLUMN telefone TO phone;
+ALTER TABLE volunteers RENAME COLUMN data_nascimento TO birth_date;
+ALTER TABLE volunteers RENAME COLUMN foto_url TO photo_url;
+ALTER TABLE volunteers RENAME COLUMN verificacao_background TO background_checked;
+ALTER TABLE volunteers RENAME COLUMN data_verificacao TO background_checked_at;
+ALTER TABLE volunteers RENAME COLUMN criado_em TO created_at;
+ALTER TABLE volunteers RENAME COLUMN atualizado_em TO updated_at;
+ALTER TABLE volunteers ADD COLUMN skills TEXT NOT NULL DEFAULT '';
+ALTER TABLE volunteers ADD COLUMN availability TEXT NOT NULL DEFAULT '';
+UPDATE volunteers SET phone = '' WHERE phone IS NULL;
+ALTER TABLE volunteers ALTER COLUMN phone SET DEFAULT '';
+ALTER TABLE volunteers ALTER COLUMN phone SET NOT NULL;
@@ -0,0 +1,3 @@
+-- migrations/000028_scrub_attendance_events.down.sql
+-- Os dados removidos não podem ser restaurados.
+SELECT 1;
@@ -0,0 +1,16 @@
+-- migrations/000028_scrub_attendance_events.up.sql
+-- Os eventos de entrada e saída guardavam o registro de presença inteiro,
+-- com o código de segurança e quem retirou a criança. O payload passa a ter
+-- só os dados do painel; os eventos ainda na janela de replay são ajustados.
+UPDATE group_events
+SET data = jsonb_build_object('attendance_id', data->'id') || (data
+    - 'id'
+    - 'security_code'
+    - 'checked_in_by'
+    - 'checked_out_by'
+    - 'released_to_caretaker_id'
+    - 'released_to_name'
+    - 'pickup_grant_id'
+    - 'created_at'
+    - 'warnings')
+WHERE type IN ('checkin', 'checkout') AND data ? 'security_code';
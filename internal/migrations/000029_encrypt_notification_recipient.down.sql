@@ -0,0 +1,3 @@
+-- migrations/000029_encrypt_notification_recipient.down.sql
+-- Exige que os destinatários estejam em texto puro: valores cifrados passam de 255 caracteres
+ALTER TABLE notifications ALTER COLUMN recipient TYPE VARCHAR(255);
@@ -0,0 +1,5 @@
+-- migrations/000029_encrypt_notification_recipient.up.sql
+-- O destinatário e a mensagem (que traz o código de segurança) passam a ser
+-- gravados cifrados (pii.String). Os valores existentes ficam em texto puro
+-- até o "kids-api rotate-keys".
+ALTER TABLE notifications ALTER COLUMN recipient TYPE TEXT;
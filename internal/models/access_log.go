@@ -0,0 +1,111 @@
+// internal/models/access_log.go
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Dados sensíveis cuja leitura é registrada.
+const (
+	AccessMedicalProfile  = "medical_profile"
+	AccessEmergencyRoster = "emergency_roster"
+	AccessMedication      = "medication"
+	AccessRestrictions    = "custody_restrictions"
+	AccessPickupGrants    = "pickup_grants"
+	AccessContactDetails  = "contact_details"
+	AccessIncident        = "incident"
+	AccessDataExport      = "data_subject_export"
+)
+
+// Tipos de entidade lida.
+const (
+	AccessEntityChild       = "child"
+	AccessEntityGroup       = "group"
+	AccessEntityCaretaker   = "caretaker"
+	AccessEntityHousehold   = "household"
+	AccessEntityMedication  = "medication_order"
+	AccessEntityIncident    = "incident"
+	AccessEntityDataRequest = "data_subject_request"
+)
+
+// Motivos informados pelo cliente no cabeçalho X-Access-Reason.
+const (
+	AccessReasonCare           = "care"
+	AccessReasonMedication     = "medication"
+	AccessReasonEmergency      = "emergency"
+	AccessReasonPickup         = "pickup"
+	AccessReasonIncident       = "incident"
+	AccessReasonFamilyContact  = "family_contact"
+	AccessReasonAdministration = "administration"
+	// AccessReasonUnspecified é gravado quando o cliente não informa o motivo.
+	AccessReasonUnspecified = "unspecified"
+)
+
+// AccessReasons lista os motivos aceitos.
+var AccessReasons = []string{
+	AccessReasonCare,
+	AccessReasonMedication,
+	AccessReasonEmergency,
+	AccessReasonPickup,
+	AccessReasonIncident,
+	AccessReasonFamilyContact,
+	AccessReasonAdministration,
+}
+
+// Padrões de acesso apontados no relatório.
+const (
+	AnomalyOutsideGroups = "outside_groups"
+	AnomalyHighVolume    = "high_volume"
+	AnomalyMissingReason = "missing_reason"
+)
+
+// Accessor identifies who is reading sensitive data and why.
+type Accessor struct {
+	Actor    string
+	Roles    []string
+	Reason   string
+	Endpoint string
+}
+
+// AccessLogEntry records one read of sensitive data.
+type AccessLogEntry struct {
+	ID         string         `json:"id" db:"id"`
+	Actor      string         `json:"actor" db:"actor"`
+	Roles      pq.StringArray `json:"roles" db:"roles"`
+	Resource   string         `json:"resource" db:"resource"`
+	EntityType string         `json:"entity_type" db:"entity_type"`
+	EntityID   string         `json:"entity_id" db:"entity_id"`
+	Reason     string         `json:"reason" db:"reason"`
+	Endpoint   string         `json:"endpoint,omitempty" db:"endpoint"`
+	AccessedAt time.Time      `json:"accessed_at" db:"accessed_at"`
+}
+
+// AccessLogFilter narrows the access log listing. Empty fields are ignored.
+type AccessLogFilter struct {
+	Actor      string
+	Resource   string
+	EntityType string
+	EntityID   string
+	Since      time.Time
+	Until      time.Time
+}
+
+// AccessAnomaly is an unusual access pattern of one actor in the report period.
+type AccessAnomaly struct {
+	Pattern     string         `json:"pattern" db:"pattern"`
+	Actor       string         `json:"actor" db:"actor"`
+	Accesses    int            `json:"accesses" db:"accesses"`
+	Entities    int            `json:"entities" db:"entities"`
+	Resources   pq.StringArray `json:"resources" db:"resources"`
+	FirstAccess time.Time      `json:"first_access" db:"first_access"`
+	LastAccess  time.Time      `json:"last_access" db:"last_access"`
+}
+
+// AccessReport lists the unusual access patterns found in a period.
+type AccessReport struct {
+	Since     time.Time        `json:"since"`
+	Until     time.Time        `json:"until"`
+	Anomalies []*AccessAnomaly `json:"anomalies"`
+}
@@ -0,0 +1,51 @@
+// internal/models/attendance.go
+package models
+
+import (
+	"time"
+)
+
+// Attendance records a child's check-in (and later check-out) for an event.
+type Attendance struct {
+	ID           string     `json:"id" db:"id"`
+	ChildID      string     `json:"child_id" db:"child_id"`
+	GroupID      string     `json:"group_id" db:"group_id"`
+	EventID      string     `json:"event_id" db:"event_id"`
+	LocationID   string     `json:"location_id,omitempty" db:"location_id"`
+	RoomID       string     `json:"room_id,omitempty" db:"room_id"`
+	RoomName     string     `json:"room_name,omitempty" db:"room_name"`
+	SecurityCode string     `json:"security_code" db:"security_code"`
+	CheckedInAt  time.Time  `json:"checked_in_at" db:"checked_in_at"`
+	CheckedInBy  string     `json:"checked_in_by" db:"checked_in_by"`
+	CheckedOutAt *time.Time `json:"checked_out_at,omitempty" db:"checked_out_at"`
+	CheckedOutBy string     `json:"checked_out_by,omitempty" db:"checked_out_by"`
+	// Quem efetivamente retirou a criança: um responsável cadastrado ou um nome.
+	ReleasedToCaretakerID string    `json:"released_to_caretaker_id,omitempty" db:"released_to_caretaker_id"`
+	ReleasedToName        string    `json:"released_to_name,omitempty" db:"released_to_name"`
+	PickupGrantID         string    `json:"pickup_grant_id,omitempty" db:"pickup_grant_id"`
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	// Avisos do check-in (por exemplo, termo obrigatório pendente); não impedem a entrada.
+	Warnings []string `json:"warnings,omitempty" db:"-"`
+}
+
+// CheckInRequest is the payload used to check in one or more children.
+type CheckInRequest struct {
+	ChildIDs   []string `json:"child_ids"`
+	EventID    string   `json:"event_id"`
+	LocationID string   `json:"location_id"`
+}
+
+// CheckOutRequest is the payload used to release a checked-in child.
+// Exactly one of CaretakerID or PickupGrantID identifies who is picking up.
+type CheckOutRequest struct {
+	SecurityCode  string `json:"security_code"`
+	CaretakerID   string `json:"caretaker_id"`
+	PickupGrantID string `json:"pickup_grant_id"`
+}
+
+// GroupPresence is the number of children currently checked in to a group.
+type GroupPresence struct {
+	GroupID   string `json:"group_id" db:"group_id"`
+	GroupName string `json:"group_name" db:"group_name"`
+	Count     int    `json:"count" db:"count"`
+}
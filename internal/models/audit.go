@@ -0,0 +1,18 @@
+// internal/models/audit.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records a sensitive action performed in the system.
+type AuditEntry struct {
+	ID         string          `json:"id" db:"id"`
+	Actor      string          `json:"actor" db:"actor"`
+	Action     string          `json:"action" db:"action"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityID   string          `json:"entity_id" db:"entity_id"`
+	Details    json.RawMessage `json:"details,omitempty" db:"details"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
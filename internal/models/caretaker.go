@@ -4,17 +4,21 @@ package models
 
 import (
 	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
 )
 
 // Caretaker represents a person responsible for one or more children.
 type Caretaker struct {
-	ID        string    `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Email     string    `json:"email" db:"email"`
-	Phone     string    `json:"phone" db:"phone"`
-	Address   string    `json:"address" db:"address"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        string     `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Email     pii.String `json:"email" db:"email"`
+	Phone     pii.String `json:"phone" db:"phone"`
+	Address   pii.String `json:"address" db:"address"`
+	Version   int        `json:"version" db:"version"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 // ChildCaretakerRelation represents the relationship between a child and their caretaker.
@@ -3,34 +3,43 @@ package models
 
 import (
 	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
 )
 
 type Need struct {
 	ID          string    `json:"id" db:"id"`
-	Type        string    `json:"type" db:"tipo"`
-	Description string    `json:"description" db:"descricao"`
-	CreatedAt   time.Time `json:"created_at" db:"criado_em"`
-	UpdatedAt   time.Time `json:"updated_at" db:"atualizado_em"`
+	Type        string    `json:"type" db:"type"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type Allergy struct {
-	ID          string    `json:"id" db:"id"`
-	Type        string    `json:"type" db:"tipo"`
-	Description string    `json:"description" db:"descricao"`
-	Severity    string    `json:"severity" db:"gravidade"`
-	CreatedAt   time.Time `json:"created_at" db:"criado_em"`
-	UpdatedAt   time.Time `json:"updated_at" db:"atualizado_em"`
+	ID          string     `json:"id" db:"id"`
+	Type        string     `json:"type" db:"type"`
+	Description pii.String `json:"description" db:"description"`
+	Severity    string     `json:"severity" db:"severity"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 type Child struct {
-	ID        string    `json:"id" db:"id"`
-	Name      string    `json:"name" db:"nome"`
-	BirthDate time.Time `json:"birth_date" db:"data_nascimento"`
-	Gender    string    `json:"gender" db:"sexo"`
-	PhotoURL  string    `json:"photo_url" db:"foto_url"`
-	Needs     []Need    `json:"needs"`
-	Allergies []Allergy `json:"allergies"`
-	GroupID   string    `json:"group_id" db:"grupo_id"`
-	CreatedAt time.Time `json:"created_at" db:"criado_em"`
-	UpdatedAt time.Time `json:"updated_at" db:"atualizado_em"`
+	ID        string     `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	BirthDate time.Time  `json:"birth_date" db:"birth_date"`
+	Gender    string     `json:"gender" db:"gender"`
+	PhotoURL  string     `json:"photo_url" db:"photo_url"`
+	Needs     []Need     `json:"needs"`
+	Allergies []Allergy  `json:"allergies"`
+	GroupID   string     `json:"group_id" db:"group_id"`
+	Version   int        `json:"version" db:"version"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// CustodyAlert só é preenchido para papéis autorizados a ver restrições.
+	CustodyAlert bool `json:"custody_alert,omitempty" db:"-"`
+	// Situação dos termos de autorização de imagem e de cuidado.
+	MediaRelease *ConsentStatus `json:"media_release,omitempty" db:"-"`
+	CareTerms    *ConsentStatus `json:"care_terms,omitempty" db:"-"`
 }
@@ -0,0 +1,71 @@
+// internal/models/consent.go
+package models
+
+import (
+	"time"
+)
+
+// Tipos de termo de consentimento.
+const (
+	ConsentMediaRelease = "media_release"
+	ConsentCareTerms    = "care_terms"
+)
+
+// ConsentKinds lista os tipos de termo aceitos.
+var ConsentKinds = []string{ConsentMediaRelease, ConsentCareTerms}
+
+// Situação do consentimento de uma criança para um tipo de termo.
+const (
+	ConsentGranted  = "granted"
+	ConsentDenied   = "denied"
+	ConsentOutdated = "outdated"
+	ConsentRevoked  = "revoked"
+	ConsentMissing  = "missing"
+)
+
+// ConsentDocument is one version of a consent document. Publishing a new
+// version of a kind makes the consents given to older versions outdated.
+type ConsentDocument struct {
+	ID          string    `json:"id" db:"id"`
+	Kind        string    `json:"kind" db:"kind"`
+	Version     int       `json:"version" db:"version"`
+	Title       string    `json:"title" db:"title"`
+	Body        string    `json:"body" db:"body"`
+	Required    bool      `json:"required" db:"required"`
+	PublishedBy string    `json:"published_by" db:"published_by"`
+	PublishedAt time.Time `json:"published_at" db:"published_at"`
+}
+
+// ConsentRecord is a caretaker's answer to a document version for a child.
+type ConsentRecord struct {
+	ID               string     `json:"id" db:"id"`
+	DocumentID       string     `json:"document_id" db:"document_id"`
+	Kind             string     `json:"kind" db:"kind"`
+	DocumentVersion  int        `json:"document_version" db:"document_version"`
+	ChildID          string     `json:"child_id" db:"child_id"`
+	CaretakerID      string     `json:"caretaker_id" db:"caretaker_id"`
+	Granted          bool       `json:"granted" db:"granted"`
+	AcceptedAt       time.Time  `json:"accepted_at" db:"accepted_at"`
+	IPAddress        string     `json:"ip_address,omitempty" db:"ip_address"`
+	Device           string     `json:"device,omitempty" db:"device"`
+	RecordedBy       string     `json:"recorded_by" db:"recorded_by"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy        string     `json:"revoked_by,omitempty" db:"revoked_by"`
+	RevocationReason string     `json:"revocation_reason,omitempty" db:"revocation_reason"`
+}
+
+// ConsentStatus summarizes a child's consent for one kind of document: the
+// most recent answer still in force, compared with the current version.
+type ConsentStatus struct {
+	Status          string     `json:"status"`
+	Required        bool       `json:"required"`
+	CurrentVersion  int        `json:"current_version"`
+	DocumentVersion int        `json:"document_version,omitempty"`
+	CaretakerID     string     `json:"caretaker_id,omitempty"`
+	AcceptedAt      *time.Time `json:"accepted_at,omitempty"`
+}
+
+// Valid reports whether the consent was given for the current version.
+func (s *ConsentStatus) Valid() bool {
+	return s.Status == ConsentGranted
+}
@@ -0,0 +1,77 @@
+// internal/models/data_subject_request.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tipos de pedido do titular.
+const (
+	DataRequestExport  = "export"
+	DataRequestErasure = "erasure"
+)
+
+// Situações de um pedido do titular.
+const (
+	DataRequestPending    = "pending"
+	DataRequestProcessing = "processing"
+	DataRequestCompleted  = "completed"
+	DataRequestFailed     = "failed"
+)
+
+// DataSubjectRequest tracks a caretaker's request (LGPD) to export or erase
+// the personal data held about them and their children.
+type DataSubjectRequest struct {
+	ID          string `json:"id" db:"id"`
+	CaretakerID string `json:"caretaker_id" db:"caretaker_id"`
+	Type        string `json:"type" db:"type"`
+	Status      string `json:"status" db:"status"`
+	Reason      string `json:"reason,omitempty" db:"reason"`
+	RequestedBy string `json:"requested_by" db:"requested_by"`
+	// Confirm precisa ser enviado em pedidos de anonimização, que são irreversíveis.
+	Confirm     bool            `json:"confirm,omitempty" db:"-"`
+	Result      json.RawMessage `json:"result,omitempty" db:"result"`
+	HasArchive  bool            `json:"has_archive" db:"has_archive"`
+	LastError   string          `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// DataSubjectExport is the data.json file of an export archive.
+type DataSubjectExport struct {
+	GeneratedAt   time.Time          `json:"generated_at"`
+	Caretaker     *Caretaker         `json:"caretaker"`
+	Household     *Household         `json:"household,omitempty"`
+	Children      []DataSubjectChild `json:"children"`
+	Notifications []*Notification    `json:"notifications"`
+}
+
+// DataSubjectChild groups everything held about one of the caretaker's children.
+type DataSubjectChild struct {
+	Child                     *Child                      `json:"child"`
+	Photo                     string                      `json:"photo,omitempty"`
+	PhotoError                string                      `json:"photo_error,omitempty"`
+	MedicalProfile            *MedicalProfile             `json:"medical_profile,omitempty"`
+	Attendance                []*Attendance               `json:"attendance"`
+	PickupGrants              []*PickupGrant              `json:"pickup_grants"`
+	MedicationOrders          []*MedicationOrder          `json:"medication_orders"`
+	MedicationAdministrations []*MedicationAdministration `json:"medication_administrations"`
+	Incidents                 []*Incident                 `json:"incidents"`
+	Consents                  []*ConsentRecord            `json:"consents"`
+}
+
+// LinkedChild is a child linked to the caretaker, with the number of other
+// active caretakers also linked to it.
+type LinkedChild struct {
+	ChildID         string `db:"child_id"`
+	OtherCaretakers int    `db:"other_caretakers"`
+}
+
+// DataSubjectErasure summarizes an erasure: children linked to other
+// caretakers are kept, since the data also belongs to them.
+type DataSubjectErasure struct {
+	ErasedChildren   []string `json:"erased_children"`
+	RetainedChildren []string `json:"retained_children"`
+}
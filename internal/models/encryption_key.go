@@ -0,0 +1,16 @@
+// internal/models/encryption_key.go
+package models
+
+import (
+	"time"
+)
+
+// EncryptionKey is a data key used to encrypt personal data, stored wrapped
+// (encrypted) by the master key identified by MasterKeyID.
+type EncryptionKey struct {
+	ID          int       `json:"id" db:"id"`
+	WrappedKey  []byte    `json:"-" db:"wrapped_key"`
+	MasterKeyID string    `json:"master_key_id" db:"master_key_id"`
+	Active      bool      `json:"active" db:"active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
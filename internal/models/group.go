@@ -5,12 +5,16 @@ import (
 	"time"
 )
 
+// Group is an age cohort. The physical space comes from the rooms assigned to
+// the group per event; Capacity only applies when the group has no room.
 type Group struct {
-	ID          string    `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	AgeRange    string    `json:"age_range" db:"age_range"`
-	Capacity    int       `json:"capacity" db:"capacity"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          string     `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description" db:"description"`
+	AgeRange    string     `json:"age_range" db:"age_range"`
+	Capacity    int        `json:"capacity" db:"capacity"`
+	Version     int        `json:"version" db:"version"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
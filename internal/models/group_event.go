@@ -0,0 +1,56 @@
+// internal/models/group_event.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tipos de evento enviados ao painel em tempo real da sala.
+const (
+	GroupEventCheckIn         = "checkin"
+	GroupEventCheckOut        = "checkout"
+	GroupEventCapacityReached = "capacity_warning"
+	GroupEventParentPaged     = "parent_paged"
+	GroupEventRollCallStarted = "roll_call_started"
+	GroupEventRollCallClosed  = "roll_call_closed"
+)
+
+// GroupEvent is a room dashboard event. ID is monotonic and doubles as the SSE event ID.
+type GroupEvent struct {
+	ID        int64           `json:"id" db:"id"`
+	GroupID   string          `json:"group_id" db:"group_id"`
+	Type      string          `json:"type" db:"type"`
+	Data      json.RawMessage `json:"data" db:"data"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AttendanceEvent is the payload of the check-in and check-out events. The
+// stream is read by every staff role and kept for replay, so it leaves out
+// the security code and who picked the child up.
+type AttendanceEvent struct {
+	AttendanceID string     `json:"attendance_id"`
+	ChildID      string     `json:"child_id"`
+	GroupID      string     `json:"group_id"`
+	EventID      string     `json:"event_id"`
+	LocationID   string     `json:"location_id,omitempty"`
+	RoomID       string     `json:"room_id,omitempty"`
+	RoomName     string     `json:"room_name,omitempty"`
+	CheckedInAt  time.Time  `json:"checked_in_at"`
+	CheckedOutAt *time.Time `json:"checked_out_at,omitempty"`
+}
+
+// NewAttendanceEvent copies the fields of record that the room dashboard needs.
+func NewAttendanceEvent(record *Attendance) AttendanceEvent {
+	return AttendanceEvent{
+		AttendanceID: record.ID,
+		ChildID:      record.ChildID,
+		GroupID:      record.GroupID,
+		EventID:      record.EventID,
+		LocationID:   record.LocationID,
+		RoomID:       record.RoomID,
+		RoomName:     record.RoomName,
+		CheckedInAt:  record.CheckedInAt,
+		CheckedOutAt: record.CheckedOutAt,
+	}
+}
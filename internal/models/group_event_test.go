@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAttendanceEventLeavesOutPickupSecrets(t *testing.T) {
+	out := time.Now()
+	record := &Attendance{
+		ID:                    "a1",
+		ChildID:               "c1",
+		GroupID:               "g1",
+		RoomID:                "r1",
+		SecurityCode:          "X7K2",
+		CheckedInAt:           out.Add(-time.Hour),
+		CheckedInBy:           "auth0|vol",
+		CheckedOutAt:          &out,
+		ReleasedToCaretakerID: "cg1",
+		ReleasedToName:        "Maria Souza",
+		PickupGrantID:         "pg1",
+	}
+
+	data, err := json.Marshal(NewAttendanceEvent(record))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, secret := range []string{"X7K2", "Maria Souza", "cg1", "pg1", "auth0|vol", "security_code", "released_to"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("event %s contains %q", data, secret)
+		}
+	}
+	for _, want := range []string{`"attendance_id":"a1"`, `"child_id":"c1"`, `"room_id":"r1"`, `"checked_out_at"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("event %s does not contain %s", data, want)
+		}
+	}
+}
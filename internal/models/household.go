@@ -0,0 +1,24 @@
+// internal/models/household.go
+package models
+
+import (
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+)
+
+// Household groups the caretakers and children who live together.
+type Household struct {
+	ID               string                   `json:"id" db:"id"`
+	Name             string                   `json:"name" db:"name"`
+	Address          pii.String               `json:"address" db:"address"`
+	PrimaryContactID string                   `json:"primary_contact_id" db:"primary_contact_id"`
+	Notes            string                   `json:"notes" db:"notes"`
+	Caretakers       []Caretaker              `json:"caretakers"`
+	Children         []Child                  `json:"children"`
+	Relations        []ChildCaretakerRelation `json:"relations"`
+	Version          int                      `json:"version" db:"version"`
+	CreatedAt        time.Time                `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time               `json:"deleted_at,omitempty" db:"deleted_at"`
+}
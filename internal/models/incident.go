@@ -0,0 +1,61 @@
+// internal/models/incident.go
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Etapas do fluxo de um relatório de incidente.
+const (
+	IncidentDraft     = "draft"
+	IncidentSubmitted = "submitted"
+	IncidentReviewed  = "reviewed"
+	IncidentClosed    = "closed"
+)
+
+// Tipos de incidente.
+const (
+	IncidentInjury   = "injury"
+	IncidentIllness  = "illness"
+	IncidentBehavior = "behavior"
+	IncidentOther    = "other"
+)
+
+// Incident is an injury or incident report involving a child.
+type Incident struct {
+	ID           string         `json:"id" db:"id"`
+	ChildID      string         `json:"child_id" db:"child_id"`
+	GroupID      string         `json:"group_id,omitempty" db:"group_id"`
+	EventID      string         `json:"event_id,omitempty" db:"event_id"`
+	VolunteerIDs []string       `json:"volunteer_ids" db:"-"`
+	Type         string         `json:"type" db:"type"`
+	Description  string         `json:"description" db:"description"`
+	FirstAid     string         `json:"first_aid,omitempty" db:"first_aid"`
+	Witnesses    pq.StringArray `json:"witnesses" db:"witnesses"`
+	OccurredAt   time.Time      `json:"occurred_at" db:"occurred_at"`
+	Status       string         `json:"status" db:"status"`
+	ReportedBy   string         `json:"reported_by,omitempty" db:"reported_by"`
+	SubmittedAt  *time.Time     `json:"submitted_at,omitempty" db:"submitted_at"`
+	ReviewedBy   string         `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt   *time.Time     `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNotes  string         `json:"review_notes,omitempty" db:"review_notes"`
+	ClosedAt     *time.Time     `json:"closed_at,omitempty" db:"closed_at"`
+	// Ciência do responsável sobre o ocorrido.
+	AcknowledgedByCaretakerID string     `json:"acknowledged_by_caretaker_id,omitempty" db:"acknowledged_by_caretaker_id"`
+	AcknowledgedAt            *time.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	Version                   int        `json:"version" db:"version"`
+	CreatedAt                 time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt                 time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IncidentReview is the reviewer sign-off payload.
+type IncidentReview struct {
+	Notes string `json:"notes"`
+}
+
+// IncidentAcknowledgement identifies the caretaker acknowledging a report.
+type IncidentAcknowledgement struct {
+	CaretakerID string `json:"caretaker_id"`
+}
@@ -0,0 +1,39 @@
+// internal/models/job.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Situações de um trabalho na fila.
+const (
+	JobQueued  = "queued"
+	JobRunning = "running"
+	JobDone    = "done"
+	// JobDead indica que o trabalho esgotou as tentativas (dead letter).
+	JobDead = "dead"
+)
+
+// Job is a unit of background work persisted in the jobs table.
+type Job struct {
+	ID          string          `json:"id" db:"id"`
+	TenantID    string          `json:"tenant_id,omitempty" db:"tenant_id"`
+	Type        string          `json:"type" db:"type"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      string          `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	RunAt       time.Time       `json:"run_at" db:"run_at"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty" db:"locked_at"`
+	LockedBy    string          `json:"locked_by,omitempty" db:"locked_by"`
+	LastError   string          `json:"last_error,omitempty" db:"last_error"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// IsLastAttempt reports whether a failure of the current attempt dead-letters the job.
+func (j *Job) IsLastAttempt() bool {
+	return j.Attempts >= j.MaxAttempts
+}
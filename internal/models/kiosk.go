@@ -0,0 +1,53 @@
+// internal/models/kiosk.go
+package models
+
+import (
+	"time"
+)
+
+// KioskDevice is a self check-in device paired by an administrator. It
+// authenticates with its own long-lived credential instead of a user login.
+type KioskDevice struct {
+	ID         string     `json:"id" db:"id"`
+	TenantID   string     `json:"tenant_id" db:"tenant_id"`
+	Name       string     `json:"name" db:"name"`
+	LocationID string     `json:"location_id" db:"location_id"`
+	PairedAt   *time.Time `json:"paired_at,omitempty" db:"paired_at"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty" db:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// KioskRegistration is returned once to the administrator when a kiosk is
+// registered; the pairing code is typed on the device to pair it.
+type KioskRegistration struct {
+	Device           *KioskDevice `json:"device"`
+	PairingCode      string       `json:"pairing_code"`
+	PairingExpiresAt time.Time    `json:"pairing_expires_at"`
+}
+
+// KioskCredential is returned once to the device after pairing.
+type KioskCredential struct {
+	Device *KioskDevice `json:"device"`
+	Token  string       `json:"token"`
+}
+
+// KioskPairRequest carries the one-time pairing code typed on the device.
+type KioskPairRequest struct {
+	PairingCode string `json:"pairing_code"`
+}
+
+// KioskFamily is the minimal household view shown on a kiosk after a phone search.
+type KioskFamily struct {
+	HouseholdID string       `json:"household_id"`
+	Name        string       `json:"name"`
+	Children    []KioskChild `json:"children"`
+}
+
+// KioskChild is the minimal child view shown on a kiosk.
+type KioskChild struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
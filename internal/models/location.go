@@ -0,0 +1,71 @@
+// internal/models/location.go
+package models
+
+import (
+	"time"
+)
+
+// Location is a campus building where events happen.
+type Location struct {
+	ID        string     `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Address   string     `json:"address,omitempty" db:"address"`
+	Notes     string     `json:"notes,omitempty" db:"notes"`
+	Version   int        `json:"version" db:"version"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// Room is a physical room in a location. Capacity and ChildrenPerVolunteer
+// are limits for the room; zero means no limit.
+type Room struct {
+	ID                   string     `json:"id" db:"id"`
+	LocationID           string     `json:"location_id" db:"location_id"`
+	Name                 string     `json:"name" db:"name"`
+	Floor                string     `json:"floor,omitempty" db:"floor"`
+	Capacity             int        `json:"capacity" db:"capacity"`
+	ChildrenPerVolunteer int        `json:"children_per_volunteer" db:"children_per_volunteer"`
+	WheelchairAccessible bool       `json:"wheelchair_accessible" db:"wheelchair_accessible"`
+	AccessibilityNotes   string     `json:"accessibility_notes,omitempty" db:"accessibility_notes"`
+	Version              int        `json:"version" db:"version"`
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt            *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// RoomAssignment places a group in a room for an event. A group assigned to
+// several rooms fills them in Position order.
+type RoomAssignment struct {
+	EventID  string `json:"event_id" db:"event_id"`
+	GroupID  string `json:"group_id" db:"group_id"`
+	RoomID   string `json:"room_id" db:"room_id"`
+	RoomName string `json:"room_name" db:"room_name"`
+	Position int    `json:"position" db:"position"`
+}
+
+// RoomOccupancy is the live state of a room during an event. Limit and
+// Available are null when the room has no limit.
+type RoomOccupancy struct {
+	Room      *Room `json:"room"`
+	Present   int   `json:"present"`
+	Staff     int   `json:"staff"`
+	Limit     *int  `json:"limit"`
+	Available *int  `json:"available"`
+}
+
+// Limit returns how many children the room takes with the given staff: the
+// lower of the capacity and the volunteer ratio. ok is false when the room has
+// neither limit; with a ratio and no staff, the room takes no children.
+func (r *Room) Limit(staff int) (limit int, ok bool) {
+	if r.Capacity > 0 {
+		limit, ok = r.Capacity, true
+	}
+	if r.ChildrenPerVolunteer > 0 {
+		ratio := staff * r.ChildrenPerVolunteer
+		if !ok || ratio < limit {
+			limit, ok = ratio, true
+		}
+	}
+	return limit, ok
+}
@@ -0,0 +1,54 @@
+// internal/models/medical_profile.go
+package models
+
+import (
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+	"github.com/lib/pq"
+)
+
+// Tipos sanguíneos aceitos.
+var BloodTypes = []string{"A+", "A-", "B+", "B-", "AB+", "AB-", "O+", "O-"}
+
+// MedicalProfile holds what volunteers need to know about a child in an emergency.
+type MedicalProfile struct {
+	ChildID               string             `json:"child_id" db:"child_id"`
+	BloodType             string             `json:"blood_type,omitempty" db:"blood_type"`
+	DoctorName            string             `json:"doctor_name,omitempty" db:"doctor_name"`
+	DoctorPhone           pii.String         `json:"doctor_phone,omitempty" db:"doctor_phone"`
+	InsuranceProvider     string             `json:"insurance_provider,omitempty" db:"insurance_provider"`
+	InsurancePolicyNumber pii.String         `json:"insurance_policy_number,omitempty" db:"insurance_policy_number"`
+	Conditions            pii.Strings        `json:"conditions" db:"conditions"`
+	DietaryRestrictions   pq.StringArray     `json:"dietary_restrictions" db:"dietary_restrictions"`
+	Notes                 pii.String         `json:"notes,omitempty" db:"notes"`
+	EmergencyContacts     []EmergencyContact `json:"emergency_contacts" db:"-"`
+	Version               int                `json:"version" db:"version"`
+	UpdatedBy             string             `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt             *time.Time         `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// EmergencyContact is a person to call in an emergency, in priority order.
+// When CaretakerID is set, missing name and phone come from the caretaker.
+type EmergencyContact struct {
+	ID           string     `json:"id" db:"id"`
+	Position     int        `json:"position" db:"position"`
+	CaretakerID  string     `json:"caretaker_id,omitempty" db:"caretaker_id"`
+	Name         string     `json:"name" db:"name"`
+	Relationship string     `json:"relationship,omitempty" db:"relationship"`
+	Phone        pii.String `json:"phone" db:"phone"`
+	AltPhone     pii.String `json:"alt_phone,omitempty" db:"alt_phone"`
+}
+
+// RosterEntry is one line of the emergency roster: a child present in a room
+// with everything needed to act in an emergency.
+type RosterEntry struct {
+	ChildID      string          `json:"child_id"`
+	ChildName    string          `json:"child_name"`
+	BirthDate    time.Time       `json:"birth_date"`
+	AttendanceID string          `json:"attendance_id"`
+	SecurityCode string          `json:"security_code"`
+	CheckedInAt  time.Time       `json:"checked_in_at"`
+	Allergies    []Allergy       `json:"allergies"`
+	Medical      *MedicalProfile `json:"medical_profile"`
+}
@@ -0,0 +1,49 @@
+// internal/models/medication.go
+package models
+
+import (
+	"time"
+)
+
+// MedicationOrder is a medication a child may receive while under the
+// ministry's care, backed by a signed parental consent that expires.
+type MedicationOrder struct {
+	ID                 string     `json:"id" db:"id"`
+	ChildID            string     `json:"child_id" db:"child_id"`
+	Drug               string     `json:"drug" db:"drug"`
+	Dose               string     `json:"dose" db:"dose"`
+	Route              string     `json:"route,omitempty" db:"route"`
+	Schedule           string     `json:"schedule" db:"schedule"`
+	AsNeeded           bool       `json:"as_needed" db:"as_needed"`
+	Instructions       string     `json:"instructions,omitempty" db:"instructions"`
+	ConsentDocumentURL string     `json:"consent_document_url" db:"consent_document_url"`
+	ConsentCaretakerID string     `json:"consent_caretaker_id,omitempty" db:"consent_caretaker_id"`
+	ConsentExpiresAt   time.Time  `json:"consent_expires_at" db:"consent_expires_at"`
+	DiscontinuedAt     *time.Time `json:"discontinued_at,omitempty" db:"discontinued_at"`
+	CreatedBy          string     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CanAdminister reports whether a dose may be given at the given moment.
+func (o *MedicationOrder) CanAdminister(at time.Time) bool {
+	return o.DiscontinuedAt == nil && o.ConsentExpiresAt.After(at)
+}
+
+// MedicationAdministration records a dose given, verified by a second volunteer.
+// The dose is recorded by one of the two volunteers and only counts as
+// verified once the other one confirms it under their own identity.
+type MedicationAdministration struct {
+	ID                        string     `json:"id" db:"id"`
+	OrderID                   string     `json:"order_id" db:"order_id"`
+	ChildID                   string     `json:"child_id" db:"child_id"`
+	DoseGiven                 string     `json:"dose_given" db:"dose_given"`
+	AdministeredAt            time.Time  `json:"administered_at" db:"administered_at"`
+	AdministeredByVolunteerID string     `json:"administered_by_volunteer_id" db:"administered_by_volunteer_id"`
+	VerifiedByVolunteerID     string     `json:"verified_by_volunteer_id" db:"verified_by_volunteer_id"`
+	Notes                     string     `json:"notes,omitempty" db:"notes"`
+	RecordedBy                string     `json:"recorded_by,omitempty" db:"recorded_by"`
+	VerifiedAt                *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	VerifiedBy                string     `json:"verified_by,omitempty" db:"verified_by"`
+	CreatedAt                 time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,48 @@
+// internal/models/notification.go
+package models
+
+import (
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+)
+
+// Situações de entrega de uma notificação.
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// Notification records a message sent to a caretaker and its delivery status.
+// Recipient and Body (which carries the security code) are stored encrypted.
+type Notification struct {
+	ID           string     `json:"id" db:"id"`
+	AttendanceID string     `json:"attendance_id,omitempty" db:"attendance_id"`
+	ChildID      string     `json:"child_id" db:"child_id"`
+	CaretakerID  string     `json:"caretaker_id,omitempty" db:"caretaker_id"`
+	Channel      string     `json:"channel" db:"channel"`
+	Recipient    pii.String `json:"recipient" db:"recipient"`
+	Reason       string     `json:"reason" db:"reason"`
+	Subject      string     `json:"subject,omitempty" db:"subject"`
+	Body         pii.String `json:"body" db:"body"`
+	Status       string     `json:"status" db:"status"`
+	Attempts     int        `json:"attempts" db:"attempts"`
+	LastError    string     `json:"last_error,omitempty" db:"last_error"`
+	SentBy       string     `json:"sent_by,omitempty" db:"sent_by"`
+	SentAt       *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PageParentRequest is the payload used by volunteers to call a child's caretaker.
+type PageParentRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note,omitempty"`
+}
+
+// ChannelBacklog is the number of notifications awaiting delivery on a channel.
+type ChannelBacklog struct {
+	Channel string `json:"channel" db:"channel"`
+	Count   int    `json:"count" db:"count"`
+}
@@ -0,0 +1,40 @@
+// internal/models/pickup_grant.go
+package models
+
+import (
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+)
+
+// PickupGrant is a temporary authorization, created by a caretaker, allowing a
+// named person to pick up a child within a date window or for a single event.
+type PickupGrant struct {
+	ID                   string     `json:"id" db:"id"`
+	ChildID              string     `json:"child_id" db:"child_id"`
+	GrantedByCaretakerID string     `json:"granted_by_caretaker_id" db:"granted_by_caretaker_id"`
+	Name                 string     `json:"name" db:"name"`
+	Phone                pii.String `json:"phone,omitempty" db:"phone"`
+	PhotoURL             string     `json:"photo_url,omitempty" db:"photo_url"`
+	ValidFrom            *time.Time `json:"valid_from,omitempty" db:"valid_from"`
+	ValidUntil           *time.Time `json:"valid_until,omitempty" db:"valid_until"`
+	EventID              string     `json:"event_id,omitempty" db:"event_id"`
+	RevokedAt            *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsValidFor reports whether the grant can be used at the given moment for the
+// given event.
+func (g *PickupGrant) IsValidFor(at time.Time, eventID string) bool {
+	if g.RevokedAt != nil {
+		return false
+	}
+
+	if g.EventID != "" {
+		return g.EventID == eventID
+	}
+
+	return g.ValidFrom != nil && g.ValidUntil != nil &&
+		!at.Before(*g.ValidFrom) && at.Before(*g.ValidUntil)
+}
@@ -0,0 +1,22 @@
+// internal/models/precheckin.go
+package models
+
+import (
+	"time"
+)
+
+// PreCheckIn is returned to the caretaker after an online pre-check-in; the
+// token is rendered as a QR code and presented at the kiosk.
+type PreCheckIn struct {
+	Token     string    `json:"token"`
+	ChildIDs  []string  `json:"child_ids"`
+	EventID   string    `json:"event_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// KioskCheckInRequest carries the scanned QR token. LocationID is ignored for
+// kiosk devices, which always check in at their assigned location.
+type KioskCheckInRequest struct {
+	Token      string `json:"token"`
+	LocationID string `json:"location_id,omitempty"`
+}
@@ -0,0 +1,32 @@
+// internal/models/restriction.go
+package models
+
+import (
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+)
+
+// ChildRestriction bars a person (a registered caretaker or anyone identified
+// by name) from picking up a child, usually because of a court order.
+type ChildRestriction struct {
+	ID                    string     `json:"id" db:"id"`
+	ChildID               string     `json:"child_id" db:"child_id"`
+	RestrictedCaretakerID string     `json:"restricted_caretaker_id,omitempty" db:"restricted_caretaker_id"`
+	RestrictedName        string     `json:"restricted_name,omitempty" db:"restricted_name"`
+	DocumentReference     pii.String `json:"document_reference,omitempty" db:"document_reference"`
+	Notes                 pii.String `json:"notes,omitempty" db:"notes"`
+	ExpiresAt             *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt             *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedBy             string     `json:"created_by" db:"created_by"`
+	CreatedAt             time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsActive reports whether the restriction is in force at the given moment.
+func (r *ChildRestriction) IsActive(at time.Time) bool {
+	if r.RevokedAt != nil {
+		return false
+	}
+	return r.ExpiresAt == nil || r.ExpiresAt.After(at)
+}
@@ -0,0 +1,79 @@
+// internal/models/retention.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Regras de retenção suportadas.
+const (
+	// RetentionInactiveChildAnonymize anonimiza crianças sem presença há after_days.
+	RetentionInactiveChildAnonymize = "inactive_child_anonymize"
+	// RetentionIncidentDraftPurge exclui rascunhos de ocorrência não enviados há after_days.
+	RetentionIncidentDraftPurge = "incident_draft_purge"
+	// RetentionInactiveChildPhoto remove a referência à foto de crianças sem
+	// presença há after_days. O arquivo não é hospedado pela API e continua no
+	// serviço de hospedagem, onde precisa ser apagado.
+	RetentionInactiveChildPhoto = "inactive_child_photo"
+	// RetentionAccessLogPurge exclui registros de leitura de dados sensíveis com mais de after_days.
+	RetentionAccessLogPurge = "access_log_purge"
+)
+
+// RetentionRuleDefaults lista as regras suportadas com o prazo sugerido (em
+// dias), usado enquanto o campus não configurar a regra.
+var RetentionRuleDefaults = []RetentionPolicy{
+	{Rule: RetentionInactiveChildAnonymize, AfterDays: 3 * 365},
+	{Rule: RetentionIncidentDraftPurge, AfterDays: 90},
+	{Rule: RetentionInactiveChildPhoto, AfterDays: 365},
+	{Rule: RetentionAccessLogPurge, AfterDays: 2 * 365},
+}
+
+// Situações de uma execução das regras de retenção.
+const (
+	RetentionRunPending    = "pending"
+	RetentionRunProcessing = "processing"
+	RetentionRunCompleted  = "completed"
+	RetentionRunFailed     = "failed"
+)
+
+// RetentionPolicy configures one retention rule for a campus. Rules are
+// disabled until enabled explicitly.
+type RetentionPolicy struct {
+	ID        string     `json:"id,omitempty" db:"id"`
+	Rule      string     `json:"rule" db:"rule"`
+	AfterDays int        `json:"after_days" db:"after_days"`
+	Enabled   bool       `json:"enabled" db:"enabled"`
+	UpdatedBy string     `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// RetentionRun is one execution of the enabled retention rules, either a dry
+// run that only reports what would be affected or an actual run.
+type RetentionRun struct {
+	ID          string          `json:"id" db:"id"`
+	DryRun      bool            `json:"dry_run" db:"dry_run"`
+	Status      string          `json:"status" db:"status"`
+	RequestedBy string          `json:"requested_by" db:"requested_by"`
+	Result      json.RawMessage `json:"result,omitempty" db:"result"`
+	LastError   string          `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// RetentionRuleResult reports what one rule affected (or would affect) in a run.
+type RetentionRuleResult struct {
+	Rule      string    `json:"rule"`
+	AfterDays int       `json:"after_days"`
+	Cutoff    time.Time `json:"cutoff"`
+	Affected  int       `json:"affected"`
+	EntityIDs []string  `json:"entity_ids,omitempty"`
+	// Note traz o que a regra deixa de fora, como os arquivos das fotos.
+	Note string `json:"note,omitempty"`
+}
+
+// RetentionResult is the report stored in RetentionRun.Result.
+type RetentionResult struct {
+	Rules []RetentionRuleResult `json:"rules"`
+}
@@ -0,0 +1,65 @@
+// internal/models/roll_call.go
+package models
+
+import (
+	"time"
+)
+
+// Situações da chamada de emergência.
+const (
+	RollCallOpen   = "open"
+	RollCallClosed = "closed"
+)
+
+// RollCall is an evacuation roll call for a location, started by an admin
+// from the children checked in at that moment.
+type RollCall struct {
+	ID         string     `json:"id" db:"id"`
+	LocationID string     `json:"location_id" db:"location_id"`
+	Status     string     `json:"status" db:"status"`
+	StartedBy  string     `json:"started_by" db:"started_by"`
+	StartedAt  time.Time  `json:"started_at" db:"started_at"`
+	ClosedBy   string     `json:"closed_by,omitempty" db:"closed_by"`
+	ClosedAt   *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+}
+
+// RollCallEntry is one checked-in child in the roll call snapshot.
+type RollCallEntry struct {
+	RollCallID   string     `json:"roll_call_id" db:"roll_call_id"`
+	AttendanceID string     `json:"attendance_id" db:"attendance_id"`
+	ChildID      string     `json:"child_id" db:"child_id"`
+	ChildName    string     `json:"child_name" db:"child_name"`
+	GroupID      string     `json:"group_id" db:"group_id"`
+	GroupName    string     `json:"group_name" db:"group_name"`
+	AccountedBy  string     `json:"accounted_by,omitempty" db:"accounted_by"`
+	AccountedAt  *time.Time `json:"accounted_at,omitempty" db:"accounted_at"`
+}
+
+// RollCallGroupSummary counts the children of one room in the roll call.
+type RollCallGroupSummary struct {
+	GroupID     string `json:"group_id"`
+	GroupName   string `json:"group_name"`
+	Total       int    `json:"total"`
+	Accounted   int    `json:"accounted"`
+	Unaccounted int    `json:"unaccounted"`
+}
+
+// UnaccountedChild is a child not yet found, with the flags volunteers need
+// while searching. The medical fields are only filled for roles with access
+// to the medical profile.
+type UnaccountedChild struct {
+	RollCallEntry
+	Allergies           []Allergy `json:"allergies,omitempty"`
+	BloodType           string    `json:"blood_type,omitempty"`
+	Conditions          []string  `json:"conditions,omitempty"`
+	DietaryRestrictions []string  `json:"dietary_restrictions,omitempty"`
+}
+
+// RollCallSummary is the live view of a roll call.
+type RollCallSummary struct {
+	RollCall
+	Total       int                    `json:"total"`
+	Accounted   int                    `json:"accounted"`
+	Groups      []RollCallGroupSummary `json:"groups"`
+	Unaccounted []UnaccountedChild     `json:"unaccounted"`
+}
@@ -0,0 +1,20 @@
+// internal/models/search.go
+package models
+
+import "github.com/eduardohass/kids-api/internal/pii"
+
+// Tipos de entidade retornados pela busca.
+const (
+	SearchTypeChild     = "child"
+	SearchTypeCaretaker = "caretaker"
+	SearchTypeVolunteer = "volunteer"
+)
+
+// SearchResult representa um resultado ranqueado da busca entre famílias.
+type SearchResult struct {
+	Type   string     `json:"type" db:"tipo"`
+	ID     string     `json:"id" db:"id"`
+	Name   string     `json:"name" db:"nome"`
+	Detail pii.String `json:"detail,omitempty" db:"detalhe"`
+	Score  float64    `json:"score" db:"score"`
+}
@@ -0,0 +1,43 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// LogNotifier não entrega nada: apenas registra as mensagens no log e as
+// guarda em memória. Usado em desenvolvimento, em testes e quando um canal
+// não está configurado.
+type LogNotifier struct {
+	channel string
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+// NewLogNotifier creates a LogNotifier for the given channel.
+func NewLogNotifier(channel string) *LogNotifier {
+	return &LogNotifier{channel: channel}
+}
+
+func (n *LogNotifier) Channel() string {
+	return n.channel
+}
+
+func (n *LogNotifier) Send(ctx context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.sent = append(n.sent, msg)
+	log.Printf("notification (%s) to %s: %s", n.channel, msg.To, msg.Subject)
+	return nil
+}
+
+// Sent retorna as mensagens registradas até agora.
+func (n *LogNotifier) Sent() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]Message(nil), n.sent...)
+}
@@ -0,0 +1,59 @@
+// Package notifications delivers messages to caretakers through pluggable channels.
+package notifications
+
+import (
+	"context"
+	"errors"
+)
+
+// Canais de entrega suportados.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+// ErrNoNotifier is returned when no notifier is registered for a channel.
+var ErrNoNotifier = errors.New("no notifier configured for channel")
+
+// Message is a rendered notification ready to be delivered.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier entrega mensagens por um canal específico (e-mail, SMS...).
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Registry agrupa os notifiers disponíveis por canal.
+type Registry struct {
+	notifiers map[string]Notifier
+}
+
+// NewRegistry creates a Registry with the given notifiers; later notifiers
+// replace earlier ones for the same channel.
+func NewRegistry(notifiers ...Notifier) *Registry {
+	reg := &Registry{notifiers: make(map[string]Notifier, len(notifiers))}
+	for _, n := range notifiers {
+		reg.notifiers[n.Channel()] = n
+	}
+	return reg
+}
+
+// Has informa se existe um notifier para o canal.
+func (r *Registry) Has(channel string) bool {
+	_, ok := r.notifiers[channel]
+	return ok
+}
+
+// Send entrega a mensagem pelo canal informado.
+func (r *Registry) Send(ctx context.Context, channel string, msg Message) error {
+	n, ok := r.notifiers[channel]
+	if !ok {
+		return ErrNoNotifier
+	}
+	return n.Send(ctx, msg)
+}
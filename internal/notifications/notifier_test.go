@@ -0,0 +1,88 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegistrySendUsesChannelNotifier(t *testing.T) {
+	email := NewLogNotifier(ChannelEmail)
+	sms := NewLogNotifier(ChannelSMS)
+	reg := NewRegistry(email, sms)
+
+	msg := Message{To: "ana@example.com", Subject: "Oi", Body: "Corpo"}
+	if err := reg.Send(context.Background(), ChannelEmail, msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got := email.Sent(); len(got) != 1 || got[0] != msg {
+		t.Fatalf("email notifier got %+v, want [%+v]", got, msg)
+	}
+	if got := sms.Sent(); len(got) != 0 {
+		t.Fatalf("sms notifier got %+v, want nothing", got)
+	}
+}
+
+func TestRegistryUnknownChannel(t *testing.T) {
+	reg := NewRegistry(NewLogNotifier(ChannelEmail))
+
+	if reg.Has(ChannelSMS) {
+		t.Fatal("Has(sms) = true without an sms notifier")
+	}
+	err := reg.Send(context.Background(), ChannelSMS, Message{To: "+5511999999999"})
+	if !errors.Is(err, ErrNoNotifier) {
+		t.Fatalf("Send error = %v, want ErrNoNotifier", err)
+	}
+}
+
+func TestRegistryLaterNotifierReplacesEarlier(t *testing.T) {
+	first := NewLogNotifier(ChannelEmail)
+	second := NewLogNotifier(ChannelEmail)
+	reg := NewRegistry(first, second)
+
+	if err := reg.Send(context.Background(), ChannelEmail, Message{To: "ana@example.com"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(first.Sent()) != 0 || len(second.Sent()) != 1 {
+		t.Fatalf("first got %d messages, second got %d; want 0 and 1", len(first.Sent()), len(second.Sent()))
+	}
+}
+
+func TestRenderPageSendsThroughLogNotifier(t *testing.T) {
+	subject, body, err := RenderPage(ReasonDiaper, PageData{
+		CaretakerName: "Ana",
+		ChildName:     "João",
+		GroupName:     "Berçário",
+		SecurityCode:  "A1B2",
+	})
+	if err != nil {
+		t.Fatalf("RenderPage: %v", err)
+	}
+
+	email := NewLogNotifier(ChannelEmail)
+	reg := NewRegistry(email)
+	if err := reg.Send(context.Background(), ChannelEmail, Message{To: "ana@example.com", Subject: subject, Body: body}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	sent := email.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("got %d messages, want 1", len(sent))
+	}
+	if !strings.Contains(sent[0].Subject, "João") {
+		t.Errorf("subject %q does not name the child", sent[0].Subject)
+	}
+	for _, want := range []string{"Ana", "Berçário", "A1B2"} {
+		if !strings.Contains(sent[0].Body, want) {
+			t.Errorf("body %q does not contain %q", sent[0].Body, want)
+		}
+	}
+}
+
+func TestRenderPageUnknownReason(t *testing.T) {
+	if _, _, err := RenderPage("unknown", PageData{}); !errors.Is(err, ErrUnknownReason) {
+		t.Fatalf("RenderPage error = %v, want ErrUnknownReason", err)
+	}
+}
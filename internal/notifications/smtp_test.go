@@ -0,0 +1,117 @@
+package notifications
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMessageStripsHeaderInjection(t *testing.T) {
+	msg := Message{
+		To:      "ana@example.com",
+		Subject: "João precisa de você\r\nBcc: intruso@example.com",
+		Body:    "Olá",
+	}
+
+	to, data, err := buildMessage("kids@example.com", msg)
+	if err != nil {
+		t.Fatalf("buildMessage: %v", err)
+	}
+	if to != "ana@example.com" {
+		t.Errorf("recipient = %q, want ana@example.com", to)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if bcc := parsed.Header.Get("Bcc"); bcc != "" {
+		t.Fatalf("injected Bcc header %q", bcc)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("DecodeHeader: %v", err)
+	}
+	if want := "João precisa de você  Bcc: intruso@example.com"; subject != want {
+		t.Errorf("subject = %q, want %q", subject, want)
+	}
+}
+
+func TestBuildMessageEncodesNonASCII(t *testing.T) {
+	_, data, err := buildMessage("kids@example.com", Message{
+		To:      "ana@example.com",
+		Subject: "Troca de fralda: Conceição",
+		Body:    "Olá, Ana. Venha até a sala Berçário.",
+	})
+	if err != nil {
+		t.Fatalf("buildMessage: %v", err)
+	}
+
+	for _, b := range data {
+		if b >= 0x80 {
+			t.Fatalf("message has raw non-ASCII byte 0x%x:\n%s", b, data)
+		}
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("DecodeHeader: %v", err)
+	}
+	if subject != "Troca de fralda: Conceição" {
+		t.Errorf("subject = %q", subject)
+	}
+}
+
+func TestBuildMessageRejectsInvalidRecipient(t *testing.T) {
+	_, _, err := buildMessage("kids@example.com", Message{To: "not an address", Subject: "Oi"})
+	if err == nil {
+		t.Fatal("buildMessage accepted an invalid recipient")
+	}
+}
+
+func TestSMTPSendHonoursContextDeadline(t *testing.T) {
+	// Servidor que aceita a conexão e nunca responde ao cumprimento SMTP
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	n := NewSMTPNotifier(SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "kids@example.com"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = n.Send(ctx, Message{To: "ana@example.com", Subject: "Oi", Body: "Olá"})
+	if err == nil {
+		t.Fatal("Send succeeded against a silent server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Send took %v, want it to stop at the context deadline", elapsed)
+	}
+}
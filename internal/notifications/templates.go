@@ -0,0 +1,84 @@
+package notifications
+
+import (
+	"bytes"
+	"errors"
+	"text/template"
+)
+
+// Motivos pelos quais um voluntário chama o responsável.
+const (
+	ReasonCrying = "crying"
+	ReasonDiaper = "diaper"
+	ReasonInjury = "injury"
+	ReasonOther  = "other"
+)
+
+// ErrUnknownReason is returned when there is no template for a reason.
+var ErrUnknownReason = errors.New("unknown notification reason")
+
+// PageData are the fields available to the page-parent templates.
+type PageData struct {
+	CaretakerName string
+	ChildName     string
+	GroupName     string
+	SecurityCode  string
+	Note          string
+}
+
+type pageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newPageTemplate(subject, body string) pageTemplate {
+	return pageTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+var pageTemplates = map[string]pageTemplate{
+	ReasonCrying: newPageTemplate(
+		"{{.ChildName}} precisa de você",
+		"Olá, {{.CaretakerName}}. {{.ChildName}} está chorando e pediu por você. Por favor, venha até a sala{{if .GroupName}} {{.GroupName}}{{end}} (código {{.SecurityCode}}).{{if .Note}}\n{{.Note}}{{end}}",
+	),
+	ReasonDiaper: newPageTemplate(
+		"Troca de fralda: {{.ChildName}}",
+		"Olá, {{.CaretakerName}}. {{.ChildName}} precisa de uma troca de fralda. Por favor, venha até a sala{{if .GroupName}} {{.GroupName}}{{end}} (código {{.SecurityCode}}).{{if .Note}}\n{{.Note}}{{end}}",
+	),
+	ReasonInjury: newPageTemplate(
+		"Urgente: {{.ChildName}} se machucou",
+		"Olá, {{.CaretakerName}}. {{.ChildName}} sofreu um pequeno acidente e a equipe precisa de você. Por favor, venha imediatamente até a sala{{if .GroupName}} {{.GroupName}}{{end}} (código {{.SecurityCode}}).{{if .Note}}\n{{.Note}}{{end}}",
+	),
+	ReasonOther: newPageTemplate(
+		"{{.ChildName}} precisa de você",
+		"Olá, {{.CaretakerName}}. A equipe precisa falar com você sobre {{.ChildName}}. Por favor, venha até a sala{{if .GroupName}} {{.GroupName}}{{end}} (código {{.SecurityCode}}).{{if .Note}}\n{{.Note}}{{end}}",
+	),
+}
+
+// KnownReason informa se existe template para o motivo.
+func KnownReason(reason string) bool {
+	_, ok := pageTemplates[reason]
+	return ok
+}
+
+// RenderPage monta a mensagem de chamada do responsável para o motivo informado.
+func RenderPage(reason string, data PageData) (subject, body string, err error) {
+	tmpl, ok := pageTemplates[reason]
+	if !ok {
+		return "", "", ErrUnknownReason
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.subject.Execute(&buf, data); err != nil {
+		return "", "", err
+	}
+	subject = buf.String()
+
+	buf.Reset()
+	if err := tmpl.body.Execute(&buf, data); err != nil {
+		return "", "", err
+	}
+	return subject, buf.String(), nil
+}
@@ -0,0 +1,65 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier envia mensagens para um gateway HTTP genérico (por exemplo,
+// um provedor de SMS), postando {"to", "subject", "body"} em JSON.
+type WebhookNotifier struct {
+	channel string
+	url     string
+	token   string
+	client  *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier for the given channel. When
+// token is set it is sent as a Bearer Authorization header.
+func NewWebhookNotifier(channel, url, token string) *WebhookNotifier {
+	return &WebhookNotifier{
+		channel: channel,
+		url:     url,
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) Channel() string {
+	return n.channel
+}
+
+func (n *WebhookNotifier) Send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"body":    msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Package pdf generates simple text-only PDF documents (A4, Helvetica) without
+// external dependencies. It covers what the printable reports need: titles,
+// labeled fields and wrapped paragraphs with automatic page breaks.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Dimensões de uma página A4 em pontos.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 56.0
+)
+
+// Tamanhos de fonte.
+const (
+	titleSize = 16.0
+	textSize  = 10.0
+	lineGap   = 1.4
+)
+
+// avgCharWidth é a largura média de um caractere da Helvetica, em fração do
+// tamanho da fonte, usada para quebrar linhas.
+const avgCharWidth = 0.5
+
+// Document acumula o conteúdo das páginas.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// New creates an empty Document.
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Title escreve um título em negrito.
+func (d *Document) Title(text string) {
+	d.line("F2", titleSize, margin, text)
+	d.Space()
+}
+
+// Heading escreve o título de uma seção.
+func (d *Document) Heading(text string) {
+	d.Space()
+	d.line("F2", textSize+2, margin, text)
+}
+
+// Field escreve "Rótulo: valor", quebrando o valor em várias linhas se preciso.
+func (d *Document) Field(label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	d.Paragraph(label + ": " + value)
+}
+
+// Paragraph escreve um texto, quebrando as linhas na largura da página.
+func (d *Document) Paragraph(text string) {
+	for _, line := range wrap(text, textSize) {
+		d.line("F1", textSize, margin, line)
+	}
+}
+
+// Space insere uma linha em branco.
+func (d *Document) Space() {
+	d.y -= textSize * lineGap
+}
+
+func (d *Document) line(font string, size, x float64, text string) {
+	if d.y-size < margin {
+		d.newPage()
+	}
+	d.y -= size * lineGap
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escape(text))
+}
+
+// wrap quebra o texto em linhas que cabem na largura útil da página.
+func wrap(text string, size float64) []string {
+	maxChars := int((pageWidth - 2*margin) / (size * avgCharWidth))
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		current := ""
+		for _, word := range words {
+			for len([]rune(word)) > maxChars {
+				if current != "" {
+					lines = append(lines, current)
+					current = ""
+				}
+				runes := []rune(word)
+				lines = append(lines, string(runes[:maxChars]))
+				word = string(runes[maxChars:])
+			}
+
+			switch {
+			case current == "":
+				current = word
+			case len([]rune(current))+1+len([]rune(word)) <= maxChars:
+				current += " " + word
+			default:
+				lines = append(lines, current)
+				current = word
+			}
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// escape converte o texto para WinAnsiEncoding (Latin-1 cobre o português) e
+// escapa os caracteres especiais das strings PDF.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r < 256:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// WriteTo serializa o documento em w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objetos 1-4: catálogo, árvore de páginas e as duas fontes.
+	pagesID := 2
+	firstPageID := 5
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageID+2*i)
+	}
+
+	object(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pagesID, pageWidth, pageHeight, firstPageID+2*i+1,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.WriteTo(w)
+}
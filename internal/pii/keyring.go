@@ -0,0 +1,208 @@
+// Package pii cifra campos sensíveis (envelope encryption): cada valor é
+// cifrado com AES-GCM por uma chave de dados, e as chaves de dados são
+// guardadas no banco cifradas pela chave mestra da configuração.
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// KeySize é o tamanho, em bytes, das chaves mestra, de dados e do índice cego.
+const KeySize = 32
+
+// prefix identifica valores cifrados: "enc:v1:<id da chave>:<nonce+cifra em base64>".
+const prefix = "enc:v1:"
+
+// ErrUnknownKey is returned when a value was encrypted with a data key that is not loaded.
+var ErrUnknownKey = errors.New("pii: unknown data key")
+
+// ErrMalformed is returned when an encrypted value cannot be parsed.
+var ErrMalformed = errors.New("pii: malformed encrypted value")
+
+// Keyring guarda as chaves de dados abertas. Cifra sempre com a chave ativa e
+// decifra com qualquer chave carregada, o que permite a rotação gradual.
+type Keyring struct {
+	mu       sync.RWMutex
+	keys     map[int]cipher.AEAD
+	active   int
+	indexKey []byte
+}
+
+// NewKeyring cria um Keyring vazio; indexKey é a chave HMAC dos índices cegos.
+func NewKeyring(indexKey []byte) *Keyring {
+	return &Keyring{
+		keys:     make(map[int]cipher.AEAD),
+		indexKey: indexKey,
+	}
+}
+
+// Add carrega uma chave de dados já decifrada.
+func (k *Keyring) Add(id int, dataKey []byte) error {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[id] = aead
+	return nil
+}
+
+// SetActive define a chave usada nas novas cifragens.
+func (k *Keyring) SetActive(id int) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.keys[id]; !ok {
+		return ErrUnknownKey
+	}
+	k.active = id
+	return nil
+}
+
+// ActivePrefix é o início de todo valor cifrado com a chave ativa.
+func (k *Keyring) ActivePrefix() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return prefix + strconv.Itoa(k.active) + ":"
+}
+
+// Encrypt cifra plain com a chave ativa.
+func (k *Keyring) Encrypt(plain string) (string, error) {
+	k.mu.RLock()
+	id, aead := k.active, k.keys[k.active]
+	k.mu.RUnlock()
+
+	if aead == nil {
+		return "", ErrUnknownKey
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plain), nil)
+	return prefix + strconv.Itoa(id) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decifra um valor produzido por Encrypt.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	idPart, payload, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok || !IsEncrypted(value) {
+		return "", ErrMalformed
+	}
+
+	id, err := strconv.Atoi(idPart)
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	k.mu.RLock()
+	aead := k.keys[id]
+	k.mu.RUnlock()
+	if aead == nil {
+		return "", fmt.Errorf("%w %d", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("pii: decrypt with key %d: %w", id, err)
+	}
+	return string(plain), nil
+}
+
+// BlindIndex retorna o HMAC de value para buscas por igualdade em campos
+// cifrados. purpose separa os índices de campos diferentes.
+func (k *Keyring) BlindIndex(purpose, value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted indica se value está no formato produzido por Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// NewDataKey gera uma chave de dados aleatória.
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// MasterKeyID identifica a chave mestra sem revelá-la, para saber qual chave
+// mestra cifrou cada chave de dados.
+func MasterKeyID(master []byte) string {
+	sum := sha256.Sum256(master)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Wrap cifra a chave de dados com a chave mestra.
+func Wrap(master, dataKey []byte) ([]byte, error) {
+	aead, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+// Unwrap decifra uma chave de dados cifrada por Wrap.
+func Unwrap(master, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], nil)
+}
+
+// DecodeKey lê uma chave de KeySize bytes em base64 (formato da configuração).
+func DecodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("pii: invalid key encoding: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("pii: key must have %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
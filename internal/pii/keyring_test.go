@@ -0,0 +1,162 @@
+package pii
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T, ids ...int) *Keyring {
+	t.Helper()
+	k := NewKeyring(bytes.Repeat([]byte{7}, KeySize))
+	for _, id := range ids {
+		key, err := NewDataKey()
+		if err != nil {
+			t.Fatalf("NewDataKey: %v", err)
+		}
+		if err := k.Add(id, key); err != nil {
+			t.Fatalf("Add(%d): %v", id, err)
+		}
+	}
+	if len(ids) > 0 {
+		if err := k.SetActive(ids[len(ids)-1]); err != nil {
+			t.Fatalf("SetActive: %v", err)
+		}
+	}
+	return k
+}
+
+func TestKeyringEncryptDecryptRoundTrip(t *testing.T) {
+	k := newTestKeyring(t, 1)
+
+	for _, plain := range []string{"Amendoim (grave)", "+55 11 99999-0000", "Rua das Flores, 123 — apto 4", ""} {
+		sealed, err := k.Encrypt(plain)
+		if err != nil {
+			t.Fatalf("Encrypt(%q): %v", plain, err)
+		}
+		if !IsEncrypted(sealed) || !strings.HasPrefix(sealed, k.ActivePrefix()) {
+			t.Fatalf("Encrypt(%q) = %q, want the active key prefix %q", plain, sealed, k.ActivePrefix())
+		}
+		if plain != "" && strings.Contains(sealed, plain) {
+			t.Fatalf("Encrypt(%q) leaks the plain text: %q", plain, sealed)
+		}
+
+		got, err := k.Decrypt(sealed)
+		if err != nil {
+			t.Fatalf("Decrypt: %v", err)
+		}
+		if got != plain {
+			t.Fatalf("Decrypt = %q, want %q", got, plain)
+		}
+	}
+}
+
+func TestKeyringEncryptUsesFreshNonce(t *testing.T) {
+	k := newTestKeyring(t, 1)
+
+	a, _ := k.Encrypt("Asma")
+	b, _ := k.Encrypt("Asma")
+	if a == b {
+		t.Fatal("two encryptions of the same value are identical")
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	k := newTestKeyring(t, 1)
+	old, err := k.Encrypt("Epilepsia")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	// Nova chave ativa: valores antigos continuam legíveis, mas deixam de ter o prefixo ativo
+	key, _ := NewDataKey()
+	if err := k.Add(2, key); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := k.SetActive(2); err != nil {
+		t.Fatalf("SetActive: %v", err)
+	}
+	if strings.HasPrefix(old, k.ActivePrefix()) {
+		t.Fatalf("value under the old key %q still matches the active prefix %q", old, k.ActivePrefix())
+	}
+
+	plain, err := k.Decrypt(old)
+	if err != nil || plain != "Epilepsia" {
+		t.Fatalf("Decrypt(old) = %q, %v; want Epilepsia", plain, err)
+	}
+
+	rotated, err := k.Encrypt(plain)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if !strings.HasPrefix(rotated, k.ActivePrefix()) {
+		t.Fatalf("re-encrypted value %q does not use the active key", rotated)
+	}
+	if plain, err := k.Decrypt(rotated); err != nil || plain != "Epilepsia" {
+		t.Fatalf("Decrypt(rotated) = %q, %v; want Epilepsia", plain, err)
+	}
+}
+
+func TestKeyringDecryptErrors(t *testing.T) {
+	k := newTestKeyring(t, 1)
+	sealed, _ := k.Encrypt("Diabetes tipo 1")
+
+	other := newTestKeyring(t, 2)
+	if _, err := other.Decrypt(sealed); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt with a keyring missing the key: err = %v, want ErrUnknownKey", err)
+	}
+
+	// Mesmo id, outra chave: a autenticação do GCM falha
+	impostor := newTestKeyring(t, 1)
+	if _, err := impostor.Decrypt(sealed); err == nil {
+		t.Error("Decrypt with a different key under the same id succeeded")
+	}
+
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if tampered == sealed {
+		tampered = sealed[:len(sealed)-2] + "BB"
+	}
+	if _, err := k.Decrypt(tampered); err == nil {
+		t.Error("Decrypt accepted a tampered value")
+	}
+
+	for _, value := range []string{"texto puro", prefix + "x:abc", prefix + "1"} {
+		if _, err := k.Decrypt(value); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Decrypt(%q): err = %v, want ErrMalformed", value, err)
+		}
+	}
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	master, _ := NewDataKey()
+	dataKey, _ := NewDataKey()
+
+	wrapped, err := Wrap(master, dataKey)
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	got, err := Unwrap(master, wrapped)
+	if err != nil {
+		t.Fatalf("Unwrap: %v", err)
+	}
+	if !bytes.Equal(got, dataKey) {
+		t.Fatal("Unwrap returned a different data key")
+	}
+
+	otherMaster, _ := NewDataKey()
+	if _, err := Unwrap(otherMaster, wrapped); err == nil {
+		t.Fatal("Unwrap with another master key succeeded")
+	}
+}
+
+func TestBlindIndexIsDeterministicPerPurpose(t *testing.T) {
+	k := newTestKeyring(t)
+
+	if k.BlindIndex("phone", "11999990000") != k.BlindIndex("phone", "11999990000") {
+		t.Fatal("BlindIndex is not deterministic")
+	}
+	if k.BlindIndex("phone", "11999990000") == k.BlindIndex("email", "11999990000") {
+		t.Fatal("BlindIndex does not separate purposes")
+	}
+}
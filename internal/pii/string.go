@@ -0,0 +1,142 @@
+package pii
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrNoKeyring is returned when an encrypted value is read before a keyring is configured.
+var ErrNoKeyring = errors.New("pii: no keyring configured")
+
+var current atomic.Pointer[Keyring]
+
+var loader atomic.Pointer[func() (*Keyring, error)]
+
+// SetKeyring define o Keyring usado pelos valores String e por BlindIndex.
+// Sem Keyring, os valores são gravados em texto puro (apenas desenvolvimento).
+func SetKeyring(k *Keyring) {
+	current.Store(k)
+}
+
+// SetLoader define como recarregar o Keyring ao encontrar um valor cifrado
+// com uma chave desconhecida, o que acontece quando outro processo rotaciona
+// as chaves enquanto este está no ar.
+func SetLoader(load func() (*Keyring, error)) {
+	loader.Store(&load)
+}
+
+// BlindIndex calcula o índice cego com o Keyring configurado. Sem Keyring,
+// usa uma chave vazia, o que mantém as buscas funcionando em desenvolvimento.
+func BlindIndex(purpose, value string) string {
+	if k := current.Load(); k != nil {
+		return k.BlindIndex(purpose, value)
+	}
+	return NewKeyring(nil).BlindIndex(purpose, value)
+}
+
+// String é um texto gravado cifrado no banco e decifrado na leitura, de forma
+// transparente para os repositórios. Valores legados em texto puro continuam
+// legíveis até serem cifrados pela rotação de chaves; o texto vazio não é cifrado.
+type String string
+
+// Value implementa driver.Valuer.
+func (s String) Value() (driver.Value, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	k := current.Load()
+	if k == nil {
+		return string(s), nil
+	}
+	return k.Encrypt(string(s))
+}
+
+// Scan implementa sql.Scanner.
+func (s *String) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("pii: cannot scan %T into String", src)
+	}
+
+	if !IsEncrypted(value) {
+		*s = String(value)
+		return nil
+	}
+
+	k := current.Load()
+	if k == nil {
+		return ErrNoKeyring
+	}
+
+	plain, err := k.Decrypt(value)
+	if errors.Is(err, ErrUnknownKey) {
+		if k, err = reload(); err == nil {
+			plain, err = k.Decrypt(value)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	*s = String(plain)
+	return nil
+}
+
+// Strings é uma lista de textos gravada como um único JSON cifrado, de modo que
+// o banco não enxerga nem os itens nem a quantidade deles.
+type Strings []string
+
+// Value implementa driver.Valuer. A lista nula é gravada como lista vazia.
+func (s Strings) Value() (driver.Value, error) {
+	items := []string(s)
+	if items == nil {
+		items = []string{}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return String(data).Value()
+}
+
+// Scan implementa sql.Scanner.
+func (s *Strings) Scan(src interface{}) error {
+	var plain String
+	if err := plain.Scan(src); err != nil {
+		return err
+	}
+
+	items := []string{}
+	if plain != "" {
+		if err := json.Unmarshal([]byte(plain), &items); err != nil {
+			return fmt.Errorf("pii: cannot decode Strings: %w", err)
+		}
+	}
+	*s = items
+	return nil
+}
+
+func reload() (*Keyring, error) {
+	load := loader.Load()
+	if load == nil {
+		return nil, ErrUnknownKey
+	}
+
+	k, err := (*load)()
+	if err != nil {
+		return nil, err
+	}
+	current.Store(k)
+	return k, nil
+}
@@ -0,0 +1,163 @@
+package pii
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useKeyring instala k como Keyring global durante o teste.
+func useKeyring(t *testing.T, k *Keyring) {
+	t.Helper()
+	SetKeyring(k)
+	t.Cleanup(func() {
+		SetKeyring(nil)
+		loader.Store(nil)
+	})
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	useKeyring(t, newTestKeyring(t, 1))
+
+	value, err := String("Rua das Flores, 123").Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	stored, ok := value.(string)
+	if !ok || !IsEncrypted(stored) {
+		t.Fatalf("Value = %#v, want an encrypted string", value)
+	}
+
+	var got String
+	if err := got.Scan([]byte(stored)); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got != "Rua das Flores, 123" {
+		t.Fatalf("Scan = %q", got)
+	}
+}
+
+func TestStringEmptyAndNull(t *testing.T) {
+	useKeyring(t, newTestKeyring(t, 1))
+
+	if value, err := String("").Value(); err != nil || value != "" {
+		t.Fatalf("Value(\"\") = %#v, %v; want an unencrypted empty string", value, err)
+	}
+
+	got := String("antigo")
+	if err := got.Scan(nil); err != nil || got != "" {
+		t.Fatalf("Scan(nil) = %q, %v", got, err)
+	}
+}
+
+func TestStringReadsLegacyPlainText(t *testing.T) {
+	useKeyring(t, newTestKeyring(t, 1))
+
+	var got String
+	if err := got.Scan("11 99999-0000"); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got != "11 99999-0000" {
+		t.Fatalf("Scan = %q", got)
+	}
+}
+
+func TestStringWithoutKeyring(t *testing.T) {
+	k := newTestKeyring(t, 1)
+	sealed, _ := k.Encrypt("Asma")
+	useKeyring(t, nil)
+
+	if value, err := String("Asma").Value(); err != nil || value != "Asma" {
+		t.Fatalf("Value without keyring = %#v, %v; want plain text", value, err)
+	}
+
+	var got String
+	if err := got.Scan(sealed); !errors.Is(err, ErrNoKeyring) {
+		t.Fatalf("Scan of an encrypted value without keyring: err = %v, want ErrNoKeyring", err)
+	}
+}
+
+func TestStringReloadsKeyringAfterRotation(t *testing.T) {
+	// Outro processo rotacionou a chave e gravou com ela
+	rotated := newTestKeyring(t, 1, 2)
+	sealed, err := rotated.Encrypt("Alergia a dipirona")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	stale := NewKeyring(nil)
+	useKeyring(t, stale)
+	loads := 0
+	SetLoader(func() (*Keyring, error) {
+		loads++
+		return rotated, nil
+	})
+
+	var got String
+	if err := got.Scan(sealed); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got != "Alergia a dipirona" || loads != 1 {
+		t.Fatalf("Scan = %q after %d loads; want the plain text after 1 load", got, loads)
+	}
+
+	// O Keyring recarregado passa a ser o global e cifra com a nova chave ativa
+	value, err := String("Alergia a dipirona").Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if !strings.HasPrefix(value.(string), rotated.ActivePrefix()) {
+		t.Fatalf("Value = %q, want the reloaded active key %q", value, rotated.ActivePrefix())
+	}
+}
+
+func TestStringsRoundTrip(t *testing.T) {
+	useKeyring(t, newTestKeyring(t, 1))
+
+	conditions := Strings{"Asma", "Epilepsia, controlada"}
+	value, err := conditions.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	stored := value.(string)
+	if !IsEncrypted(stored) || strings.Contains(stored, "Asma") {
+		t.Fatalf("Value = %q, want the whole list encrypted", stored)
+	}
+
+	var got Strings
+	if err := got.Scan(stored); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !reflect.DeepEqual(got, conditions) {
+		t.Fatalf("Scan = %#v, want %#v", got, conditions)
+	}
+}
+
+func TestStringsEmptyAndLegacy(t *testing.T) {
+	useKeyring(t, newTestKeyring(t, 1))
+
+	value, err := Strings(nil).Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	var got Strings
+	if err := got.Scan(value); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Fatalf("nil list read back as %#v, want an empty list", got)
+	}
+
+	// Valor convertido pela migração e ainda não cifrado pela rotação
+	if err := got.Scan([]byte(`["Asma","Celíaca"]`)); err != nil {
+		t.Fatalf("Scan legacy: %v", err)
+	}
+	if !reflect.DeepEqual(got, Strings{"Asma", "Celíaca"}) {
+		t.Fatalf("Scan legacy = %#v", got)
+	}
+
+	if err := got.Scan("{Asma}"); err == nil {
+		t.Fatal("Scan accepted a value that is not a JSON list")
+	}
+}
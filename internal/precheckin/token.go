@@ -0,0 +1,108 @@
+// Package precheckin signs and verifies the QR payloads used for online pre-check-in.
+package precheckin
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned when the token is malformed or its signature does not match.
+	ErrInvalidToken = errors.New("invalid pre-check-in code")
+	// ErrExpiredToken is returned when the token is past its expiry.
+	ErrExpiredToken = errors.New("pre-check-in code has expired")
+)
+
+// Payload is the content carried by the QR code.
+type Payload struct {
+	Nonce       string    `json:"nonce"`
+	CaretakerID string    `json:"caretaker_id"`
+	ChildIDs    []string  `json:"child_ids"`
+	EventID     string    `json:"event_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Signer emite e valida tokens no formato base64url(payload).base64url(hmac).
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSigner creates a Signer using an HMAC-SHA256 secret and the token lifetime.
+func NewSigner(secret string, ttl time.Duration) *Signer {
+	return &Signer{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Sign preenche o nonce e a expiração do payload e retorna o token assinado.
+func (s *Signer) Sign(payload *Payload) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload.Nonce = hex.EncodeToString(nonce)
+	payload.ExpiresAt = s.now().Add(s.ttl).UTC().Truncate(time.Second)
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	body := base64.RawURLEncoding.EncodeToString(raw)
+	return body + "." + base64.RawURLEncoding.EncodeToString(s.mac(body)), nil
+}
+
+// Verify confere a assinatura e a validade do token e retorna o payload.
+func (s *Signer) Verify(token string) (*Payload, error) {
+	body, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.mac(body)) {
+		return nil, ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.Nonce == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if !s.now().Before(payload.ExpiresAt) {
+		return nil, ErrExpiredToken
+	}
+
+	return &payload, nil
+}
+
+// RandomSecret gera um segredo aleatório, útil apenas em desenvolvimento.
+func RandomSecret() string {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(secret)
+}
+
+func (s *Signer) mac(body string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(body))
+	return h.Sum(nil)
+}
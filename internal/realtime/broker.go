@@ -0,0 +1,97 @@
+// Package realtime distributes room dashboard events to connected clients.
+package realtime
+
+import (
+	"sync"
+
+	"github.com/eduardohass/kids-api/internal/models"
+)
+
+// subscriberBuffer é quantos eventos um cliente lento pode acumular antes de
+// ser desconectado. Ao reconectar com Last-Event-ID ele recupera o que perdeu.
+const subscriberBuffer = 64
+
+// Broker é o pub/sub em memória que entrega os eventos de cada sala aos
+// clientes SSE conectados nesta réplica.
+type Broker struct {
+	mu     sync.Mutex
+	subs   map[string]map[chan *models.GroupEvent]struct{}
+	closed bool
+}
+
+// NewBroker creates a new Broker instance.
+func NewBroker() *Broker {
+	return &Broker{
+		subs: make(map[string]map[chan *models.GroupEvent]struct{}),
+	}
+}
+
+// Subscribe registra um cliente nos eventos da sala. A função retornada
+// cancela a inscrição e deve sempre ser chamada. O canal é fechado quando o
+// cliente não acompanha o ritmo dos eventos.
+func (b *Broker) Subscribe(groupID string) (<-chan *models.GroupEvent, func()) {
+	ch := make(chan *models.GroupEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subs[groupID] == nil {
+		b.subs[groupID] = make(map[chan *models.GroupEvent]struct{})
+	}
+	b.subs[groupID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(groupID, ch)
+	}
+}
+
+// Dispatch entrega o evento aos clientes da sala sem bloquear.
+func (b *Broker) Dispatch(event *models.GroupEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs[event.GroupID] {
+		select {
+		case ch <- event:
+		default:
+			b.remove(event.GroupID, ch)
+		}
+	}
+}
+
+// Close desconecta todos os clientes, permitindo que o servidor HTTP encerre
+// as conexões SSE no graceful shutdown.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for groupID, subs := range b.subs {
+		for ch := range subs {
+			b.remove(groupID, ch)
+		}
+	}
+}
+
+// remove deve ser chamado com b.mu travado.
+func (b *Broker) remove(groupID string, ch chan *models.GroupEvent) {
+	subs, ok := b.subs[groupID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(b.subs, groupID)
+	}
+}
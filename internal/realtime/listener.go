@@ -0,0 +1,77 @@
+package realtime
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/lib/pq"
+)
+
+// Listener recebe os eventos publicados por qualquer réplica via LISTEN/NOTIFY
+// e os repassa ao Broker local.
+type Listener struct {
+	listener *pq.Listener
+	broker   *Broker
+	done     chan struct{}
+}
+
+// Listen conecta ao Postgres e começa a escutar o canal de eventos das salas.
+func Listen(databaseURL string, broker *Broker) (*Listener, error) {
+	pqListener := pq.NewListener(databaseURL, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Realtime listener: %v", err)
+		}
+	})
+
+	if err := pqListener.Listen(repository.GroupEventsChannel); err != nil {
+		pqListener.Close()
+		return nil, err
+	}
+
+	l := &Listener{
+		listener: pqListener,
+		broker:   broker,
+		done:     make(chan struct{}),
+	}
+	go l.run()
+	return l, nil
+}
+
+func (l *Listener) run() {
+	defer close(l.done)
+
+	for {
+		select {
+		case n, ok := <-l.listener.Notify:
+			if !ok {
+				return
+			}
+			// n == nil indica reconexão; eventos perdidos nesse intervalo
+			// são recuperados pelos clientes via Last-Event-ID.
+			if n == nil {
+				continue
+			}
+
+			var event models.GroupEvent
+			if err := json.Unmarshal([]byte(n.Extra), &event); err != nil {
+				log.Printf("Realtime listener: invalid event: %v", err)
+				continue
+			}
+			l.broker.Dispatch(&event)
+
+		case <-time.After(90 * time.Second):
+			// Verifica se a conexão continua viva quando não há eventos.
+			go l.listener.Ping()
+		}
+	}
+}
+
+// Close encerra a escuta.
+func (l *Listener) Close() error {
+	err := l.listener.Close()
+	<-l.done
+	return err
+}
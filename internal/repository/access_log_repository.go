@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+	"github.com/lib/pq"
+)
+
+// AccessLogRepository persiste as leituras de dados sensíveis e calcula os
+// padrões de acesso incomuns.
+type AccessLogRepository interface {
+	Create(ctx context.Context, accessor models.Accessor, resource, entityType string, entityIDs []string) error
+	List(ctx context.Context, filter models.AccessLogFilter, limit, offset int) ([]*models.AccessLogEntry, error)
+	OutsideGroups(ctx context.Context, since, until time.Time, privilegedRoles []string) ([]*models.AccessAnomaly, error)
+	HighVolume(ctx context.Context, since, until time.Time, threshold int) ([]*models.AccessAnomaly, error)
+	MissingReason(ctx context.Context, since, until time.Time) ([]*models.AccessAnomaly, error)
+	CountBefore(ctx context.Context, before time.Time) (int, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+type accessLogRepository struct {
+	db *tenant.DB
+}
+
+func NewAccessLogRepository(db *tenant.DB) AccessLogRepository {
+	return &accessLogRepository{db: db}
+}
+
+// anomalyColumns resume os acessos agrupados por ator (alias a).
+const anomalyColumns = `
+	a.actor,
+	COUNT(*) AS accesses,
+	COUNT(DISTINCT a.entity_id) AS entities,
+	array_agg(DISTINCT a.resource) AS resources,
+	MIN(a.accessed_at) AS first_access,
+	MAX(a.accessed_at) AS last_access
+`
+
+// Create grava uma leitura por entidade, em um único comando.
+func (r *accessLogRepository) Create(ctx context.Context, accessor models.Accessor, resource, entityType string, entityIDs []string) error {
+	const query = `
+		INSERT INTO sensitive_access_log (actor, roles, resource, entity_type, entity_id, reason, endpoint)
+		SELECT $1, $2, $3, $4, entity_id, $6, NULLIF($7, '')
+		FROM unnest($5::uuid[]) AS entity_id
+	`
+
+	roles := accessor.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		accessor.Actor,
+		pq.Array(roles),
+		resource,
+		entityType,
+		pq.Array(entityIDs),
+		accessor.Reason,
+		accessor.Endpoint,
+	)
+	if err != nil {
+		return fmt.Errorf("accessLogRepository.Create: %w", err)
+	}
+	return nil
+}
+
+// List retorna as leituras mais recentes que atendem ao filtro.
+func (r *accessLogRepository) List(ctx context.Context, filter models.AccessLogFilter, limit, offset int) ([]*models.AccessLogEntry, error) {
+	const query = `
+		SELECT
+			id,
+			actor,
+			roles,
+			resource,
+			entity_type,
+			entity_id,
+			reason,
+			COALESCE(endpoint, '') AS endpoint,
+			accessed_at
+		FROM sensitive_access_log
+		WHERE ($1 = '' OR actor = $1)
+			AND ($2 = '' OR resource = $2)
+			AND ($3 = '' OR entity_type = $3)
+			AND ($4 = '' OR entity_id::text = $4)
+			AND accessed_at >= $5
+			AND accessed_at < $6
+		ORDER BY accessed_at DESC
+		LIMIT $7 OFFSET $8
+	`
+
+	var entries []*models.AccessLogEntry
+	err := r.db.SelectContext(
+		ctx,
+		&entries,
+		query,
+		filter.Actor,
+		filter.Resource,
+		filter.EntityType,
+		filter.EntityID,
+		filter.Since,
+		filter.Until,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("accessLogRepository.List: %w", err)
+	}
+	return entries, nil
+}
+
+// OutsideGroups aponta voluntários que leram dados de crianças (diretamente,
+// pelo grupo, por um responsável, por um medicamento ou por uma ocorrência)
+// de grupos em cujas salas nunca foram escalados. Leituras feitas com algum
+// dos papéis privilegiados são ignoradas.
+func (r *accessLogRepository) OutsideGroups(ctx context.Context, since, until time.Time, privilegedRoles []string) ([]*models.AccessAnomaly, error) {
+	query := `
+		WITH staffed AS (
+			SELECT DISTINCT v.auth0_id AS actor, ra.group_id
+			FROM volunteers v
+			INNER JOIN room_staff rs ON rs.volunteer_id = v.id
+			INNER JOIN room_assignments ra ON ra.event_id = rs.event_id AND ra.room_id = rs.room_id
+			WHERE v.auth0_id IS NOT NULL
+		),
+		targets AS (
+			SELECT l.id, c.group_id
+			FROM sensitive_access_log l
+			INNER JOIN children c ON c.id = l.entity_id
+			WHERE l.entity_type = 'child' AND l.accessed_at >= $1 AND l.accessed_at < $2
+			UNION ALL
+			SELECT l.id, l.entity_id
+			FROM sensitive_access_log l
+			WHERE l.entity_type = 'group' AND l.accessed_at >= $1 AND l.accessed_at < $2
+			UNION ALL
+			SELECT l.id, c.group_id
+			FROM sensitive_access_log l
+			INNER JOIN children_caretakers cc ON cc.responsavel_id = l.entity_id
+			INNER JOIN children c ON c.id = cc.crianca_id
+			WHERE l.entity_type = 'caretaker' AND l.accessed_at >= $1 AND l.accessed_at < $2
+			UNION ALL
+			SELECT l.id, c.group_id
+			FROM sensitive_access_log l
+			INNER JOIN medication_orders mo ON mo.id = l.entity_id
+			INNER JOIN children c ON c.id = mo.child_id
+			WHERE l.entity_type = 'medication_order' AND l.accessed_at >= $1 AND l.accessed_at < $2
+			UNION ALL
+			SELECT l.id, c.group_id
+			FROM sensitive_access_log l
+			INNER JOIN incidents i ON i.id = l.entity_id
+			INNER JOIN children c ON c.id = i.child_id
+			WHERE l.entity_type = 'incident' AND l.accessed_at >= $1 AND l.accessed_at < $2
+		)
+		SELECT '` + models.AnomalyOutsideGroups + `' AS pattern, ` + anomalyColumns + `
+		FROM sensitive_access_log a
+		WHERE a.accessed_at >= $1
+			AND a.accessed_at < $2
+			AND NOT (a.roles && $3::text[])
+			AND a.actor IN (SELECT auth0_id FROM volunteers WHERE auth0_id IS NOT NULL AND deleted_at IS NULL)
+			AND EXISTS (SELECT 1 FROM targets t WHERE t.id = a.id AND t.group_id IS NOT NULL)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM targets t
+				INNER JOIN staffed s ON s.group_id = t.group_id AND s.actor = a.actor
+				WHERE t.id = a.id
+			)
+		GROUP BY a.actor
+		ORDER BY accesses DESC
+	`
+
+	var anomalies []*models.AccessAnomaly
+	if err := r.db.SelectContext(ctx, &anomalies, query, since, until, pq.Array(privilegedRoles)); err != nil {
+		return nil, fmt.Errorf("accessLogRepository.OutsideGroups: %w", err)
+	}
+	return anomalies, nil
+}
+
+// HighVolume aponta atores que leram dados de mais entidades distintas que o
+// limite no período.
+func (r *accessLogRepository) HighVolume(ctx context.Context, since, until time.Time, threshold int) ([]*models.AccessAnomaly, error) {
+	query := `
+		SELECT '` + models.AnomalyHighVolume + `' AS pattern, ` + anomalyColumns + `
+		FROM sensitive_access_log a
+		WHERE a.accessed_at >= $1 AND a.accessed_at < $2
+		GROUP BY a.actor
+		HAVING COUNT(DISTINCT a.entity_id) > $3
+		ORDER BY entities DESC
+	`
+
+	var anomalies []*models.AccessAnomaly
+	if err := r.db.SelectContext(ctx, &anomalies, query, since, until, threshold); err != nil {
+		return nil, fmt.Errorf("accessLogRepository.HighVolume: %w", err)
+	}
+	return anomalies, nil
+}
+
+// MissingReason aponta atores que leram dados sensíveis sem informar o motivo.
+func (r *accessLogRepository) MissingReason(ctx context.Context, since, until time.Time) ([]*models.AccessAnomaly, error) {
+	query := `
+		SELECT '` + models.AnomalyMissingReason + `' AS pattern, ` + anomalyColumns + `
+		FROM sensitive_access_log a
+		WHERE a.accessed_at >= $1 AND a.accessed_at < $2 AND a.reason = $3
+		GROUP BY a.actor
+		ORDER BY accesses DESC
+	`
+
+	var anomalies []*models.AccessAnomaly
+	if err := r.db.SelectContext(ctx, &anomalies, query, since, until, models.AccessReasonUnspecified); err != nil {
+		return nil, fmt.Errorf("accessLogRepository.MissingReason: %w", err)
+	}
+	return anomalies, nil
+}
+
+func (r *accessLogRepository) CountBefore(ctx context.Context, before time.Time) (int, error) {
+	const query = `SELECT COUNT(*) FROM sensitive_access_log WHERE accessed_at < $1`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, before); err != nil {
+		return 0, fmt.Errorf("accessLogRepository.CountBefore: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteBefore exclui os registros de leitura anteriores a before.
+func (r *accessLogRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	const query = `DELETE FROM sensitive_access_log WHERE accessed_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("accessLogRepository.DeleteBefore: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("accessLogRepository.DeleteBefore: %w", err)
+	}
+	return int(rows), nil
+}
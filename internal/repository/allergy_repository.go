@@ -6,7 +6,7 @@ import (
 	"fmt"
 
 	"github.com/eduardohass/kids-api/internal/models"
-	"github.com/jmoiron/sqlx"
+	"github.com/eduardohass/kids-api/internal/tenant"
 )
 
 type AllergyRepository interface {
@@ -15,16 +15,27 @@ type AllergyRepository interface {
 }
 
 type allergyRepository struct {
-	db *sqlx.DB
+	db *tenant.DB
 }
 
-func NewAllergyRepository(db *sqlx.DB) AllergyRepository {
+func NewAllergyRepository(db *tenant.DB) AllergyRepository {
 	return &allergyRepository{db: db}
 }
 
 func (r *allergyRepository) Create(ctx context.Context, allergy *models.Allergy) error {
-	// TODO: implemente a criação do registro de allergy conforme necessário
-	return nil
+	const query = `
+		INSERT INTO allergies (type, description, severity)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowxContext(
+		ctx,
+		query,
+		allergy.Type,
+		allergy.Description,
+		allergy.Severity,
+	).Scan(&allergy.ID, &allergy.CreatedAt, &allergy.UpdatedAt)
 }
 
 func (r *allergyRepository) GetByID(ctx context.Context, id string) (*models.Allergy, error) {
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ErrAlreadyCheckedIn is returned when a child already has an open check-in.
+var ErrAlreadyCheckedIn = errors.New("child is already checked in")
+
+// ErrAlreadyCheckedOut is returned when a check-out targets a closed attendance.
+var ErrAlreadyCheckedOut = errors.New("child is already checked out")
+
+// ErrRoomFull is returned when a check-in would take a room past its capacity
+// or volunteer ratio.
+var ErrRoomFull = errors.New("room is at capacity")
+
+type AttendanceRepository interface {
+	CreateMany(ctx context.Context, records []*models.Attendance) error
+	GetByID(ctx context.Context, id string) (*models.Attendance, error)
+	CheckOut(ctx context.Context, record *models.Attendance) error
+	ListByChildren(ctx context.Context, childIDs []string, limit, offset int) ([]*models.Attendance, error)
+	CountPresent(ctx context.Context, groupID string) (int, error)
+	ListPresent(ctx context.Context, groupID string) ([]*models.Attendance, error)
+	CountPresentInRoom(ctx context.Context, roomID string) (int, error)
+	CountPresentByGroup(ctx context.Context) ([]*models.GroupPresence, error)
+}
+
+type attendanceRepository struct {
+	db *tenant.DB
+}
+
+func NewAttendanceRepository(db *tenant.DB) AttendanceRepository {
+	return &attendanceRepository{db: db}
+}
+
+const attendanceColumns = `
+	id,
+	child_id,
+	COALESCE(group_id::text, '') AS group_id,
+	COALESCE(event_id::text, '') AS event_id,
+	COALESCE(location_id::text, '') AS location_id,
+	COALESCE(room_id::text, '') AS room_id,
+	COALESCE((SELECT name FROM rooms WHERE rooms.id = attendance.room_id), '') AS room_name,
+	security_code,
+	checked_in_at,
+	COALESCE(checked_in_by, '') AS checked_in_by,
+	checked_out_at,
+	COALESCE(checked_out_by, '') AS checked_out_by,
+	COALESCE(released_to_caretaker_id::text, '') AS released_to_caretaker_id,
+	COALESCE(released_to_name, '') AS released_to_name,
+	COALESCE(pickup_grant_id::text, '') AS pickup_grant_id,
+	created_at
+`
+
+// CreateMany registra o check-in de várias crianças em uma única transação:
+// ou todas entram, ou nenhuma. A lotação das salas é conferida de novo dentro
+// da transação; retorna ErrRoomFull se alguma sala não comporta as crianças.
+func (r *attendanceRepository) CreateMany(ctx context.Context, records []*models.Attendance) error {
+	const query = `
+		INSERT INTO attendance (
+			child_id,
+			group_id,
+			event_id,
+			location_id,
+			room_id,
+			security_code,
+			checked_in_by
+		) VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6, $7)
+		RETURNING id, checked_in_at, created_at
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("attendanceRepository.CreateMany: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkRoomCapacity(ctx, tx, records); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			record.ChildID,
+			record.GroupID,
+			record.EventID,
+			record.LocationID,
+			record.RoomID,
+			record.SecurityCode,
+			record.CheckedInBy,
+		).Scan(&record.ID, &record.CheckedInAt, &record.CreatedAt)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return ErrAlreadyCheckedIn
+			}
+			return fmt.Errorf("attendanceRepository.CreateMany: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("attendanceRepository.CreateMany: %w", err)
+	}
+	return nil
+}
+
+func (r *attendanceRepository) GetByID(ctx context.Context, id string) (*models.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + ` FROM attendance WHERE id = $1`
+
+	var record models.Attendance
+	if err := r.db.GetContext(ctx, &record, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("attendanceRepository.GetByID: %w", err)
+	}
+	return &record, nil
+}
+
+// CheckOut encerra a presença registrando quem liberou e quem retirou a criança.
+func (r *attendanceRepository) CheckOut(ctx context.Context, record *models.Attendance) error {
+	const query = `
+		UPDATE attendance SET
+			checked_out_at = NOW(),
+			checked_out_by = $1,
+			released_to_caretaker_id = NULLIF($2, '')::uuid,
+			released_to_name = NULLIF($3, ''),
+			pickup_grant_id = NULLIF($4, '')::uuid
+		WHERE id = $5 AND checked_out_at IS NULL
+		RETURNING checked_out_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		record.CheckedOutBy,
+		record.ReleasedToCaretakerID,
+		record.ReleasedToName,
+		record.PickupGrantID,
+		record.ID,
+	).Scan(&record.CheckedOutAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAlreadyCheckedOut
+	}
+	if err != nil {
+		return fmt.Errorf("attendanceRepository.CheckOut: %w", err)
+	}
+
+	return nil
+}
+
+// ListByChildren retorna o histórico de presença das crianças informadas, do mais recente ao mais antigo.
+func (r *attendanceRepository) ListByChildren(ctx context.Context, childIDs []string, limit, offset int) ([]*models.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + `
+		FROM attendance
+		WHERE child_id = ANY($1::uuid[])
+		ORDER BY checked_in_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var records []*models.Attendance
+	if err := r.db.SelectContext(ctx, &records, query, pq.Array(childIDs), limit, offset); err != nil {
+		return nil, fmt.Errorf("attendanceRepository.ListByChildren: %w", err)
+	}
+	return records, nil
+}
+
+// CountPresent conta as crianças do grupo com check-in aberto.
+func (r *attendanceRepository) CountPresent(ctx context.Context, groupID string) (int, error) {
+	const query = `SELECT COUNT(*) FROM attendance WHERE group_id = $1 AND checked_out_at IS NULL`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, groupID); err != nil {
+		return 0, fmt.Errorf("attendanceRepository.CountPresent: %w", err)
+	}
+	return count, nil
+}
+
+// ListPresent retorna os check-ins abertos da sala, em ordem de chegada.
+func (r *attendanceRepository) ListPresent(ctx context.Context, groupID string) ([]*models.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + `
+		FROM attendance
+		WHERE group_id = $1 AND checked_out_at IS NULL
+		ORDER BY checked_in_at`
+
+	var records []*models.Attendance
+	if err := r.db.SelectContext(ctx, &records, query, groupID); err != nil {
+		return nil, fmt.Errorf("attendanceRepository.ListPresent: %w", err)
+	}
+	return records, nil
+}
+
+// checkRoomCapacity trava as salas dos registros (FOR UPDATE, em ordem de id)
+// e confere a lotação dentro da transação: check-ins simultâneos na mesma sala
+// esperam um pelo outro, e o segundo já conta as crianças do primeiro.
+func checkRoomCapacity(ctx context.Context, tx *sqlx.Tx, records []*models.Attendance) error {
+	incoming := make(map[string]int)
+	events := make(map[string]string)
+	var roomIDs []string
+	for _, record := range records {
+		if record.RoomID == "" {
+			continue
+		}
+		if incoming[record.RoomID] == 0 {
+			roomIDs = append(roomIDs, record.RoomID)
+		}
+		incoming[record.RoomID]++
+		events[record.RoomID] = record.EventID
+	}
+	if len(roomIDs) == 0 {
+		return nil
+	}
+
+	const lockQuery = `SELECT ` + roomColumns + ` FROM rooms r WHERE r.id = ANY($1::uuid[]) ORDER BY r.id FOR UPDATE`
+	var rooms []*models.Room
+	if err := tx.SelectContext(ctx, &rooms, lockQuery, pq.Array(roomIDs)); err != nil {
+		return fmt.Errorf("attendanceRepository.checkRoomCapacity: %w", err)
+	}
+
+	for _, room := range rooms {
+		var present, staff int
+		if err := tx.GetContext(ctx, &present, `SELECT COUNT(*) FROM attendance WHERE room_id = $1 AND checked_out_at IS NULL`, room.ID); err != nil {
+			return fmt.Errorf("attendanceRepository.checkRoomCapacity: %w", err)
+		}
+		if err := tx.GetContext(ctx, &staff, `SELECT COUNT(*) FROM room_staff WHERE event_id = $1 AND room_id = $2`, events[room.ID], room.ID); err != nil {
+			return fmt.Errorf("attendanceRepository.checkRoomCapacity: %w", err)
+		}
+		if limit, ok := room.Limit(staff); ok && present+incoming[room.ID] > limit {
+			return ErrRoomFull
+		}
+	}
+	return nil
+}
+
+// CountPresentInRoom conta as crianças com check-in aberto na sala, de todos os grupos.
+func (r *attendanceRepository) CountPresentInRoom(ctx context.Context, roomID string) (int, error) {
+	const query = `SELECT COUNT(*) FROM attendance WHERE room_id = $1 AND checked_out_at IS NULL`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, roomID); err != nil {
+		return 0, fmt.Errorf("attendanceRepository.CountPresentInRoom: %w", err)
+	}
+	return count, nil
+}
+
+// CountPresentByGroup conta as crianças com check-in aberto em cada grupo.
+// Check-ins sem grupo aparecem com group_id vazio.
+func (r *attendanceRepository) CountPresentByGroup(ctx context.Context) ([]*models.GroupPresence, error) {
+	const query = `
+		SELECT
+			COALESCE(a.group_id::text, '') AS group_id,
+			COALESCE(g.name, '') AS group_name,
+			COUNT(*) AS count
+		FROM attendance a
+		LEFT JOIN groups g ON g.id = a.group_id
+		WHERE a.checked_out_at IS NULL
+		GROUP BY a.group_id, g.name
+		ORDER BY group_name
+	`
+
+	var counts []*models.GroupPresence
+	if err := r.db.SelectContext(ctx, &counts, query); err != nil {
+		return nil, fmt.Errorf("attendanceRepository.CountPresentByGroup: %w", err)
+	}
+	return counts, nil
+}
+
+// isUniqueViolation identifica violações de UNIQUE no Postgres (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+func TestCreateManyDoesNotOverbookRoom(t *testing.T) {
+	raw := isolationDB(t)
+	db := tenant.NewDB(raw)
+	ctx := tenant.WithID(context.Background(), createTenant(t, raw, "Campus"))
+
+	groups := NewGroupRepository(db)
+	children := NewChildRepository(db)
+	locations := NewLocationRepository(db)
+	rooms := NewRoomRepository(db)
+	attendance := NewAttendanceRepository(db)
+
+	group := &models.Group{Name: "Berçário"}
+	if err := groups.Create(ctx, group); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	location := &models.Location{Name: "Campus"}
+	if err := locations.Create(ctx, location); err != nil {
+		t.Fatalf("create location: %v", err)
+	}
+	room := &models.Room{LocationID: location.ID, Name: "Sala 1", Capacity: 1}
+	if err := rooms.Create(ctx, room); err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	const eventID = "6f1c2a34-0000-4000-8000-000000000001"
+	const attempts = 4
+	var records []*models.Attendance
+	for i := 0; i < attempts; i++ {
+		child := &models.Child{Name: "Criança", BirthDate: time.Now().AddDate(-1, 0, 0), Gender: "F", GroupID: group.ID}
+		if err := children.Create(ctx, child); err != nil {
+			t.Fatalf("create child: %v", err)
+		}
+		records = append(records, &models.Attendance{
+			ChildID:      child.ID,
+			GroupID:      group.ID,
+			EventID:      eventID,
+			LocationID:   location.ID,
+			RoomID:       room.ID,
+			SecurityCode: "A1B2",
+			CheckedInBy:  "auth0|staff",
+		})
+	}
+
+	// Check-ins simultâneos na última vaga: apenas um pode entrar
+	var wg sync.WaitGroup
+	errs := make([]error, attempts)
+	for i, record := range records {
+		wg.Add(1)
+		go func(i int, record *models.Attendance) {
+			defer wg.Done()
+			errs[i] = attendance.CreateMany(ctx, []*models.Attendance{record})
+		}(i, record)
+	}
+	wg.Wait()
+
+	admitted := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			admitted++
+		case !errors.Is(err, ErrRoomFull):
+			t.Errorf("CreateMany: err = %v, want nil or ErrRoomFull", err)
+		}
+	}
+	if admitted != 1 {
+		t.Fatalf("%d check-ins admitted into a room with capacity 1", admitted)
+	}
+
+	present, err := attendance.CountPresentInRoom(ctx, room.ID)
+	if err != nil {
+		t.Fatalf("CountPresentInRoom: %v", err)
+	}
+	if present != 1 {
+		t.Fatalf("room has %d children, want 1", present)
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+}
+
+type auditRepository struct {
+	db *tenant.DB
+}
+
+func NewAuditRepository(db *tenant.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	const query = `
+		INSERT INTO audit_log (
+			actor,
+			action,
+			entity_type,
+			entity_id,
+			details
+		) VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5)
+		RETURNING id, created_at
+	`
+
+	var details interface{}
+	if len(entry.Details) > 0 {
+		details = []byte(entry.Details)
+	}
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		entry.Actor,
+		entry.Action,
+		entry.EntityType,
+		entry.EntityID,
+		details,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("auditRepository.Create: %w", err)
+	}
+
+	return nil
+}
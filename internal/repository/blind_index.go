@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+)
+
+// Finalidades dos índices cegos: cada uma gera um HMAC diferente para o mesmo valor.
+const (
+	indexEmail      = "email"
+	indexPhone      = "phone"
+	indexPhoneLast4 = "phone_last4"
+)
+
+// emailIndex normaliza o e-mail (minúsculas, sem espaços) antes de calcular o índice.
+func emailIndex(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return ""
+	}
+	return pii.BlindIndex(indexEmail, email)
+}
+
+// phoneIndex considera apenas os dígitos do telefone, ignorando a formatação.
+func phoneIndex(phone string) string {
+	digits := onlyDigits(phone)
+	if digits == "" {
+		return ""
+	}
+	return pii.BlindIndex(indexPhone, digits)
+}
+
+// phoneLast4Index indexa os últimos 4 dígitos, usados na busca do quiosque.
+func phoneLast4Index(phone string) string {
+	digits := onlyDigits(phone)
+	if len(digits) < 4 {
+		return ""
+	}
+	return pii.BlindIndex(indexPhoneLast4, digits[len(digits)-4:])
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, value)
+}
@@ -3,25 +3,33 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
-	"github.com/jmoiron/sqlx"
+	"github.com/eduardohass/kids-api/internal/tenant"
 )
 
 type CaretakerRepository interface {
 	Create(ctx context.Context, caretaker *models.Caretaker) error
 	GetByID(ctx context.Context, id string) (*models.Caretaker, error)
+	GetByAuth0ID(ctx context.Context, auth0ID string) (*models.Caretaker, error)
 	Update(ctx context.Context, caretaker *models.Caretaker) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string, deletedBefore time.Time) error
 	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Caretaker, error)
+	CanPickup(ctx context.Context, childID, caretakerID string) (bool, error)
+	ListContacts(ctx context.Context, childID string) ([]*models.Caretaker, error)
 }
 
 type caretakerRepository struct {
-	db *sqlx.DB
+	db *tenant.DB
 }
 
-func NewCaretakerRepository(db *sqlx.DB) CaretakerRepository {
+func NewCaretakerRepository(db *tenant.DB) CaretakerRepository {
 	return &caretakerRepository{db: db}
 }
 
@@ -31,9 +39,12 @@ func (r *caretakerRepository) Create(ctx context.Context, caretaker *models.Care
 			name,
 			email,
 			phone,
-			address
-		) VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at, updated_at
+			address,
+			email_index,
+			phone_index,
+			phone_last4_index
+		) VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''))
+		RETURNING id, version, created_at, updated_at
 	`
 
 	return r.db.QueryRowxContext(
@@ -43,23 +54,49 @@ func (r *caretakerRepository) Create(ctx context.Context, caretaker *models.Care
 		caretaker.Email,
 		caretaker.Phone,
 		caretaker.Address,
-	).Scan(&caretaker.ID, &caretaker.CreatedAt, &caretaker.UpdatedAt)
+		emailIndex(string(caretaker.Email)),
+		phoneIndex(string(caretaker.Phone)),
+		phoneLast4Index(string(caretaker.Phone)),
+	).Scan(&caretaker.ID, &caretaker.Version, &caretaker.CreatedAt, &caretaker.UpdatedAt)
 }
 
 func (r *caretakerRepository) GetByID(ctx context.Context, id string) (*models.Caretaker, error) {
 	const query = `
-		SELECT id, name, email, phone, address, created_at, updated_at
+		SELECT id, name, email, phone, address, version, created_at, updated_at
 		FROM caretakers
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var caretaker models.Caretaker
 	if err := r.db.GetContext(ctx, &caretaker, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
 		return nil, fmt.Errorf("caretakerRepository.GetByID: %w", err)
 	}
 	return &caretaker, nil
 }
 
+// GetByAuth0ID busca o responsável vinculado ao usuário do Auth0 (claim "sub").
+func (r *caretakerRepository) GetByAuth0ID(ctx context.Context, auth0ID string) (*models.Caretaker, error) {
+	const query = `
+		SELECT id, name, email, phone, address, version, created_at, updated_at
+		FROM caretakers
+		WHERE auth0_id = $1 AND deleted_at IS NULL
+	`
+
+	var caretaker models.Caretaker
+	if err := r.db.GetContext(ctx, &caretaker, query, auth0ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("caretakerRepository.GetByAuth0ID: %w", err)
+	}
+	return &caretaker, nil
+}
+
+// Update grava as alterações usando controle de concorrência otimista: quando
+// caretaker.Version é informado, a atualização só ocorre se a versão persistida for a mesma.
 func (r *caretakerRepository) Update(ctx context.Context, caretaker *models.Caretaker) error {
 	const query = `
 		UPDATE caretakers SET
@@ -67,27 +104,49 @@ func (r *caretakerRepository) Update(ctx context.Context, caretaker *models.Care
 			email = $2,
 			phone = $3,
 			address = $4,
+			email_index = NULLIF($5, ''),
+			phone_index = NULLIF($6, ''),
+			phone_last4_index = NULLIF($7, ''),
+			version = version + 1,
 			updated_at = NOW()
-		WHERE id = $5
-		RETURNING updated_at
+		WHERE id = $8 AND deleted_at IS NULL AND ($9 = 0 OR version = $9)
+		RETURNING version, updated_at
 	`
 
-	result, err := r.db.ExecContext(
+	err := r.db.QueryRowxContext(
 		ctx,
 		query,
 		caretaker.Name,
 		caretaker.Email,
 		caretaker.Phone,
 		caretaker.Address,
+		emailIndex(string(caretaker.Email)),
+		phoneIndex(string(caretaker.Phone)),
+		phoneLast4Index(string(caretaker.Phone)),
 		caretaker.ID,
-	)
+		caretaker.Version,
+	).Scan(&caretaker.Version, &caretaker.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "caretakers", caretaker.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("caretakerRepository.Update: %w", err)
 	}
 
+	return nil
+}
+
+func (r *caretakerRepository) Delete(ctx context.Context, id string) error {
+	const query = `UPDATE caretakers SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("caretakerRepository.Delete: %w", err)
+	}
+
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("caretakerRepository.Update: %w", err)
+		return fmt.Errorf("caretakerRepository.Delete: %w", err)
 	}
 
 	if rows == 0 {
@@ -97,17 +156,18 @@ func (r *caretakerRepository) Update(ctx context.Context, caretaker *models.Care
 	return nil
 }
 
-func (r *caretakerRepository) Delete(ctx context.Context, id string) error {
-	const query = `DELETE FROM caretakers WHERE id = $1`
+// Restore desfaz a exclusão lógica de um registro.
+func (r *caretakerRepository) Restore(ctx context.Context, id string) error {
+	const query = `UPDATE caretakers SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("caretakerRepository.Delete: %w", err)
+		return fmt.Errorf("caretakerRepository.Restore: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("caretakerRepository.Delete: %w", err)
+		return fmt.Errorf("caretakerRepository.Restore: %w", err)
 	}
 
 	if rows == 0 {
@@ -117,10 +177,16 @@ func (r *caretakerRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Purge remove fisicamente um registro excluído logicamente antes de deletedBefore.
+func (r *caretakerRepository) Purge(ctx context.Context, id string, deletedBefore time.Time) error {
+	return purge(ctx, r.db, "caretakers", id, deletedBefore)
+}
+
 func (r *caretakerRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Caretaker, error) {
 	const query = `
-		SELECT id, name, email, phone, address, created_at, updated_at
+		SELECT id, name, email, phone, address, version, created_at, updated_at
 		FROM caretakers
+		WHERE deleted_at IS NULL
 		LIMIT $1 OFFSET $2
 	`
 
@@ -131,3 +197,48 @@ func (r *caretakerRepository) List(ctx context.Context, filter map[string]interf
 
 	return caretakers, nil
 }
+
+// CanPickup informa se o responsável está vinculado à criança com permissão de retirada.
+func (r *caretakerRepository) CanPickup(ctx context.Context, childID, caretakerID string) (bool, error) {
+	const query = `
+		SELECT EXISTS(
+			SELECT 1
+			FROM children_caretakers cc
+			INNER JOIN caretakers ct ON ct.id = cc.responsavel_id
+			WHERE cc.crianca_id = $1
+				AND cc.responsavel_id = $2
+				AND cc.pode_retirar
+				AND ct.deleted_at IS NULL
+		)
+	`
+
+	var allowed bool
+	if err := r.db.GetContext(ctx, &allowed, query, childID, caretakerID); err != nil {
+		return false, fmt.Errorf("caretakerRepository.CanPickup: %w", err)
+	}
+	return allowed, nil
+}
+
+// ListContacts retorna os responsáveis vinculados à criança em ordem de
+// preferência para contato: o contato principal da família, depois quem pode
+// retirar a criança e, por fim, os demais.
+func (r *caretakerRepository) ListContacts(ctx context.Context, childID string) ([]*models.Caretaker, error) {
+	const query = `
+		SELECT ct.id, ct.name, ct.email, ct.phone, ct.address, ct.version, ct.created_at, ct.updated_at
+		FROM children_caretakers cc
+		INNER JOIN caretakers ct ON ct.id = cc.responsavel_id
+		INNER JOIN children c ON c.id = cc.crianca_id
+		LEFT JOIN households h ON h.id = c.household_id
+		WHERE cc.crianca_id = $1 AND ct.deleted_at IS NULL
+		ORDER BY
+			(h.primary_contact_id = ct.id) IS TRUE DESC,
+			cc.pode_retirar DESC,
+			cc.criado_em
+	`
+
+	var caretakers []*models.Caretaker
+	if err := r.db.SelectContext(ctx, &caretakers, query, childID); err != nil {
+		return nil, fmt.Errorf("caretakerRepository.ListContacts: %w", err)
+	}
+	return caretakers, nil
+}
@@ -6,29 +6,46 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // ErrNotFound is returned when a requested entity is not found in the database.
 var ErrNotFound = errors.New("child not found")
 
+// ErrPurgeNotAllowed is returned when a hard delete is requested for an entity
+// that is not soft-deleted or is still inside its retention period.
+var ErrPurgeNotAllowed = errors.New("entity is not eligible for purge")
+
+// ErrVersionConflict is returned when an optimistic update targets a version
+// that no longer matches the persisted one.
+var ErrVersionConflict = errors.New("entity was modified by another request")
+
 type ChildRepository interface {
 	Create(ctx context.Context, child *models.Child) error
 	GetByID(ctx context.Context, id string) (*models.Child, error)
 	Update(ctx context.Context, child *models.Child) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string, deletedBefore time.Time) error
 	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Child, error)
 	AssociateNeed(ctx context.Context, childID, needID string) error
 	AssociateAllergy(ctx context.Context, childID, allergyID string) error
+	DissociateNeed(ctx context.Context, childID, needID string) error
+	DissociateAllergy(ctx context.Context, childID, allergyID string) error
+	ListInactive(ctx context.Context, lastSeenBefore time.Time, withPhoto bool, limit, offset int) ([]string, error)
+	ClearPhotos(ctx context.Context, ids []string) ([]string, error)
 }
 
 type childRepository struct {
-	db *sqlx.DB
+	db *tenant.DB
 }
 
-func NewChildRepository(db *sqlx.DB) ChildRepository {
+func NewChildRepository(db *tenant.DB) ChildRepository {
 	return &childRepository{db: db}
 }
 
@@ -40,8 +57,8 @@ func (r *childRepository) Create(ctx context.Context, child *models.Child) error
 			gender, 
 			photo_url, 
 			group_id
-		) VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at, updated_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, version, created_at, updated_at
 	`
 
 	err := r.db.QueryRowxContext(
@@ -52,7 +69,7 @@ func (r *childRepository) Create(ctx context.Context, child *models.Child) error
 		child.Gender,
 		child.PhotoURL,
 		child.GroupID,
-	).Scan(&child.ID, &child.CreatedAt, &child.UpdatedAt)
+	).Scan(&child.ID, &child.Version, &child.CreatedAt, &child.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("childRepository.Create: %w", err)
@@ -72,12 +89,13 @@ func (r *childRepository) GetByID(ctx context.Context, id string) (*models.Child
 			name, 
 			birth_date, 
 			gender, 
-			photo_url, 
+			COALESCE(photo_url, '') AS photo_url,
 			group_id, 
+			version,
 			created_at, 
 			updated_at
 		FROM children
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var child models.Child
@@ -95,20 +113,23 @@ func (r *childRepository) GetByID(ctx context.Context, id string) (*models.Child
 	return &child, nil
 }
 
+// Update grava as alterações usando controle de concorrência otimista: quando
+// child.Version é informado, a atualização só ocorre se a versão persistida for a mesma.
 func (r *childRepository) Update(ctx context.Context, child *models.Child) error {
 	const query = `
 		UPDATE children SET
 			name = $1,
 			birth_date = $2,
 			gender = $3,
-			photo_url = $4,
+			photo_url = NULLIF($4, ''),
 			group_id = $5,
+			version = version + 1,
 			updated_at = NOW()
-		WHERE id = $6
-		RETURNING updated_at
+		WHERE id = $6 AND deleted_at IS NULL AND ($7 = 0 OR version = $7)
+		RETURNING version, updated_at
 	`
 
-	if _, err := r.db.ExecContext(
+	err := r.db.QueryRowxContext(
 		ctx,
 		query,
 		child.Name,
@@ -117,7 +138,12 @@ func (r *childRepository) Update(ctx context.Context, child *models.Child) error
 		child.PhotoURL,
 		child.GroupID,
 		child.ID,
-	); err != nil {
+		child.Version,
+	).Scan(&child.Version, &child.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "children", child.ID)
+	}
+	if err != nil {
 		return fmt.Errorf("childRepository.Update: %w", err)
 	}
 
@@ -129,7 +155,7 @@ func (r *childRepository) Update(ctx context.Context, child *models.Child) error
 }
 
 func (r *childRepository) Delete(ctx context.Context, id string) error {
-	const query = `DELETE FROM children WHERE id = $1`
+	const query = `UPDATE children SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -143,6 +169,72 @@ func (r *childRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Restore desfaz a exclusão lógica de uma criança.
+func (r *childRepository) Restore(ctx context.Context, id string) error {
+	const query = `UPDATE children SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("childRepository.Restore: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Purge remove fisicamente uma criança excluída logicamente antes de deletedBefore.
+func (r *childRepository) Purge(ctx context.Context, id string, deletedBefore time.Time) error {
+	return purge(ctx, r.db, "children", id, deletedBefore)
+}
+
+// ListInactive retorna as crianças ainda não anonimizadas cuja última presença
+// (ou o cadastro, para quem nunca compareceu) é anterior a lastSeenBefore e que
+// não estão presentes agora. withPhoto restringe às que ainda têm foto.
+func (r *childRepository) ListInactive(ctx context.Context, lastSeenBefore time.Time, withPhoto bool, limit, offset int) ([]string, error) {
+	const query = `
+		SELECT c.id
+		FROM children c
+		WHERE c.name <> $1
+			AND ($2 = FALSE OR c.photo_url IS NOT NULL)
+			AND COALESCE(
+				(SELECT MAX(a.checked_in_at) FROM attendance a WHERE a.child_id = c.id),
+				c.created_at
+			) < $3
+			AND NOT EXISTS (
+				SELECT 1 FROM attendance a WHERE a.child_id = c.id AND a.checked_out_at IS NULL
+			)
+		ORDER BY c.created_at, c.id
+		LIMIT $4 OFFSET $5
+	`
+
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, query, AnonymizedName, withPhoto, lastSeenBefore, limit, offset); err != nil {
+		return nil, fmt.Errorf("childRepository.ListInactive: %w", err)
+	}
+	return ids, nil
+}
+
+// ClearPhotos remove a referência à foto das crianças. A API não hospeda as
+// fotos: o arquivo apontado por photo_url continua no serviço de hospedagem e
+// precisa ser apagado lá.
+func (r *childRepository) ClearPhotos(ctx context.Context, ids []string) ([]string, error) {
+	const query = `
+		UPDATE children
+		SET photo_url = NULL, updated_at = NOW()
+		WHERE id = ANY($1::uuid[]) AND photo_url IS NOT NULL
+		RETURNING id
+	`
+
+	var cleared []string
+	if err := r.db.SelectContext(ctx, &cleared, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("childRepository.ClearPhotos: %w", err)
+	}
+	return cleared, nil
+}
+
 // Adição da implementação do método List para satisfazer a interface
 func (r *childRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Child, error) {
 	const query = `
@@ -151,11 +243,13 @@ func (r *childRepository) List(ctx context.Context, filter map[string]interface{
 			name, 
 			birth_date, 
 			gender, 
-			photo_url, 
+			COALESCE(photo_url, '') AS photo_url,
 			group_id, 
+			version,
 			created_at, 
 			updated_at
 		FROM children
+		WHERE deleted_at IS NULL
 		LIMIT $1 OFFSET $2
 	`
 	var children []*models.Child
@@ -218,8 +312,8 @@ func (r *childRepository) loadNeeds(ctx context.Context, child *models.Child) er
 			n.created_at,
 			n.updated_at
 		FROM needs n
-		INNER JOIN child_needs cn ON n.id = cn.need_id
-		WHERE cn.child_id = $1
+		INNER JOIN children_needs cn ON n.id = cn.necessidade_id
+		WHERE cn.crianca_id = $1
 	`
 	return r.db.SelectContext(ctx, &child.Needs, query, child.ID)
 }
@@ -234,8 +328,8 @@ func (r *childRepository) loadAllergies(ctx context.Context, child *models.Child
 			a.created_at,
 			a.updated_at
 		FROM allergies a
-		INNER JOIN child_allergies ca ON a.id = ca.allergy_id
-		WHERE ca.child_id = $1
+		INNER JOIN children_allergies ca ON a.id = ca.alergia_id
+		WHERE ca.crianca_id = $1
 	`
 	return r.db.SelectContext(ctx, &child.Allergies, query, child.ID)
 }
@@ -253,9 +347,9 @@ func (r *childRepository) AssociateNeed(ctx context.Context, childID, needID str
 
 func (r *childRepository) associateNeed(ctx context.Context, exec sqlx.ExecerContext, childID, needID string) error {
 	const query = `
-		INSERT INTO child_needs (child_id, need_id)
+		INSERT INTO children_needs (crianca_id, necessidade_id)
 		VALUES ($1, $2)
-		ON CONFLICT (child_id, need_id) DO NOTHING
+		ON CONFLICT (crianca_id, necessidade_id) DO NOTHING
 	`
 	_, err := exec.ExecContext(ctx, query, childID, needID)
 	return err
@@ -267,10 +361,40 @@ func (r *childRepository) AssociateAllergy(ctx context.Context, childID, allergy
 
 func (r *childRepository) associateAllergy(ctx context.Context, exec sqlx.ExecerContext, childID, allergyID string) error {
 	const query = `
-		INSERT INTO child_allergies (child_id, allergy_id)
+		INSERT INTO children_allergies (crianca_id, alergia_id)
 		VALUES ($1, $2)
-		ON CONFLICT (child_id, allergy_id) DO NOTHING
+		ON CONFLICT (crianca_id, alergia_id) DO NOTHING
 	`
 	_, err := exec.ExecContext(ctx, query, childID, allergyID)
 	return err
 }
+
+func (r *childRepository) DissociateNeed(ctx context.Context, childID, needID string) error {
+	const query = `DELETE FROM children_needs WHERE crianca_id = $1 AND necessidade_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, childID, needID)
+	if err != nil {
+		return fmt.Errorf("childRepository.DissociateNeed: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *childRepository) DissociateAllergy(ctx context.Context, childID, allergyID string) error {
+	const query = `DELETE FROM children_allergies WHERE crianca_id = $1 AND alergia_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, childID, allergyID)
+	if err != nil {
+		return fmt.Errorf("childRepository.DissociateAllergy: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+	"github.com/lib/pq"
+)
+
+// ErrConsentRevoked is returned when revoking a consent that was already revoked.
+var ErrConsentRevoked = errors.New("consent is already revoked")
+
+type ConsentRepository interface {
+	CreateDocument(ctx context.Context, document *models.ConsentDocument) error
+	GetDocument(ctx context.Context, id string) (*models.ConsentDocument, error)
+	ListDocuments(ctx context.Context, kind string) ([]*models.ConsentDocument, error)
+	CurrentDocuments(ctx context.Context) ([]*models.ConsentDocument, error)
+	CreateRecord(ctx context.Context, record *models.ConsentRecord) error
+	GetRecord(ctx context.Context, id string) (*models.ConsentRecord, error)
+	ListRecords(ctx context.Context, childIDs []string) ([]*models.ConsentRecord, error)
+	RevokeRecord(ctx context.Context, record *models.ConsentRecord) error
+}
+
+type consentRepository struct {
+	db *tenant.DB
+}
+
+func NewConsentRepository(db *tenant.DB) ConsentRepository {
+	return &consentRepository{db: db}
+}
+
+const consentDocumentColumns = `id, kind, version, title, body, required, published_by, published_at`
+
+const consentRecordColumns = `
+	r.id,
+	r.document_id,
+	d.kind,
+	d.version AS document_version,
+	r.child_id,
+	COALESCE(r.caretaker_id::text, '') AS caretaker_id,
+	r.granted,
+	r.accepted_at,
+	COALESCE(r.ip_address, '') AS ip_address,
+	COALESCE(r.device, '') AS device,
+	r.recorded_by,
+	r.revoked_at,
+	COALESCE(r.revoked_by, '') AS revoked_by,
+	COALESCE(r.revocation_reason, '') AS revocation_reason
+`
+
+// CreateDocument publica uma nova versão do termo, numerada a partir da última do mesmo tipo.
+func (r *consentRepository) CreateDocument(ctx context.Context, document *models.ConsentDocument) error {
+	const query = `
+		INSERT INTO consent_documents (kind, version, title, body, required, published_by)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5
+		FROM consent_documents
+		WHERE kind = $1
+		RETURNING id, version, published_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		document.Kind,
+		document.Title,
+		document.Body,
+		document.Required,
+		document.PublishedBy,
+	).Scan(&document.ID, &document.Version, &document.PublishedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrVersionConflict
+		}
+		return fmt.Errorf("consentRepository.CreateDocument: %w", err)
+	}
+	return nil
+}
+
+func (r *consentRepository) GetDocument(ctx context.Context, id string) (*models.ConsentDocument, error) {
+	query := `SELECT ` + consentDocumentColumns + ` FROM consent_documents WHERE id = $1`
+
+	var document models.ConsentDocument
+	if err := r.db.GetContext(ctx, &document, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("consentRepository.GetDocument: %w", err)
+	}
+	return &document, nil
+}
+
+// ListDocuments retorna todas as versões, da mais recente para a mais antiga;
+// kind vazio lista todos os tipos.
+func (r *consentRepository) ListDocuments(ctx context.Context, kind string) ([]*models.ConsentDocument, error) {
+	query := `SELECT ` + consentDocumentColumns + `
+		FROM consent_documents
+		WHERE ($1 = '' OR kind = $1)
+		ORDER BY kind, version DESC`
+
+	var documents []*models.ConsentDocument
+	if err := r.db.SelectContext(ctx, &documents, query, kind); err != nil {
+		return nil, fmt.Errorf("consentRepository.ListDocuments: %w", err)
+	}
+	return documents, nil
+}
+
+// CurrentDocuments retorna a versão vigente de cada tipo de termo.
+func (r *consentRepository) CurrentDocuments(ctx context.Context) ([]*models.ConsentDocument, error) {
+	query := `SELECT DISTINCT ON (kind) ` + consentDocumentColumns + `
+		FROM consent_documents
+		ORDER BY kind, version DESC`
+
+	var documents []*models.ConsentDocument
+	if err := r.db.SelectContext(ctx, &documents, query); err != nil {
+		return nil, fmt.Errorf("consentRepository.CurrentDocuments: %w", err)
+	}
+	return documents, nil
+}
+
+func (r *consentRepository) CreateRecord(ctx context.Context, record *models.ConsentRecord) error {
+	const query = `
+		INSERT INTO consent_records (
+			document_id,
+			child_id,
+			caretaker_id,
+			granted,
+			ip_address,
+			device,
+			recorded_by
+		) VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7)
+		RETURNING id, accepted_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		record.DocumentID,
+		record.ChildID,
+		record.CaretakerID,
+		record.Granted,
+		record.IPAddress,
+		record.Device,
+		record.RecordedBy,
+	).Scan(&record.ID, &record.AcceptedAt)
+	if err != nil {
+		return fmt.Errorf("consentRepository.CreateRecord: %w", err)
+	}
+	return nil
+}
+
+func (r *consentRepository) GetRecord(ctx context.Context, id string) (*models.ConsentRecord, error) {
+	query := `SELECT ` + consentRecordColumns + `
+		FROM consent_records r
+		INNER JOIN consent_documents d ON d.id = r.document_id
+		WHERE r.id = $1`
+
+	var record models.ConsentRecord
+	if err := r.db.GetContext(ctx, &record, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("consentRepository.GetRecord: %w", err)
+	}
+	return &record, nil
+}
+
+// ListRecords retorna o histórico de consentimentos das crianças, do mais recente ao mais antigo.
+func (r *consentRepository) ListRecords(ctx context.Context, childIDs []string) ([]*models.ConsentRecord, error) {
+	query := `SELECT ` + consentRecordColumns + `
+		FROM consent_records r
+		INNER JOIN consent_documents d ON d.id = r.document_id
+		WHERE r.child_id = ANY($1::uuid[])
+		ORDER BY r.accepted_at DESC`
+
+	var records []*models.ConsentRecord
+	if err := r.db.SelectContext(ctx, &records, query, pq.Array(childIDs)); err != nil {
+		return nil, fmt.Errorf("consentRepository.ListRecords: %w", err)
+	}
+	return records, nil
+}
+
+func (r *consentRepository) RevokeRecord(ctx context.Context, record *models.ConsentRecord) error {
+	const query = `
+		UPDATE consent_records
+		SET revoked_at = NOW(), revoked_by = $1, revocation_reason = NULLIF($2, '')
+		WHERE id = $3 AND revoked_at IS NULL
+		RETURNING revoked_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, record.RevokedBy, record.RevocationReason, record.ID).Scan(&record.RevokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrConsentRevoked
+	}
+	if err != nil {
+		return fmt.Errorf("consentRepository.RevokeRecord: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+	"github.com/lib/pq"
+)
+
+// AnonymizedName substitui os nomes de responsáveis e crianças anonimizados.
+const AnonymizedName = "Anonimizado"
+
+// RedactedText substitui o texto livre obrigatório dos registros mantidos
+// após a anonimização (descrição das ocorrências).
+const RedactedText = "[removido]"
+
+// DataSubjectRepository persiste os pedidos do titular (LGPD) e executa a
+// anonimização dos dados.
+type DataSubjectRepository interface {
+	Create(ctx context.Context, request *models.DataSubjectRequest) error
+	GetByID(ctx context.Context, id string) (*models.DataSubjectRequest, error)
+	List(ctx context.Context, caretakerID string, limit, offset int) ([]*models.DataSubjectRequest, error)
+	Start(ctx context.Context, id string) error
+	Complete(ctx context.Context, id string, result []byte, archive []byte) error
+	Fail(ctx context.Context, id, lastError string) error
+	GetArchive(ctx context.Context, id string) ([]byte, error)
+	ListLinkedChildren(ctx context.Context, caretakerID string) ([]models.LinkedChild, error)
+	ListNotifications(ctx context.Context, caretakerID string, childIDs []string) ([]*models.Notification, error)
+	Erase(ctx context.Context, caretakerID string, childIDs []string) error
+	AnonymizeChildren(ctx context.Context, childIDs []string) error
+}
+
+type dataSubjectRepository struct {
+	db *tenant.DB
+}
+
+func NewDataSubjectRepository(db *tenant.DB) DataSubjectRepository {
+	return &dataSubjectRepository{db: db}
+}
+
+const dataSubjectRequestColumns = `
+	id,
+	COALESCE(caretaker_id::text, '') AS caretaker_id,
+	type,
+	status,
+	COALESCE(reason, '') AS reason,
+	requested_by,
+	result,
+	archive IS NOT NULL AS has_archive,
+	COALESCE(last_error, '') AS last_error,
+	created_at,
+	started_at,
+	completed_at
+`
+
+func (r *dataSubjectRepository) Create(ctx context.Context, request *models.DataSubjectRequest) error {
+	const query = `
+		INSERT INTO data_subject_requests (caretaker_id, type, reason, requested_by)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING id, status, created_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		request.CaretakerID,
+		request.Type,
+		request.Reason,
+		request.RequestedBy,
+	).Scan(&request.ID, &request.Status, &request.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("dataSubjectRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *dataSubjectRepository) GetByID(ctx context.Context, id string) (*models.DataSubjectRequest, error) {
+	query := `SELECT ` + dataSubjectRequestColumns + ` FROM data_subject_requests WHERE id = $1`
+
+	var request models.DataSubjectRequest
+	if err := r.db.GetContext(ctx, &request, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("dataSubjectRepository.GetByID: %w", err)
+	}
+	return &request, nil
+}
+
+// List retorna os pedidos mais recentes; caretakerID vazio lista todos.
+func (r *dataSubjectRepository) List(ctx context.Context, caretakerID string, limit, offset int) ([]*models.DataSubjectRequest, error) {
+	query := `SELECT ` + dataSubjectRequestColumns + `
+		FROM data_subject_requests
+		WHERE ($1 = '' OR caretaker_id::text = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var requests []*models.DataSubjectRequest
+	if err := r.db.SelectContext(ctx, &requests, query, caretakerID, limit, offset); err != nil {
+		return nil, fmt.Errorf("dataSubjectRepository.List: %w", err)
+	}
+	return requests, nil
+}
+
+// Start marca o pedido como em processamento. Pedidos já concluídos não são
+// reprocessados quando o trabalho da fila é repetido.
+func (r *dataSubjectRepository) Start(ctx context.Context, id string) error {
+	const query = `
+		UPDATE data_subject_requests
+		SET status = 'processing', started_at = COALESCE(started_at, NOW()), last_error = NULL
+		WHERE id = $1 AND status <> 'completed'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("dataSubjectRepository.Start: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("dataSubjectRepository.Start: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *dataSubjectRepository) Complete(ctx context.Context, id string, result []byte, archive []byte) error {
+	const query = `
+		UPDATE data_subject_requests
+		SET status = 'completed', result = $1, archive = $2, last_error = NULL, completed_at = NOW()
+		WHERE id = $3
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, result, archive, id); err != nil {
+		return fmt.Errorf("dataSubjectRepository.Complete: %w", err)
+	}
+	return nil
+}
+
+func (r *dataSubjectRepository) Fail(ctx context.Context, id, lastError string) error {
+	const query = `UPDATE data_subject_requests SET status = 'failed', last_error = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, id); err != nil {
+		return fmt.Errorf("dataSubjectRepository.Fail: %w", err)
+	}
+	return nil
+}
+
+func (r *dataSubjectRepository) GetArchive(ctx context.Context, id string) ([]byte, error) {
+	const query = `SELECT archive FROM data_subject_requests WHERE id = $1 AND archive IS NOT NULL`
+
+	var archive []byte
+	if err := r.db.GetContext(ctx, &archive, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("dataSubjectRepository.GetArchive: %w", err)
+	}
+	return archive, nil
+}
+
+// ListLinkedChildren retorna as crianças vinculadas ao responsável e quantos
+// outros responsáveis ativos cada uma tem.
+func (r *dataSubjectRepository) ListLinkedChildren(ctx context.Context, caretakerID string) ([]models.LinkedChild, error) {
+	const query = `
+		SELECT
+			cc.crianca_id AS child_id,
+			(
+				SELECT COUNT(*)
+				FROM children_caretakers other
+				INNER JOIN caretakers ct ON ct.id = other.responsavel_id
+				WHERE other.crianca_id = cc.crianca_id
+					AND other.responsavel_id <> cc.responsavel_id
+					AND ct.deleted_at IS NULL
+			) AS other_caretakers
+		FROM children_caretakers cc
+		WHERE cc.responsavel_id = $1
+		ORDER BY cc.criado_em
+	`
+
+	var children []models.LinkedChild
+	if err := r.db.SelectContext(ctx, &children, query, caretakerID); err != nil {
+		return nil, fmt.Errorf("dataSubjectRepository.ListLinkedChildren: %w", err)
+	}
+	return children, nil
+}
+
+// ListNotifications retorna as mensagens enviadas ao responsável ou sobre as crianças.
+func (r *dataSubjectRepository) ListNotifications(ctx context.Context, caretakerID string, childIDs []string) ([]*models.Notification, error) {
+	const query = `
+		SELECT
+			id,
+			COALESCE(attendance_id::text, '') AS attendance_id,
+			child_id,
+			COALESCE(caretaker_id::text, '') AS caretaker_id,
+			channel,
+			recipient,
+			reason,
+			COALESCE(subject, '') AS subject,
+			body,
+			status,
+			attempts,
+			COALESCE(last_error, '') AS last_error,
+			COALESCE(sent_by, '') AS sent_by,
+			sent_at,
+			created_at,
+			updated_at
+		FROM notifications
+		WHERE caretaker_id = $1 OR child_id = ANY($2::uuid[])
+		ORDER BY created_at
+	`
+
+	var notifications []*models.Notification
+	if err := r.db.SelectContext(ctx, &notifications, query, caretakerID, pq.Array(childIDs)); err != nil {
+		return nil, fmt.Errorf("dataSubjectRepository.ListNotifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// erasureStep é um comando da anonimização.
+type erasureStep struct {
+	query string
+	args  []interface{}
+}
+
+// Erase anonimiza, em uma única transação, o responsável e as crianças
+// informadas. Presenças, medicamentos e ocorrências são mantidos (estatísticas
+// e obrigação legal), mas passam a apontar para registros sem dados pessoais e
+// perdem o texto livre, que pode citar a criança, a família ou testemunhas.
+func (r *dataSubjectRepository) Erase(ctx context.Context, caretakerID string, childIDs []string) error {
+	steps := append(childErasureSteps(childIDs), caretakerErasureSteps(caretakerID)...)
+	if err := r.execSteps(ctx, steps); err != nil {
+		return fmt.Errorf("dataSubjectRepository.Erase: %w", err)
+	}
+	return nil
+}
+
+// AnonymizeChildren anonimiza as crianças informadas, em uma única transação,
+// e desfaz os vínculos com os responsáveis, que são mantidos.
+func (r *dataSubjectRepository) AnonymizeChildren(ctx context.Context, childIDs []string) error {
+	steps := append(childErasureSteps(childIDs), erasureStep{
+		`DELETE FROM children_caretakers WHERE crianca_id = ANY($1::uuid[])`, []interface{}{pq.Array(childIDs)},
+	})
+	if err := r.execSteps(ctx, steps); err != nil {
+		return fmt.Errorf("dataSubjectRepository.AnonymizeChildren: %w", err)
+	}
+	return nil
+}
+
+// childErasureSteps remove os dados pessoais das crianças.
+func childErasureSteps(childIDs []string) []erasureStep {
+	children := pq.Array(childIDs)
+	return []erasureStep{
+		// Mantém sexo e ano de nascimento para as estatísticas por faixa etária
+		{`UPDATE children
+			SET name = $2,
+				photo_url = NULL,
+				birth_date = date_trunc('year', birth_date)::date,
+				deleted_at = COALESCE(deleted_at, NOW()),
+				updated_at = NOW()
+			WHERE id = ANY($1::uuid[])`, []interface{}{children, AnonymizedName}},
+		{`DELETE FROM children_needs WHERE crianca_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`DELETE FROM children_allergies WHERE crianca_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`DELETE FROM child_emergency_contacts WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`DELETE FROM child_medical_profiles WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`DELETE FROM child_restrictions WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`DELETE FROM pickup_grants WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		// Os aceites são mantidos como prova do consentimento, sem a origem
+		{`UPDATE consent_records SET ip_address = NULL, device = NULL WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`UPDATE attendance SET released_to_name = NULL WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`UPDATE notifications SET recipient = '', subject = NULL, body = '' WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		// Ocorrências ficam com tipo, data e fluxo de revisão
+		{`UPDATE incidents
+			SET description = $2, first_aid = NULL, witnesses = '{}', review_notes = NULL, updated_at = NOW()
+			WHERE child_id = ANY($1::uuid[])`, []interface{}{children, RedactedText}},
+		// Medicamento, dose e horários continuam como registro do que foi dado;
+		// a autorização assinada é mantida como prova, assim como os aceites
+		{`UPDATE medication_orders SET instructions = NULL, updated_at = NOW() WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		{`UPDATE medication_administrations SET notes = NULL WHERE child_id = ANY($1::uuid[])`, []interface{}{children}},
+		// Auditoria: o nome de quem buscou a criança sai dos detalhes
+		{`UPDATE audit_log
+			SET details = details - 'pickup_name'
+			WHERE details ? 'pickup_name'
+				AND ((entity_type = 'child' AND entity_id = ANY($1::uuid[]))
+					OR (entity_type = 'attendance' AND entity_id IN (
+						SELECT id FROM attendance WHERE child_id = ANY($1::uuid[])
+					)))`, []interface{}{children}},
+	}
+}
+
+// caretakerErasureSteps remove os dados pessoais do responsável.
+func caretakerErasureSteps(caretakerID string) []erasureStep {
+	return []erasureStep{
+		// Família: só é anonimizada quando não resta outro responsável ativo
+		{`UPDATE households h
+			SET name = $2, address = NULL, notes = NULL, primary_contact_id = NULL, updated_at = NOW()
+			WHERE h.id = (SELECT household_id FROM caretakers WHERE id = $1)
+				AND NOT EXISTS (
+					SELECT 1 FROM caretakers o
+					WHERE o.household_id = h.id AND o.id <> $1 AND o.deleted_at IS NULL
+				)`, []interface{}{caretakerID, AnonymizedName}},
+		{`UPDATE households SET primary_contact_id = NULL WHERE primary_contact_id = $1`, []interface{}{caretakerID}},
+		{`DELETE FROM child_emergency_contacts WHERE caretaker_id = $1`, []interface{}{caretakerID}},
+		{`DELETE FROM pickup_grants WHERE granted_by_caretaker_id = $1`, []interface{}{caretakerID}},
+		{`UPDATE consent_records SET ip_address = NULL, device = NULL WHERE caretaker_id = $1`, []interface{}{caretakerID}},
+		{`UPDATE notifications SET recipient = '', subject = NULL, body = '' WHERE caretaker_id = $1`, []interface{}{caretakerID}},
+		{`DELETE FROM children_caretakers WHERE responsavel_id = $1`, []interface{}{caretakerID}},
+		{`UPDATE precheckin_redemptions SET caretaker_id = NULL WHERE caretaker_id = $1`, []interface{}{caretakerID}},
+		{`UPDATE caretakers
+			SET name = $2,
+				email = NULL,
+				phone = NULL,
+				phone_type = NULL,
+				address = NULL,
+				auth0_id = NULL,
+				email_index = NULL,
+				phone_index = NULL,
+				phone_last4_index = NULL,
+				deleted_at = COALESCE(deleted_at, NOW())
+			WHERE id = $1`, []interface{}{caretakerID, AnonymizedName}},
+	}
+}
+
+// execSteps executa os comandos em uma única transação.
+func (r *dataSubjectRepository) execSteps(ctx context.Context, steps []erasureStep) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, step := range steps {
+		if _, err := tx.ExecContext(ctx, step.query, step.args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// EncryptionKeyRepository persiste as chaves de dados, sempre cifradas pela
+// chave mestra. A tabela é global: as chaves valem para todos os tenants.
+type EncryptionKeyRepository interface {
+	List(ctx context.Context) ([]*models.EncryptionKey, error)
+	Create(ctx context.Context, key *models.EncryptionKey) error
+	Rewrap(ctx context.Context, key *models.EncryptionKey) error
+}
+
+type encryptionKeyRepository struct {
+	db *tenant.DB
+}
+
+func NewEncryptionKeyRepository(db *tenant.DB) EncryptionKeyRepository {
+	return &encryptionKeyRepository{db: db}
+}
+
+func (r *encryptionKeyRepository) List(ctx context.Context) ([]*models.EncryptionKey, error) {
+	const query = `
+		SELECT id, wrapped_key, master_key_id, active, created_at
+		FROM encryption_keys
+		ORDER BY id
+	`
+
+	var keys []*models.EncryptionKey
+	if err := r.db.SelectContext(ctx, &keys, query); err != nil {
+		return nil, fmt.Errorf("encryptionKeyRepository.List: %w", err)
+	}
+	return keys, nil
+}
+
+// Create grava a chave como a nova chave ativa, desativando a anterior na
+// mesma transação.
+func (r *encryptionKeyRepository) Create(ctx context.Context, key *models.EncryptionKey) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("encryptionKeyRepository.Create: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE encryption_keys SET active = FALSE WHERE active`); err != nil {
+		return fmt.Errorf("encryptionKeyRepository.Create: %w", err)
+	}
+
+	const query = `
+		INSERT INTO encryption_keys (wrapped_key, master_key_id, active)
+		VALUES ($1, $2, TRUE)
+		RETURNING id, active, created_at
+	`
+	err = tx.QueryRowxContext(ctx, query, key.WrappedKey, key.MasterKeyID).
+		Scan(&key.ID, &key.Active, &key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("encryptionKeyRepository.Create: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("encryptionKeyRepository.Create: %w", err)
+	}
+	return nil
+}
+
+// Rewrap substitui a cifra da chave de dados após a troca da chave mestra.
+func (r *encryptionKeyRepository) Rewrap(ctx context.Context, key *models.EncryptionKey) error {
+	const query = `UPDATE encryption_keys SET wrapped_key = $1, master_key_id = $2 WHERE id = $3 RETURNING id`
+
+	var id int
+	err := r.db.QueryRowxContext(ctx, query, key.WrappedKey, key.MasterKeyID, key.ID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("encryptionKeyRepository.Rewrap: %w", err)
+	}
+	return nil
+}
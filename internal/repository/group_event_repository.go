@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// GroupEventsChannel é o canal do LISTEN/NOTIFY que distribui os eventos entre réplicas.
+const GroupEventsChannel = "group_events"
+
+type GroupEventRepository interface {
+	Publish(ctx context.Context, event *models.GroupEvent) error
+	ListSince(ctx context.Context, groupID string, afterID int64, since time.Time, limit int) ([]*models.GroupEvent, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+type groupEventRepository struct {
+	db *tenant.DB
+}
+
+func NewGroupEventRepository(db *tenant.DB) GroupEventRepository {
+	return &groupEventRepository{db: db}
+}
+
+// Publish grava o evento e o anuncia com pg_notify no mesmo comando, de modo
+// que todo evento notificado também está disponível para replay.
+//
+// O ID vem de uma sequência, que é consumida antes do commit: sem
+// serialização, um evento com ID menor poderia ser confirmado depois de um
+// maior, e o cliente que já recebeu o maior (e reconecta com ele no
+// Last-Event-ID) nunca veria o menor. O advisory lock por sala, mantido até o
+// commit, garante que os IDs de cada sala são confirmados em ordem.
+func (r *groupEventRepository) Publish(ctx context.Context, event *models.GroupEvent) error {
+	const lockQuery = `SELECT pg_advisory_xact_lock(hashtext('group_events:' || $1))`
+
+	const query = `
+		WITH e AS (
+			INSERT INTO group_events (group_id, type, data)
+			VALUES ($1, $2, $3)
+			RETURNING id, group_id, type, data, created_at
+		)
+		SELECT e.id, e.created_at, pg_notify($4, row_to_json(e)::text)
+		FROM e
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("groupEventRepository.Publish: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, lockQuery, event.GroupID); err != nil {
+		return fmt.Errorf("groupEventRepository.Publish: %w", err)
+	}
+
+	var notified string
+	err = tx.QueryRowxContext(
+		ctx,
+		query,
+		event.GroupID,
+		event.Type,
+		[]byte(event.Data),
+		GroupEventsChannel,
+	).Scan(&event.ID, &event.CreatedAt, &notified)
+	if err != nil {
+		return fmt.Errorf("groupEventRepository.Publish: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("groupEventRepository.Publish: %w", err)
+	}
+	return nil
+}
+
+// ListSince retorna os eventos da sala posteriores a afterID, para o replay
+// de clientes que reconectam com Last-Event-ID.
+func (r *groupEventRepository) ListSince(ctx context.Context, groupID string, afterID int64, since time.Time, limit int) ([]*models.GroupEvent, error) {
+	const query = `
+		SELECT id, group_id, type, data, created_at
+		FROM group_events
+		WHERE group_id = $1 AND id > $2 AND created_at >= $3
+		ORDER BY id
+		LIMIT $4
+	`
+
+	var events []*models.GroupEvent
+	if err := r.db.SelectContext(ctx, &events, query, groupID, afterID, since, limit); err != nil {
+		return nil, fmt.Errorf("groupEventRepository.ListSince: %w", err)
+	}
+	return events, nil
+}
+
+// DeleteBefore remove os eventos do tenant criados antes de before, que já não
+// entram no replay. Retorna quantos foram removidos.
+func (r *groupEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	const query = `DELETE FROM group_events WHERE created_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("groupEventRepository.DeleteBefore: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
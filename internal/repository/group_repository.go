@@ -3,10 +3,13 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
-	"github.com/jmoiron/sqlx"
+	"github.com/eduardohass/kids-api/internal/tenant"
 )
 
 type GroupRepository interface {
@@ -14,14 +17,16 @@ type GroupRepository interface {
 	GetByID(ctx context.Context, id string) (*models.Group, error)
 	Update(ctx context.Context, group *models.Group) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string, deletedBefore time.Time) error
 	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Group, error)
 }
 
 type groupRepository struct {
-	db *sqlx.DB
+	db *tenant.DB
 }
 
-func NewGroupRepository(db *sqlx.DB) GroupRepository {
+func NewGroupRepository(db *tenant.DB) GroupRepository {
 	return &groupRepository{db: db}
 }
 
@@ -33,7 +38,7 @@ func (r *groupRepository) Create(ctx context.Context, group *models.Group) error
 			age_range,
 			capacity
 		) VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at, updated_at
+		RETURNING id, version, created_at, updated_at
 	`
 
 	return r.db.QueryRowxContext(
@@ -43,23 +48,28 @@ func (r *groupRepository) Create(ctx context.Context, group *models.Group) error
 		group.Description,
 		group.AgeRange,
 		group.Capacity,
-	).Scan(&group.ID, &group.CreatedAt, &group.UpdatedAt)
+	).Scan(&group.ID, &group.Version, &group.CreatedAt, &group.UpdatedAt)
 }
 
 func (r *groupRepository) GetByID(ctx context.Context, id string) (*models.Group, error) {
 	const query = `
-		SELECT id, name, description, age_range, capacity, created_at, updated_at
+		SELECT id, name, description, age_range, capacity, version, created_at, updated_at
 		FROM groups
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var group models.Group
 	if err := r.db.GetContext(ctx, &group, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
 		return nil, fmt.Errorf("groupRepository.GetByID: %w", err)
 	}
 	return &group, nil
 }
 
+// Update grava as alterações usando controle de concorrência otimista: quando
+// group.Version é informado, a atualização só ocorre se a versão persistida for a mesma.
 func (r *groupRepository) Update(ctx context.Context, group *models.Group) error {
 	const query = `
 		UPDATE groups SET
@@ -67,12 +77,13 @@ func (r *groupRepository) Update(ctx context.Context, group *models.Group) error
 			description = $2,
 			age_range = $3,
 			capacity = $4,
+			version = version + 1,
 			updated_at = NOW()
-		WHERE id = $5
-		RETURNING updated_at
+		WHERE id = $5 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)
+		RETURNING version, updated_at
 	`
 
-	result, err := r.db.ExecContext(
+	err := r.db.QueryRowxContext(
 		ctx,
 		query,
 		group.Name,
@@ -80,14 +91,29 @@ func (r *groupRepository) Update(ctx context.Context, group *models.Group) error
 		group.AgeRange,
 		group.Capacity,
 		group.ID,
-	)
+		group.Version,
+	).Scan(&group.Version, &group.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "groups", group.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("groupRepository.Update: %w", err)
 	}
 
+	return nil
+}
+
+func (r *groupRepository) Delete(ctx context.Context, id string) error {
+	const query = `UPDATE groups SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("groupRepository.Delete: %w", err)
+	}
+
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("groupRepository.Update: %w", err)
+		return fmt.Errorf("groupRepository.Delete: %w", err)
 	}
 
 	if rows == 0 {
@@ -97,17 +123,18 @@ func (r *groupRepository) Update(ctx context.Context, group *models.Group) error
 	return nil
 }
 
-func (r *groupRepository) Delete(ctx context.Context, id string) error {
-	const query = `DELETE FROM groups WHERE id = $1`
+// Restore desfaz a exclusão lógica de um registro.
+func (r *groupRepository) Restore(ctx context.Context, id string) error {
+	const query = `UPDATE groups SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("groupRepository.Delete: %w", err)
+		return fmt.Errorf("groupRepository.Restore: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("groupRepository.Delete: %w", err)
+		return fmt.Errorf("groupRepository.Restore: %w", err)
 	}
 
 	if rows == 0 {
@@ -117,10 +144,16 @@ func (r *groupRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Purge remove fisicamente um registro excluído logicamente antes de deletedBefore.
+func (r *groupRepository) Purge(ctx context.Context, id string, deletedBefore time.Time) error {
+	return purge(ctx, r.db, "groups", id, deletedBefore)
+}
+
 func (r *groupRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Group, error) {
 	const query = `
-		SELECT id, name, description, age_range, capacity, created_at, updated_at
+		SELECT id, name, description, age_range, capacity, version, created_at, updated_at
 		FROM groups
+		WHERE deleted_at IS NULL
 		LIMIT $1 OFFSET $2
 	`
 
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type HouseholdRepository interface {
+	Create(ctx context.Context, household *models.Household) error
+	GetByID(ctx context.Context, id string) (*models.Household, error)
+	GetByCaretaker(ctx context.Context, caretakerID string) (*models.Household, error)
+	Update(ctx context.Context, household *models.Household) error
+	Delete(ctx context.Context, id string) error
+	AddChild(ctx context.Context, householdID, childID string) error
+	AddCaretaker(ctx context.Context, householdID, caretakerID string) error
+	LoadMembers(ctx context.Context, household *models.Household) error
+	FindByPhone(ctx context.Context, digits string, limit int) ([]*models.KioskFamily, error)
+}
+
+type householdRepository struct {
+	db *tenant.DB
+}
+
+func NewHouseholdRepository(db *tenant.DB) HouseholdRepository {
+	return &householdRepository{db: db}
+}
+
+func (r *householdRepository) Create(ctx context.Context, household *models.Household) error {
+	const query = `
+		INSERT INTO households (
+			name,
+			address,
+			primary_contact_id,
+			notes
+		) VALUES ($1, $2, NULLIF($3, '')::uuid, $4)
+		RETURNING id, version, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		household.Name,
+		household.Address,
+		household.PrimaryContactID,
+		household.Notes,
+	).Scan(&household.ID, &household.Version, &household.CreatedAt, &household.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("householdRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *householdRepository) GetByID(ctx context.Context, id string) (*models.Household, error) {
+	const query = `
+		SELECT
+			id,
+			name,
+			COALESCE(address, '') AS address,
+			COALESCE(primary_contact_id::text, '') AS primary_contact_id,
+			COALESCE(notes, '') AS notes,
+			version,
+			created_at,
+			updated_at
+		FROM households
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	var household models.Household
+	if err := r.db.GetContext(ctx, &household, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("householdRepository.GetByID: %w", err)
+	}
+	return &household, nil
+}
+
+// GetByCaretaker busca a família à qual o responsável pertence.
+func (r *householdRepository) GetByCaretaker(ctx context.Context, caretakerID string) (*models.Household, error) {
+	const query = `
+		SELECT
+			h.id,
+			h.name,
+			COALESCE(h.address, '') AS address,
+			COALESCE(h.primary_contact_id::text, '') AS primary_contact_id,
+			COALESCE(h.notes, '') AS notes,
+			h.version,
+			h.created_at,
+			h.updated_at
+		FROM households h
+		INNER JOIN caretakers c ON c.household_id = h.id
+		WHERE c.id = $1 AND h.deleted_at IS NULL
+	`
+
+	var household models.Household
+	if err := r.db.GetContext(ctx, &household, query, caretakerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("householdRepository.GetByCaretaker: %w", err)
+	}
+	return &household, nil
+}
+
+func (r *householdRepository) Update(ctx context.Context, household *models.Household) error {
+	const query = `
+		UPDATE households SET
+			name = $1,
+			address = $2,
+			primary_contact_id = NULLIF($3, '')::uuid,
+			notes = $4,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = $5 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)
+		RETURNING version, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		household.Name,
+		household.Address,
+		household.PrimaryContactID,
+		household.Notes,
+		household.ID,
+		household.Version,
+	).Scan(&household.Version, &household.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "households", household.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("householdRepository.Update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *householdRepository) Delete(ctx context.Context, id string) error {
+	const query = `UPDATE households SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("householdRepository.Delete: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *householdRepository) AddChild(ctx context.Context, householdID, childID string) error {
+	const query = `UPDATE children SET household_id = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
+	return r.addMember(ctx, "householdRepository.AddChild", query, householdID, childID)
+}
+
+func (r *householdRepository) AddCaretaker(ctx context.Context, householdID, caretakerID string) error {
+	const query = `UPDATE caretakers SET household_id = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
+	return r.addMember(ctx, "householdRepository.AddCaretaker", query, householdID, caretakerID)
+}
+
+func (r *householdRepository) addMember(ctx context.Context, op, query, householdID, memberID string) error {
+	result, err := r.db.ExecContext(ctx, query, householdID, memberID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// LoadMembers carrega os responsáveis, as crianças e as relações entre eles
+// (children_caretakers) que compõem a árvore familiar.
+func (r *householdRepository) LoadMembers(ctx context.Context, household *models.Household) error {
+	const caretakersQuery = `
+		SELECT id, name, email, phone, address, version, created_at, updated_at
+		FROM caretakers
+		WHERE household_id = $1 AND deleted_at IS NULL
+		ORDER BY name
+	`
+	if err := r.db.SelectContext(ctx, &household.Caretakers, caretakersQuery, household.ID); err != nil {
+		return fmt.Errorf("householdRepository.LoadMembers: %w", err)
+	}
+
+	const childrenQuery = `
+		SELECT
+			id,
+			name,
+			birth_date,
+			gender,
+			photo_url,
+			group_id,
+			version,
+			created_at,
+			updated_at
+		FROM children
+		WHERE household_id = $1 AND deleted_at IS NULL
+		ORDER BY birth_date
+	`
+	if err := r.db.SelectContext(ctx, &household.Children, childrenQuery, household.ID); err != nil {
+		return fmt.Errorf("householdRepository.LoadMembers: %w", err)
+	}
+
+	const relationsQuery = `
+		SELECT
+			cc.id,
+			cc.crianca_id,
+			cc.responsavel_id,
+			cc.tipo_relacao,
+			cc.pode_retirar,
+			cc.criado_em,
+			cc.atualizado_em
+		FROM children_caretakers cc
+		INNER JOIN children c ON c.id = cc.crianca_id
+		WHERE c.household_id = $1 AND c.deleted_at IS NULL
+	`
+	if err := r.db.SelectContext(ctx, &household.Relations, relationsQuery, household.ID); err != nil {
+		return fmt.Errorf("householdRepository.LoadMembers: %w", err)
+	}
+
+	return nil
+}
+
+// FindByPhone busca famílias pelo telefone de algum responsável: com 4 dígitos,
+// compara com o final do número; com mais, exige o número completo. Como o
+// telefone é gravado cifrado, a comparação usa os índices cegos.
+func (r *householdRepository) FindByPhone(ctx context.Context, digits string, limit int) ([]*models.KioskFamily, error) {
+	const query = `
+		WITH familias AS (
+			SELECT DISTINCT h.id, h.name
+			FROM households h
+			INNER JOIN caretakers ct ON ct.household_id = h.id
+			WHERE h.deleted_at IS NULL
+				AND ct.deleted_at IS NULL
+				AND (ct.phone_last4_index = $1 OR ct.phone_index = $2)
+			ORDER BY h.name
+			LIMIT $3
+		)
+		SELECT
+			f.id AS household_id,
+			f.name AS household_name,
+			COALESCE(c.id::text, '') AS child_id,
+			COALESCE(c.name, '') AS child_name
+		FROM familias f
+		LEFT JOIN children c ON c.household_id = f.id AND c.deleted_at IS NULL
+		ORDER BY f.name, c.name
+	`
+
+	var rows []struct {
+		HouseholdID   string `db:"household_id"`
+		HouseholdName string `db:"household_name"`
+		ChildID       string `db:"child_id"`
+		ChildName     string `db:"child_name"`
+	}
+	last4, full := "", phoneIndex(digits)
+	if len(digits) == 4 {
+		last4, full = phoneLast4Index(digits), ""
+	}
+
+	if err := r.db.SelectContext(ctx, &rows, query, last4, full, limit); err != nil {
+		return nil, fmt.Errorf("householdRepository.FindByPhone: %w", err)
+	}
+
+	families := []*models.KioskFamily{}
+	byID := map[string]*models.KioskFamily{}
+	for _, row := range rows {
+		family, ok := byID[row.HouseholdID]
+		if !ok {
+			family = &models.KioskFamily{
+				HouseholdID: row.HouseholdID,
+				Name:        row.HouseholdName,
+				Children:    []models.KioskChild{},
+			}
+			byID[row.HouseholdID] = family
+			families = append(families, family)
+		}
+		if row.ChildID != "" {
+			family.Children = append(family.Children, models.KioskChild{ID: row.ChildID, Name: row.ChildName})
+		}
+	}
+	return families, nil
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type IncidentRepository interface {
+	Create(ctx context.Context, incident *models.Incident) error
+	GetByID(ctx context.Context, id string) (*models.Incident, error)
+	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Incident, error)
+	Update(ctx context.Context, incident *models.Incident) error
+	ListStaleDrafts(ctx context.Context, updatedBefore time.Time, limit, offset int) ([]string, error)
+	DeleteDrafts(ctx context.Context, ids []string, updatedBefore time.Time) ([]string, error)
+}
+
+type incidentRepository struct {
+	db *tenant.DB
+}
+
+func NewIncidentRepository(db *tenant.DB) IncidentRepository {
+	return &incidentRepository{db: db}
+}
+
+const incidentColumns = `
+	id,
+	child_id,
+	COALESCE(group_id::text, '') AS group_id,
+	COALESCE(event_id::text, '') AS event_id,
+	type,
+	description,
+	COALESCE(first_aid, '') AS first_aid,
+	witnesses,
+	occurred_at,
+	status,
+	COALESCE(reported_by, '') AS reported_by,
+	submitted_at,
+	COALESCE(reviewed_by, '') AS reviewed_by,
+	reviewed_at,
+	COALESCE(review_notes, '') AS review_notes,
+	closed_at,
+	COALESCE(acknowledged_by_caretaker_id::text, '') AS acknowledged_by_caretaker_id,
+	acknowledged_at,
+	version,
+	created_at,
+	updated_at
+`
+
+// Create grava o relatório e os voluntários envolvidos em uma transação.
+func (r *incidentRepository) Create(ctx context.Context, incident *models.Incident) error {
+	const query = `
+		INSERT INTO incidents (
+			child_id,
+			group_id,
+			event_id,
+			type,
+			description,
+			first_aid,
+			witnesses,
+			occurred_at,
+			status,
+			reported_by
+		) VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, version, created_at, updated_at
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("incidentRepository.Create: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(
+		ctx,
+		query,
+		incident.ChildID,
+		incident.GroupID,
+		incident.EventID,
+		incident.Type,
+		incident.Description,
+		incident.FirstAid,
+		incident.Witnesses,
+		incident.OccurredAt,
+		incident.Status,
+		incident.ReportedBy,
+	).Scan(&incident.ID, &incident.Version, &incident.CreatedAt, &incident.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("incidentRepository.Create: %w", err)
+	}
+
+	if err := setIncidentVolunteers(ctx, tx, incident.ID, incident.VolunteerIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("incidentRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *incidentRepository) GetByID(ctx context.Context, id string) (*models.Incident, error) {
+	query := `SELECT ` + incidentColumns + ` FROM incidents WHERE id = $1`
+
+	var incident models.Incident
+	if err := r.db.GetContext(ctx, &incident, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("incidentRepository.GetByID: %w", err)
+	}
+
+	const volunteersQuery = `SELECT volunteer_id FROM incident_volunteers WHERE incident_id = $1`
+	incident.VolunteerIDs = []string{}
+	if err := r.db.SelectContext(ctx, &incident.VolunteerIDs, volunteersQuery, id); err != nil {
+		return nil, fmt.Errorf("incidentRepository.GetByID: %w", err)
+	}
+
+	return &incident, nil
+}
+
+func (r *incidentRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Incident, error) {
+	query := `SELECT ` + incidentColumns + ` FROM incidents WHERE 1=1`
+
+	var args []interface{}
+	for _, column := range []string{"child_id", "group_id", "status"} {
+		if value, ok := filter[column]; ok {
+			args = append(args, value)
+			query += fmt.Sprintf(" AND %s = $%d", column, len(args))
+		}
+	}
+
+	args = append(args, limit, offset)
+	query += fmt.Sprintf(" ORDER BY occurred_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	var incidents []*models.Incident
+	if err := r.db.SelectContext(ctx, &incidents, query, args...); err != nil {
+		return nil, fmt.Errorf("incidentRepository.List: %w", err)
+	}
+	return incidents, nil
+}
+
+// Update grava o relatório inteiro, inclusive a etapa do fluxo, com controle
+// de concorrência pela versão.
+func (r *incidentRepository) Update(ctx context.Context, incident *models.Incident) error {
+	const query = `
+		UPDATE incidents SET
+			group_id = NULLIF($1, '')::uuid,
+			event_id = NULLIF($2, '')::uuid,
+			type = $3,
+			description = $4,
+			first_aid = $5,
+			witnesses = $6,
+			occurred_at = $7,
+			status = $8,
+			submitted_at = $9,
+			reviewed_by = NULLIF($10, ''),
+			reviewed_at = $11,
+			review_notes = NULLIF($12, ''),
+			closed_at = $13,
+			acknowledged_by_caretaker_id = NULLIF($14, '')::uuid,
+			acknowledged_at = $15,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = $16 AND ($17 = 0 OR version = $17)
+		RETURNING version, updated_at
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("incidentRepository.Update: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(
+		ctx,
+		query,
+		incident.GroupID,
+		incident.EventID,
+		incident.Type,
+		incident.Description,
+		incident.FirstAid,
+		incident.Witnesses,
+		incident.OccurredAt,
+		incident.Status,
+		incident.SubmittedAt,
+		incident.ReviewedBy,
+		incident.ReviewedAt,
+		incident.ReviewNotes,
+		incident.ClosedAt,
+		incident.AcknowledgedByCaretakerID,
+		incident.AcknowledgedAt,
+		incident.ID,
+		incident.Version,
+	).Scan(&incident.Version, &incident.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Relatórios não são excluídos: sem linha afetada, é conflito de versão
+		// ou ID inexistente.
+		if _, err := r.GetByID(ctx, incident.ID); err != nil {
+			return err
+		}
+		return ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("incidentRepository.Update: %w", err)
+	}
+
+	if err := setIncidentVolunteers(ctx, tx, incident.ID, incident.VolunteerIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("incidentRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func setIncidentVolunteers(ctx context.Context, tx *sqlx.Tx, incidentID string, volunteerIDs []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM incident_volunteers WHERE incident_id = $1`, incidentID); err != nil {
+		return fmt.Errorf("incidentRepository.setVolunteers: %w", err)
+	}
+
+	const query = `
+		INSERT INTO incident_volunteers (incident_id, volunteer_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, incidentID, pq.Array(volunteerIDs)); err != nil {
+		return fmt.Errorf("incidentRepository.setVolunteers: %w", err)
+	}
+	return nil
+}
+
+// ListStaleDrafts retorna os rascunhos não alterados desde updatedBefore.
+func (r *incidentRepository) ListStaleDrafts(ctx context.Context, updatedBefore time.Time, limit, offset int) ([]string, error) {
+	const query = `
+		SELECT id
+		FROM incidents
+		WHERE status = 'draft' AND updated_at < $1
+		ORDER BY updated_at, id
+		LIMIT $2 OFFSET $3
+	`
+
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, query, updatedBefore, limit, offset); err != nil {
+		return nil, fmt.Errorf("incidentRepository.ListStaleDrafts: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteDrafts exclui os rascunhos informados que continuam sem alteração
+// desde updatedBefore e retorna os excluídos; os voluntários envolvidos saem
+// em cascata.
+func (r *incidentRepository) DeleteDrafts(ctx context.Context, ids []string, updatedBefore time.Time) ([]string, error) {
+	const query = `
+		DELETE FROM incidents
+		WHERE id = ANY($1::uuid[]) AND status = 'draft' AND updated_at < $2
+		RETURNING id
+	`
+
+	var deleted []string
+	if err := r.db.SelectContext(ctx, &deleted, query, pq.Array(ids), updatedBefore); err != nil {
+		return nil, fmt.Errorf("incidentRepository.DeleteDrafts: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// ErrJobLockLost is returned when a worker reports the outcome of a job whose
+// lock expired and was claimed again by another worker.
+var ErrJobLockLost = errors.New("job lock was lost to another worker")
+
+type JobRepository interface {
+	Enqueue(ctx context.Context, job *models.Job) error
+	Claim(ctx context.Context, workerID string, lockTimeout time.Duration) (*models.Job, error)
+	Complete(ctx context.Context, job *models.Job) error
+	Fail(ctx context.Context, job *models.Job, runAt time.Time) error
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	List(ctx context.Context, status string, limit, offset int) ([]*models.Job, error)
+	Requeue(ctx context.Context, id string) error
+	HasQueued(ctx context.Context, jobType string) (bool, error)
+}
+
+type jobRepository struct {
+	db *tenant.DB
+}
+
+func NewJobRepository(db *tenant.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+const jobColumns = `
+	id,
+	COALESCE(tenant_id::text, '') AS tenant_id,
+	type,
+	payload,
+	status,
+	attempts,
+	max_attempts,
+	run_at,
+	locked_at,
+	COALESCE(locked_by, '') AS locked_by,
+	COALESCE(last_error, '') AS last_error,
+	completed_at,
+	created_at,
+	updated_at
+`
+
+func (r *jobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	const query = `
+		INSERT INTO jobs (
+			type,
+			payload,
+			max_attempts,
+			run_at
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		job.Type,
+		[]byte(job.Payload),
+		job.MaxAttempts,
+		job.RunAt,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("jobRepository.Enqueue: %w", err)
+	}
+
+	return nil
+}
+
+// Claim reserva o próximo trabalho pronto para execução. O SKIP LOCKED permite
+// que vários workers consultem a fila ao mesmo tempo sem disputar a mesma
+// linha. Trabalhos "running" cujo lock expirou (worker que morreu no meio da
+// execução) voltam a ser elegíveis enquanto restarem tentativas; os que já
+// esgotaram vão para a dead letter, para que um trabalho que derruba o
+// processo não rode para sempre. Retorna ErrNotFound quando a fila está vazia.
+func (r *jobRepository) Claim(ctx context.Context, workerID string, lockTimeout time.Duration) (*models.Job, error) {
+	const buryQuery = `
+		UPDATE jobs SET
+			status = 'dead',
+			last_error = 'lock expired on the last attempt',
+			locked_at = NULL,
+			locked_by = NULL,
+			updated_at = NOW()
+		WHERE status = 'running'
+			AND attempts >= max_attempts
+			AND locked_at < NOW() - make_interval(secs => $1)
+	`
+	if _, err := r.db.ExecContext(ctx, buryQuery, lockTimeout.Seconds()); err != nil {
+		return nil, fmt.Errorf("jobRepository.Claim: %w", err)
+	}
+
+	query := `
+		UPDATE jobs SET
+			status = 'running',
+			attempts = attempts + 1,
+			locked_at = NOW(),
+			locked_by = $1,
+			updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE (status = 'queued' AND run_at <= NOW())
+				OR (status = 'running' AND attempts < max_attempts AND locked_at < NOW() - make_interval(secs => $2))
+			ORDER BY run_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + jobColumns
+
+	var job models.Job
+	if err := r.db.GetContext(ctx, &job, query, workerID, lockTimeout.Seconds()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("jobRepository.Claim: %w", err)
+	}
+	return &job, nil
+}
+
+// Complete marca o trabalho como concluído. Só vale para quem ainda detém o
+// lock: locked_by e attempts identificam a reserva feita no Claim, já que
+// vários goroutines do mesmo worker compartilham o locked_by. Retorna
+// ErrJobLockLost se o lock expirou e o trabalho foi reservado de novo.
+func (r *jobRepository) Complete(ctx context.Context, job *models.Job) error {
+	const query = `
+		UPDATE jobs SET
+			status = 'done',
+			locked_at = NULL,
+			locked_by = NULL,
+			last_error = NULL,
+			completed_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND locked_by = $2 AND attempts = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, job.ID, job.LockedBy, job.Attempts)
+	if err != nil {
+		return fmt.Errorf("jobRepository.Complete: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrJobLockLost
+	}
+
+	return nil
+}
+
+// Fail registra a falha da tentativa: o trabalho volta para a fila em runAt ou,
+// se esgotou as tentativas, vai para a dead letter. Como em Complete, retorna
+// ErrJobLockLost se outro worker já reservou o trabalho.
+func (r *jobRepository) Fail(ctx context.Context, job *models.Job, runAt time.Time) error {
+	const query = `
+		UPDATE jobs SET
+			status = CASE WHEN attempts >= max_attempts THEN 'dead' ELSE 'queued' END,
+			run_at = $1,
+			last_error = $2,
+			locked_at = NULL,
+			locked_by = NULL,
+			updated_at = NOW()
+		WHERE id = $3 AND status = 'running' AND locked_by = $4 AND attempts = $5
+		RETURNING status
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, runAt, job.LastError, job.ID, job.LockedBy, job.Attempts).Scan(&job.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrJobLockLost
+		}
+		return fmt.Errorf("jobRepository.Fail: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRepository) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1 AND tenant_id = ` + currentTenant
+
+	var job models.Job
+	if err := r.db.GetContext(ctx, &job, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("jobRepository.GetByID: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) List(ctx context.Context, status string, limit, offset int) ([]*models.Job, error) {
+	query := `SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE ($1 = '' OR status = $1) AND tenant_id = ` + currentTenant + `
+		ORDER BY updated_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var jobs []*models.Job
+	if err := r.db.SelectContext(ctx, &jobs, query, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("jobRepository.List: %w", err)
+	}
+	return jobs, nil
+}
+
+// Requeue devolve um trabalho da dead letter para a fila, com as tentativas zeradas.
+func (r *jobRepository) Requeue(ctx context.Context, id string) error {
+	query := `
+		UPDATE jobs SET
+			status = 'queued',
+			attempts = 0,
+			run_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'dead' AND tenant_id = ` + currentTenant
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("jobRepository.Requeue: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// HasQueued informa se o tenant já tem um trabalho do tipo aguardando execução.
+func (r *jobRepository) HasQueued(ctx context.Context, jobType string) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM jobs WHERE type = $1 AND status = 'queued' AND tenant_id = ` + currentTenant + `
+	)`
+
+	var queued bool
+	if err := r.db.GetContext(ctx, &queued, query, jobType); err != nil {
+		return false, fmt.Errorf("jobRepository.HasQueued: %w", err)
+	}
+	return queued, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+func TestClaimDeadLettersStuckJobsOutOfAttempts(t *testing.T) {
+	raw := isolationDB(t)
+	jobs := NewJobRepository(tenant.NewDB(raw))
+	ctx := tenant.WithID(context.Background(), createTenant(t, raw, "Campus"))
+
+	job := &models.Job{Type: "crashes_worker", Payload: []byte(`{}`), MaxAttempts: 2, RunAt: time.Now().Add(-time.Second)}
+	if err := jobs.Enqueue(ctx, job); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	// O worker morre no meio da execução: o lock expira sem Complete nem Fail
+	expireLock := func() {
+		t.Helper()
+		if _, err := raw.Exec(`UPDATE jobs SET locked_at = NOW() - INTERVAL '1 hour' WHERE id = $1`, job.ID); err != nil {
+			t.Fatalf("expire lock: %v", err)
+		}
+	}
+
+	for attempt := 1; attempt <= job.MaxAttempts; attempt++ {
+		claimed, err := jobs.Claim(context.Background(), "worker", time.Minute)
+		if err != nil {
+			t.Fatalf("Claim attempt %d: %v", attempt, err)
+		}
+		if claimed.ID != job.ID || claimed.Attempts != attempt {
+			t.Fatalf("Claim attempt %d = job %s attempt %d", attempt, claimed.ID, claimed.Attempts)
+		}
+		expireLock()
+	}
+
+	if claimed, err := jobs.Claim(context.Background(), "worker", time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Claim after the last attempt = %+v, %v; want ErrNotFound", claimed, err)
+	}
+
+	got, err := jobs.GetByID(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != models.JobDead || got.LockedBy != "" {
+		t.Fatalf("job status = %s, locked by %q; want dead and unlocked", got.Status, got.LockedBy)
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// KioskRepository persiste dispositivos de quiosque. Códigos de pareamento e
+// credenciais são armazenados apenas como hash.
+type KioskRepository interface {
+	Create(ctx context.Context, device *models.KioskDevice, pairingCodeHash string, pairingExpiresAt time.Time) error
+	List(ctx context.Context) ([]*models.KioskDevice, error)
+	Pair(ctx context.Context, pairingCodeHash, tokenHash string) (*models.KioskDevice, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.KioskDevice, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type kioskRepository struct {
+	db *tenant.DB
+}
+
+func NewKioskRepository(db *tenant.DB) KioskRepository {
+	return &kioskRepository{db: db}
+}
+
+const kioskColumns = `
+	id,
+	tenant_id,
+	name,
+	COALESCE(location_id::text, '') AS location_id,
+	paired_at,
+	last_seen_at,
+	revoked_at,
+	COALESCE(created_by, '') AS created_by,
+	created_at,
+	updated_at
+`
+
+func (r *kioskRepository) Create(ctx context.Context, device *models.KioskDevice, pairingCodeHash string, pairingExpiresAt time.Time) error {
+	const query = `
+		INSERT INTO kiosk_devices (
+			name,
+			location_id,
+			pairing_code_hash,
+			pairing_expires_at,
+			created_by
+		) VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		device.Name,
+		device.LocationID,
+		pairingCodeHash,
+		pairingExpiresAt,
+		device.CreatedBy,
+	).Scan(&device.ID, &device.CreatedAt, &device.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("kioskRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *kioskRepository) List(ctx context.Context) ([]*models.KioskDevice, error) {
+	query := `SELECT ` + kioskColumns + ` FROM kiosk_devices WHERE tenant_id = ` + currentTenant + ` ORDER BY name`
+
+	var devices []*models.KioskDevice
+	if err := r.db.SelectContext(ctx, &devices, query); err != nil {
+		return nil, fmt.Errorf("kioskRepository.List: %w", err)
+	}
+	return devices, nil
+}
+
+// Pair troca um código de pareamento válido pela credencial do dispositivo.
+// O código é invalidado no mesmo UPDATE, então só pode ser usado uma vez.
+func (r *kioskRepository) Pair(ctx context.Context, pairingCodeHash, tokenHash string) (*models.KioskDevice, error) {
+	query := `
+		UPDATE kiosk_devices SET
+			token_hash = $2,
+			pairing_code_hash = NULL,
+			pairing_expires_at = NULL,
+			paired_at = NOW(),
+			updated_at = NOW()
+		WHERE pairing_code_hash = $1
+			AND pairing_expires_at > NOW()
+			AND revoked_at IS NULL
+		RETURNING ` + kioskColumns
+
+	var device models.KioskDevice
+	if err := r.db.GetContext(ctx, &device, query, pairingCodeHash, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("kioskRepository.Pair: %w", err)
+	}
+	return &device, nil
+}
+
+// GetByTokenHash busca o dispositivo ativo dono da credencial e registra o último acesso.
+func (r *kioskRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.KioskDevice, error) {
+	query := `
+		UPDATE kiosk_devices SET last_seen_at = NOW()
+		WHERE token_hash = $1 AND revoked_at IS NULL
+		RETURNING ` + kioskColumns
+
+	var device models.KioskDevice
+	if err := r.db.GetContext(ctx, &device, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("kioskRepository.GetByTokenHash: %w", err)
+	}
+	return &device, nil
+}
+
+func (r *kioskRepository) Revoke(ctx context.Context, id string) error {
+	query := `
+		UPDATE kiosk_devices SET
+			revoked_at = NOW(),
+			token_hash = NULL,
+			pairing_code_hash = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL AND tenant_id = ` + currentTenant
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("kioskRepository.Revoke: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type LocationRepository interface {
+	Create(ctx context.Context, location *models.Location) error
+	GetByID(ctx context.Context, id string) (*models.Location, error)
+	Update(ctx context.Context, location *models.Location) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, limit, offset int) ([]*models.Location, error)
+}
+
+type locationRepository struct {
+	db *tenant.DB
+}
+
+func NewLocationRepository(db *tenant.DB) LocationRepository {
+	return &locationRepository{db: db}
+}
+
+const locationColumns = `
+	id,
+	name,
+	COALESCE(address, '') AS address,
+	COALESCE(notes, '') AS notes,
+	version,
+	created_at,
+	updated_at
+`
+
+func (r *locationRepository) Create(ctx context.Context, location *models.Location) error {
+	const query = `
+		INSERT INTO locations (name, address, notes)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''))
+		RETURNING id, version, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, location.Name, location.Address, location.Notes).
+		Scan(&location.ID, &location.Version, &location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("locationRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *locationRepository) GetByID(ctx context.Context, id string) (*models.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM locations WHERE id = $1 AND deleted_at IS NULL`
+
+	var location models.Location
+	if err := r.db.GetContext(ctx, &location, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("locationRepository.GetByID: %w", err)
+	}
+	return &location, nil
+}
+
+func (r *locationRepository) Update(ctx context.Context, location *models.Location) error {
+	const query = `
+		UPDATE locations SET
+			name = $1,
+			address = NULLIF($2, ''),
+			notes = NULLIF($3, ''),
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = $4 AND deleted_at IS NULL AND ($5 = 0 OR version = $5)
+		RETURNING version, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		location.Name,
+		location.Address,
+		location.Notes,
+		location.ID,
+		location.Version,
+	).Scan(&location.Version, &location.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "locations", location.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("locationRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *locationRepository) Delete(ctx context.Context, id string) error {
+	const query = `UPDATE locations SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("locationRepository.Delete: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *locationRepository) List(ctx context.Context, limit, offset int) ([]*models.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM locations
+		WHERE deleted_at IS NULL
+		ORDER BY name
+		LIMIT $1 OFFSET $2`
+
+	var locations []*models.Location
+	if err := r.db.SelectContext(ctx, &locations, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("locationRepository.List: %w", err)
+	}
+	return locations, nil
+}
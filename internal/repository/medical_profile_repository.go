@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type MedicalProfileRepository interface {
+	Get(ctx context.Context, childID string) (*models.MedicalProfile, error)
+	Save(ctx context.Context, profile *models.MedicalProfile) error
+}
+
+type medicalProfileRepository struct {
+	db *tenant.DB
+}
+
+func NewMedicalProfileRepository(db *tenant.DB) MedicalProfileRepository {
+	return &medicalProfileRepository{db: db}
+}
+
+// Get retorna a ficha médica e os contatos de emergência da criança. Crianças
+// sem ficha recebem uma ficha vazia (versão 0).
+func (r *medicalProfileRepository) Get(ctx context.Context, childID string) (*models.MedicalProfile, error) {
+	const query = `
+		SELECT
+			child_id,
+			COALESCE(blood_type, '') AS blood_type,
+			COALESCE(doctor_name, '') AS doctor_name,
+			COALESCE(doctor_phone, '') AS doctor_phone,
+			COALESCE(insurance_provider, '') AS insurance_provider,
+			COALESCE(insurance_policy_number, '') AS insurance_policy_number,
+			conditions,
+			dietary_restrictions,
+			COALESCE(notes, '') AS notes,
+			version,
+			COALESCE(updated_by, '') AS updated_by,
+			updated_at
+		FROM child_medical_profiles
+		WHERE child_id = $1
+	`
+
+	profile := models.MedicalProfile{
+		ChildID:             childID,
+		Conditions:          []string{},
+		DietaryRestrictions: []string{},
+	}
+	if err := r.db.GetContext(ctx, &profile, query, childID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("medicalProfileRepository.Get: %w", err)
+	}
+
+	// Dados do responsável completam o contato quando não foram informados.
+	const contactsQuery = `
+		SELECT
+			ec.id,
+			ec.position,
+			COALESCE(ec.caretaker_id::text, '') AS caretaker_id,
+			COALESCE(ec.name, ct.name, '') AS name,
+			COALESCE(ec.relationship, '') AS relationship,
+			COALESCE(ec.phone, ct.phone, '') AS phone,
+			COALESCE(ec.alt_phone, '') AS alt_phone
+		FROM child_emergency_contacts ec
+		LEFT JOIN caretakers ct ON ct.id = ec.caretaker_id AND ct.deleted_at IS NULL
+		WHERE ec.child_id = $1
+		ORDER BY ec.position
+	`
+	profile.EmergencyContacts = []models.EmergencyContact{}
+	if err := r.db.SelectContext(ctx, &profile.EmergencyContacts, contactsQuery, childID); err != nil {
+		return nil, fmt.Errorf("medicalProfileRepository.Get: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// Save grava a ficha (criando-a na primeira vez) e substitui os contatos de
+// emergência, em uma transação. profile.Version diferente de zero ativa o
+// controle de concorrência otimista.
+func (r *medicalProfileRepository) Save(ctx context.Context, profile *models.MedicalProfile) error {
+	const query = `
+		INSERT INTO child_medical_profiles (
+			child_id,
+			blood_type,
+			doctor_name,
+			doctor_phone,
+			insurance_provider,
+			insurance_policy_number,
+			conditions,
+			dietary_restrictions,
+			notes,
+			updated_by
+		) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8, NULLIF($9, ''), $10)
+		ON CONFLICT (child_id) DO UPDATE SET
+			blood_type = EXCLUDED.blood_type,
+			doctor_name = EXCLUDED.doctor_name,
+			doctor_phone = EXCLUDED.doctor_phone,
+			insurance_provider = EXCLUDED.insurance_provider,
+			insurance_policy_number = EXCLUDED.insurance_policy_number,
+			conditions = EXCLUDED.conditions,
+			dietary_restrictions = EXCLUDED.dietary_restrictions,
+			notes = EXCLUDED.notes,
+			updated_by = EXCLUDED.updated_by,
+			version = child_medical_profiles.version + 1,
+			updated_at = NOW()
+		WHERE $11 = 0 OR child_medical_profiles.version = $11
+		RETURNING version, updated_at
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("medicalProfileRepository.Save: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(
+		ctx,
+		query,
+		profile.ChildID,
+		profile.BloodType,
+		profile.DoctorName,
+		profile.DoctorPhone,
+		profile.InsuranceProvider,
+		profile.InsurancePolicyNumber,
+		profile.Conditions,
+		profile.DietaryRestrictions,
+		profile.Notes,
+		profile.UpdatedBy,
+		profile.Version,
+	).Scan(&profile.Version, &profile.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("medicalProfileRepository.Save: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM child_emergency_contacts WHERE child_id = $1`, profile.ChildID); err != nil {
+		return fmt.Errorf("medicalProfileRepository.Save: %w", err)
+	}
+
+	const contactQuery = `
+		INSERT INTO child_emergency_contacts (
+			child_id,
+			position,
+			caretaker_id,
+			name,
+			relationship,
+			phone,
+			alt_phone
+		) VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''))
+		RETURNING id
+	`
+	for i := range profile.EmergencyContacts {
+		contact := &profile.EmergencyContacts[i]
+		err := tx.QueryRowxContext(
+			ctx,
+			contactQuery,
+			profile.ChildID,
+			contact.Position,
+			contact.CaretakerID,
+			contact.Name,
+			contact.Relationship,
+			contact.Phone,
+			contact.AltPhone,
+		).Scan(&contact.ID)
+		if err != nil {
+			return fmt.Errorf("medicalProfileRepository.Save: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("medicalProfileRepository.Save: %w", err)
+	}
+	return nil
+}
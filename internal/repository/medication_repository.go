@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// ErrAlreadyVerified is returned when confirming a dose that was already verified.
+var ErrAlreadyVerified = errors.New("medication dose was already verified")
+
+type MedicationRepository interface {
+	CreateOrder(ctx context.Context, order *models.MedicationOrder) error
+	GetOrder(ctx context.Context, id string) (*models.MedicationOrder, error)
+	ListOrdersByChild(ctx context.Context, childID string, includeDiscontinued bool) ([]*models.MedicationOrder, error)
+	DiscontinueOrder(ctx context.Context, id string) error
+	CreateAdministration(ctx context.Context, administration *models.MedicationAdministration) error
+	GetAdministration(ctx context.Context, id string) (*models.MedicationAdministration, error)
+	VerifyAdministration(ctx context.Context, id, verifiedBy string) error
+	ListAdministrationsByOrder(ctx context.Context, orderID string) ([]*models.MedicationAdministration, error)
+	ListAdministrationsByChild(ctx context.Context, childID string, limit, offset int) ([]*models.MedicationAdministration, error)
+}
+
+type medicationRepository struct {
+	db *tenant.DB
+}
+
+func NewMedicationRepository(db *tenant.DB) MedicationRepository {
+	return &medicationRepository{db: db}
+}
+
+const medicationOrderColumns = `
+	id,
+	child_id,
+	drug,
+	dose,
+	COALESCE(route, '') AS route,
+	schedule,
+	as_needed,
+	COALESCE(instructions, '') AS instructions,
+	consent_document_url,
+	COALESCE(consent_caretaker_id::text, '') AS consent_caretaker_id,
+	consent_expires_at,
+	discontinued_at,
+	COALESCE(created_by, '') AS created_by,
+	created_at,
+	updated_at
+`
+
+const medicationAdministrationColumns = `
+	id,
+	order_id,
+	child_id,
+	dose_given,
+	administered_at,
+	administered_by_volunteer_id,
+	verified_by_volunteer_id,
+	COALESCE(notes, '') AS notes,
+	COALESCE(recorded_by, '') AS recorded_by,
+	verified_at,
+	COALESCE(verified_by, '') AS verified_by,
+	created_at
+`
+
+func (r *medicationRepository) CreateOrder(ctx context.Context, order *models.MedicationOrder) error {
+	const query = `
+		INSERT INTO medication_orders (
+			child_id,
+			drug,
+			dose,
+			route,
+			schedule,
+			as_needed,
+			instructions,
+			consent_document_url,
+			consent_caretaker_id,
+			consent_expires_at,
+			created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		order.ChildID,
+		order.Drug,
+		order.Dose,
+		order.Route,
+		order.Schedule,
+		order.AsNeeded,
+		order.Instructions,
+		order.ConsentDocumentURL,
+		order.ConsentCaretakerID,
+		order.ConsentExpiresAt,
+		order.CreatedBy,
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("medicationRepository.CreateOrder: %w", err)
+	}
+
+	return nil
+}
+
+func (r *medicationRepository) GetOrder(ctx context.Context, id string) (*models.MedicationOrder, error) {
+	query := `SELECT ` + medicationOrderColumns + ` FROM medication_orders WHERE id = $1`
+
+	var order models.MedicationOrder
+	if err := r.db.GetContext(ctx, &order, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("medicationRepository.GetOrder: %w", err)
+	}
+	return &order, nil
+}
+
+func (r *medicationRepository) ListOrdersByChild(ctx context.Context, childID string, includeDiscontinued bool) ([]*models.MedicationOrder, error) {
+	query := `SELECT ` + medicationOrderColumns + `
+		FROM medication_orders
+		WHERE child_id = $1 AND ($2 OR discontinued_at IS NULL)
+		ORDER BY drug`
+
+	var orders []*models.MedicationOrder
+	if err := r.db.SelectContext(ctx, &orders, query, childID, includeDiscontinued); err != nil {
+		return nil, fmt.Errorf("medicationRepository.ListOrdersByChild: %w", err)
+	}
+	return orders, nil
+}
+
+func (r *medicationRepository) DiscontinueOrder(ctx context.Context, id string) error {
+	const query = `
+		UPDATE medication_orders SET discontinued_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND discontinued_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("medicationRepository.DiscontinueOrder: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *medicationRepository) CreateAdministration(ctx context.Context, administration *models.MedicationAdministration) error {
+	const query = `
+		INSERT INTO medication_administrations (
+			order_id,
+			child_id,
+			dose_given,
+			administered_at,
+			administered_by_volunteer_id,
+			verified_by_volunteer_id,
+			notes,
+			recorded_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		administration.OrderID,
+		administration.ChildID,
+		administration.DoseGiven,
+		administration.AdministeredAt,
+		administration.AdministeredByVolunteerID,
+		administration.VerifiedByVolunteerID,
+		administration.Notes,
+		administration.RecordedBy,
+	).Scan(&administration.ID, &administration.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("medicationRepository.CreateAdministration: %w", err)
+	}
+
+	return nil
+}
+
+func (r *medicationRepository) GetAdministration(ctx context.Context, id string) (*models.MedicationAdministration, error) {
+	query := `SELECT ` + medicationAdministrationColumns + ` FROM medication_administrations WHERE id = $1`
+
+	var administration models.MedicationAdministration
+	if err := r.db.GetContext(ctx, &administration, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("medicationRepository.GetAdministration: %w", err)
+	}
+	return &administration, nil
+}
+
+// VerifyAdministration registra a conferência da dose. Retorna
+// ErrAlreadyVerified se outra requisição conferiu a dose antes.
+func (r *medicationRepository) VerifyAdministration(ctx context.Context, id, verifiedBy string) error {
+	const query = `
+		UPDATE medication_administrations SET
+			verified_at = NOW(),
+			verified_by = $2
+		WHERE id = $1 AND verified_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, verifiedBy)
+	if err != nil {
+		return fmt.Errorf("medicationRepository.VerifyAdministration: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrAlreadyVerified
+	}
+
+	return nil
+}
+
+func (r *medicationRepository) ListAdministrationsByOrder(ctx context.Context, orderID string) ([]*models.MedicationAdministration, error) {
+	query := `SELECT ` + medicationAdministrationColumns + `
+		FROM medication_administrations
+		WHERE order_id = $1
+		ORDER BY administered_at DESC`
+
+	var administrations []*models.MedicationAdministration
+	if err := r.db.SelectContext(ctx, &administrations, query, orderID); err != nil {
+		return nil, fmt.Errorf("medicationRepository.ListAdministrationsByOrder: %w", err)
+	}
+	return administrations, nil
+}
+
+func (r *medicationRepository) ListAdministrationsByChild(ctx context.Context, childID string, limit, offset int) ([]*models.MedicationAdministration, error) {
+	query := `SELECT ` + medicationAdministrationColumns + `
+		FROM medication_administrations
+		WHERE child_id = $1
+		ORDER BY administered_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var administrations []*models.MedicationAdministration
+	if err := r.db.SelectContext(ctx, &administrations, query, childID, limit, offset); err != nil {
+		return nil, fmt.Errorf("medicationRepository.ListAdministrationsByChild: %w", err)
+	}
+	return administrations, nil
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 
 	"github.com/eduardohass/kids-api/internal/models"
-	"github.com/jmoiron/sqlx"
+	"github.com/eduardohass/kids-api/internal/tenant"
 )
 
 type NeedRepository interface {
@@ -15,10 +15,10 @@ type NeedRepository interface {
 }
 
 type needRepository struct {
-	db *sqlx.DB
+	db *tenant.DB
 }
 
-func NewNeedRepository(db *sqlx.DB) NeedRepository {
+func NewNeedRepository(db *tenant.DB) NeedRepository {
 	return &needRepository{db: db}
 }
 
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	GetByID(ctx context.Context, id string) (*models.Notification, error)
+	ListByAttendance(ctx context.Context, attendanceID string) ([]*models.Notification, error)
+	UpdateStatus(ctx context.Context, notification *models.Notification) error
+	CountPendingByChannel(ctx context.Context) ([]*models.ChannelBacklog, error)
+}
+
+type notificationRepository struct {
+	db *tenant.DB
+}
+
+func NewNotificationRepository(db *tenant.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+const notificationColumns = `
+	id,
+	COALESCE(attendance_id::text, '') AS attendance_id,
+	child_id,
+	COALESCE(caretaker_id::text, '') AS caretaker_id,
+	channel,
+	recipient,
+	reason,
+	COALESCE(subject, '') AS subject,
+	body,
+	status,
+	attempts,
+	COALESCE(last_error, '') AS last_error,
+	COALESCE(sent_by, '') AS sent_by,
+	sent_at,
+	created_at,
+	updated_at
+`
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	const query = `
+		INSERT INTO notifications (
+			attendance_id,
+			child_id,
+			caretaker_id,
+			channel,
+			recipient,
+			reason,
+			subject,
+			body,
+			status,
+			sent_by
+		) VALUES (NULLIF($1, '')::uuid, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		notification.AttendanceID,
+		notification.ChildID,
+		notification.CaretakerID,
+		notification.Channel,
+		notification.Recipient,
+		notification.Reason,
+		notification.Subject,
+		notification.Body,
+		notification.Status,
+		notification.SentBy,
+	).Scan(&notification.ID, &notification.CreatedAt, &notification.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("notificationRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) GetByID(ctx context.Context, id string) (*models.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE id = $1`
+
+	var notification models.Notification
+	if err := r.db.GetContext(ctx, &notification, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("notificationRepository.GetByID: %w", err)
+	}
+	return &notification, nil
+}
+
+func (r *notificationRepository) ListByAttendance(ctx context.Context, attendanceID string) ([]*models.Notification, error) {
+	query := `SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE attendance_id = $1
+		ORDER BY created_at DESC`
+
+	var notifications []*models.Notification
+	if err := r.db.SelectContext(ctx, &notifications, query, attendanceID); err != nil {
+		return nil, fmt.Errorf("notificationRepository.ListByAttendance: %w", err)
+	}
+	return notifications, nil
+}
+
+// UpdateStatus grava o resultado de uma tentativa de entrega.
+func (r *notificationRepository) UpdateStatus(ctx context.Context, notification *models.Notification) error {
+	const query = `
+		UPDATE notifications SET
+			status = $1,
+			attempts = $2,
+			last_error = NULLIF($3, ''),
+			sent_at = $4,
+			updated_at = NOW()
+		WHERE id = $5
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		notification.Status,
+		notification.Attempts,
+		notification.LastError,
+		notification.SentAt,
+		notification.ID,
+	).Scan(&notification.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("notificationRepository.UpdateStatus: %w", err)
+	}
+
+	return nil
+}
+
+// CountPendingByChannel conta as notificações ainda aguardando entrega.
+func (r *notificationRepository) CountPendingByChannel(ctx context.Context) ([]*models.ChannelBacklog, error) {
+	const query = `
+		SELECT channel, COUNT(*) AS count
+		FROM notifications
+		WHERE status = 'pending'
+		GROUP BY channel
+		ORDER BY channel
+	`
+
+	var counts []*models.ChannelBacklog
+	if err := r.db.SelectContext(ctx, &counts, query); err != nil {
+		return nil, fmt.Errorf("notificationRepository.CountPendingByChannel: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type PickupGrantRepository interface {
+	Create(ctx context.Context, grant *models.PickupGrant) error
+	GetByID(ctx context.Context, id string) (*models.PickupGrant, error)
+	ListByChild(ctx context.Context, childID string) ([]*models.PickupGrant, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type pickupGrantRepository struct {
+	db *tenant.DB
+}
+
+func NewPickupGrantRepository(db *tenant.DB) PickupGrantRepository {
+	return &pickupGrantRepository{db: db}
+}
+
+const pickupGrantColumns = `
+	id,
+	child_id,
+	granted_by_caretaker_id,
+	name,
+	COALESCE(phone, '') AS phone,
+	COALESCE(photo_url, '') AS photo_url,
+	valid_from,
+	valid_until,
+	COALESCE(event_id::text, '') AS event_id,
+	revoked_at,
+	created_at,
+	updated_at
+`
+
+func (r *pickupGrantRepository) Create(ctx context.Context, grant *models.PickupGrant) error {
+	const query = `
+		INSERT INTO pickup_grants (
+			child_id,
+			granted_by_caretaker_id,
+			name,
+			phone,
+			photo_url,
+			valid_from,
+			valid_until,
+			event_id
+		) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, NULLIF($8, '')::uuid)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		grant.ChildID,
+		grant.GrantedByCaretakerID,
+		grant.Name,
+		grant.Phone,
+		grant.PhotoURL,
+		grant.ValidFrom,
+		grant.ValidUntil,
+		grant.EventID,
+	).Scan(&grant.ID, &grant.CreatedAt, &grant.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("pickupGrantRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pickupGrantRepository) GetByID(ctx context.Context, id string) (*models.PickupGrant, error) {
+	query := `SELECT ` + pickupGrantColumns + ` FROM pickup_grants WHERE id = $1`
+
+	var grant models.PickupGrant
+	if err := r.db.GetContext(ctx, &grant, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("pickupGrantRepository.GetByID: %w", err)
+	}
+	return &grant, nil
+}
+
+func (r *pickupGrantRepository) ListByChild(ctx context.Context, childID string) ([]*models.PickupGrant, error) {
+	query := `SELECT ` + pickupGrantColumns + `
+		FROM pickup_grants
+		WHERE child_id = $1
+		ORDER BY created_at DESC`
+
+	var grants []*models.PickupGrant
+	if err := r.db.SelectContext(ctx, &grants, query, childID); err != nil {
+		return nil, fmt.Errorf("pickupGrantRepository.ListByChild: %w", err)
+	}
+	return grants, nil
+}
+
+func (r *pickupGrantRepository) Revoke(ctx context.Context, id string) error {
+	const query = `
+		UPDATE pickup_grants SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("pickupGrantRepository.Revoke: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eduardohass/kids-api/internal/pii"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// PIIColumn descreve uma coluna cifrada e os índices cegos derivados dela.
+type PIIColumn struct {
+	Table   string
+	Key     string
+	Column  string
+	Indexes map[string]func(string) string
+}
+
+// PIIColumns lista as colunas gravadas com pii.String ou pii.Strings.
+var PIIColumns = []PIIColumn{
+	{Table: "caretakers", Key: "id", Column: "email", Indexes: map[string]func(string) string{
+		"email_index": emailIndex,
+	}},
+	{Table: "caretakers", Key: "id", Column: "phone", Indexes: map[string]func(string) string{
+		"phone_index":       phoneIndex,
+		"phone_last4_index": phoneLast4Index,
+	}},
+	{Table: "caretakers", Key: "id", Column: "address"},
+	{Table: "households", Key: "id", Column: "address"},
+	{Table: "allergies", Key: "id", Column: "description"},
+	{Table: "child_restrictions", Key: "id", Column: "document_reference"},
+	{Table: "child_restrictions", Key: "id", Column: "notes"},
+	{Table: "pickup_grants", Key: "id", Column: "phone"},
+	{Table: "child_medical_profiles", Key: "child_id", Column: "doctor_phone"},
+	{Table: "child_medical_profiles", Key: "child_id", Column: "insurance_policy_number"},
+	{Table: "child_medical_profiles", Key: "child_id", Column: "conditions"},
+	{Table: "child_medical_profiles", Key: "child_id", Column: "notes"},
+	{Table: "child_emergency_contacts", Key: "id", Column: "phone"},
+	{Table: "child_emergency_contacts", Key: "id", Column: "alt_phone"},
+	{Table: "notifications", Key: "id", Column: "recipient"},
+	{Table: "notifications", Key: "id", Column: "body"},
+}
+
+// PIIRepository recifra os dados pessoais com a chave ativa.
+type PIIRepository interface {
+	ReencryptBatch(ctx context.Context, column PIIColumn, activePrefix string, limit int) (int, error)
+}
+
+type piiRepository struct {
+	db *tenant.DB
+}
+
+func NewPIIRepository(db *tenant.DB) PIIRepository {
+	return &piiRepository{db: db}
+}
+
+// ReencryptBatch recifra até limit valores que ainda estão em texto puro ou
+// cifrados com uma chave antiga, recalculando os índices cegos. Retorna quantos
+// registros foram alterados; zero indica que a coluna está em dia.
+func (r *piiRepository) ReencryptBatch(ctx context.Context, column PIIColumn, activePrefix string, limit int) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("piiRepository.ReencryptBatch: %w", err)
+	}
+	defer tx.Rollback()
+
+	selectQuery := fmt.Sprintf(`
+		SELECT %[1]s::text AS key, %[2]s AS value
+		FROM %[3]s
+		WHERE %[2]s <> '' AND %[2]s NOT LIKE $1 || '%%'
+		ORDER BY %[1]s
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, column.Key, column.Column, column.Table)
+
+	var rows []struct {
+		Key   string     `db:"key"`
+		Value pii.String `db:"value"`
+	}
+	if err := tx.SelectContext(ctx, &rows, selectQuery, activePrefix, limit); err != nil {
+		return 0, fmt.Errorf("piiRepository.ReencryptBatch: %s.%s: %w", column.Table, column.Column, err)
+	}
+
+	sets := []string{fmt.Sprintf("%s = $1", column.Column)}
+	indexes := make([]string, 0, len(column.Indexes))
+	for name := range column.Indexes {
+		indexes = append(indexes, name)
+		sets = append(sets, fmt.Sprintf("%s = NULLIF($%d, '')", name, len(sets)+1))
+	}
+	updateQuery := fmt.Sprintf(`UPDATE %s SET %s WHERE %s = $%d`,
+		column.Table, strings.Join(sets, ", "), column.Key, len(sets)+1)
+
+	for _, row := range rows {
+		args := []interface{}{row.Value}
+		for _, name := range indexes {
+			args = append(args, column.Indexes[name](string(row.Value)))
+		}
+		args = append(args, row.Key)
+
+		if _, err := tx.ExecContext(ctx, updateQuery, args...); err != nil {
+			return 0, fmt.Errorf("piiRepository.ReencryptBatch: %s.%s: %w", column.Table, column.Column, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("piiRepository.ReencryptBatch: %w", err)
+	}
+	return len(rows), nil
+}
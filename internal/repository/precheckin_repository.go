@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// ErrAlreadyRedeemed is returned when a pre-check-in code is used a second time.
+var ErrAlreadyRedeemed = errors.New("pre-check-in code was already used")
+
+type PreCheckInRepository interface {
+	Redeem(ctx context.Context, nonce, caretakerID string, expiresAt time.Time) error
+	Release(ctx context.Context, nonce string) error
+	PurgeExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type preCheckInRepository struct {
+	db *tenant.DB
+}
+
+func NewPreCheckInRepository(db *tenant.DB) PreCheckInRepository {
+	return &preCheckInRepository{db: db}
+}
+
+// Redeem marca o nonce como usado. Uma segunda tentativa com o mesmo nonce
+// retorna ErrAlreadyRedeemed.
+func (r *preCheckInRepository) Redeem(ctx context.Context, nonce, caretakerID string, expiresAt time.Time) error {
+	const query = `
+		INSERT INTO precheckin_redemptions (nonce, caretaker_id, expires_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3)
+		ON CONFLICT (nonce) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, nonce, caretakerID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("preCheckInRepository.Redeem: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrAlreadyRedeemed
+	}
+
+	return nil
+}
+
+// Release libera o nonce para que o QR possa ser lido de novo, quando o
+// check-in que o consumiu não foi concluído.
+func (r *preCheckInRepository) Release(ctx context.Context, nonce string) error {
+	const query = `DELETE FROM precheckin_redemptions WHERE nonce = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, nonce); err != nil {
+		return fmt.Errorf("preCheckInRepository.Release: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired remove os nonces de QR codes já expirados: a assinatura
+// recusa esses códigos, então o registro não é mais necessário.
+func (r *preCheckInRepository) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	const query = `DELETE FROM precheckin_redemptions WHERE expires_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("preCheckInRepository.PurgeExpired: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("preCheckInRepository.PurgeExpired: %w", err)
+	}
+	return int(rows), nil
+}
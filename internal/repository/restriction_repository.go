@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type RestrictionRepository interface {
+	Create(ctx context.Context, restriction *models.ChildRestriction) error
+	GetByID(ctx context.Context, id string) (*models.ChildRestriction, error)
+	Revoke(ctx context.Context, id string) error
+	ListByChild(ctx context.Context, childID string) ([]*models.ChildRestriction, error)
+	HasActive(ctx context.Context, childID string) (bool, error)
+	FindActiveMatch(ctx context.Context, childID, caretakerID, name string) (*models.ChildRestriction, error)
+}
+
+type restrictionRepository struct {
+	db *tenant.DB
+}
+
+func NewRestrictionRepository(db *tenant.DB) RestrictionRepository {
+	return &restrictionRepository{db: db}
+}
+
+const restrictionColumns = `
+	id,
+	child_id,
+	COALESCE(restricted_caretaker_id::text, '') AS restricted_caretaker_id,
+	COALESCE(restricted_name, '') AS restricted_name,
+	COALESCE(document_reference, '') AS document_reference,
+	COALESCE(notes, '') AS notes,
+	expires_at,
+	revoked_at,
+	COALESCE(created_by, '') AS created_by,
+	created_at,
+	updated_at
+`
+
+// activeRestriction filtra restrições não revogadas e ainda dentro da validade.
+const activeRestriction = `revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())`
+
+func (r *restrictionRepository) Create(ctx context.Context, restriction *models.ChildRestriction) error {
+	const query = `
+		INSERT INTO child_restrictions (
+			child_id,
+			restricted_caretaker_id,
+			restricted_name,
+			document_reference,
+			notes,
+			expires_at,
+			created_by
+		) VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		restriction.ChildID,
+		restriction.RestrictedCaretakerID,
+		restriction.RestrictedName,
+		restriction.DocumentReference,
+		restriction.Notes,
+		restriction.ExpiresAt,
+		restriction.CreatedBy,
+	).Scan(&restriction.ID, &restriction.CreatedAt, &restriction.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("restrictionRepository.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restrictionRepository) GetByID(ctx context.Context, id string) (*models.ChildRestriction, error) {
+	query := `SELECT ` + restrictionColumns + ` FROM child_restrictions WHERE id = $1`
+
+	var restriction models.ChildRestriction
+	if err := r.db.GetContext(ctx, &restriction, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("restrictionRepository.GetByID: %w", err)
+	}
+	return &restriction, nil
+}
+
+func (r *restrictionRepository) Revoke(ctx context.Context, id string) error {
+	const query = `
+		UPDATE child_restrictions SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("restrictionRepository.Revoke: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *restrictionRepository) ListByChild(ctx context.Context, childID string) ([]*models.ChildRestriction, error) {
+	query := `SELECT ` + restrictionColumns + `
+		FROM child_restrictions
+		WHERE child_id = $1
+		ORDER BY created_at DESC`
+
+	var restrictions []*models.ChildRestriction
+	if err := r.db.SelectContext(ctx, &restrictions, query, childID); err != nil {
+		return nil, fmt.Errorf("restrictionRepository.ListByChild: %w", err)
+	}
+	return restrictions, nil
+}
+
+func (r *restrictionRepository) HasActive(ctx context.Context, childID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM child_restrictions WHERE child_id = $1 AND ` + activeRestriction + `)`
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, childID); err != nil {
+		return false, fmt.Errorf("restrictionRepository.HasActive: %w", err)
+	}
+	return exists, nil
+}
+
+// FindActiveMatch procura uma restrição ativa que impeça a retirada pela pessoa
+// informada, seja pelo ID do responsável ou pelo nome (sem acentos e caixa).
+// Retorna ErrNotFound quando a retirada não é restrita.
+func (r *restrictionRepository) FindActiveMatch(ctx context.Context, childID, caretakerID, name string) (*models.ChildRestriction, error) {
+	query := `SELECT ` + restrictionColumns + `
+		FROM child_restrictions
+		WHERE child_id = $1
+			AND ` + activeRestriction + `
+			AND (
+				($2 <> '' AND restricted_caretaker_id = NULLIF($2, '')::uuid)
+				OR ($3 <> '' AND f_unaccent(lower(restricted_name)) = f_unaccent(lower($3)))
+				OR ($2 <> '' AND restricted_name IS NOT NULL AND EXISTS (
+					SELECT 1 FROM caretakers ct
+					WHERE ct.id = NULLIF($2, '')::uuid
+						AND f_unaccent(lower(ct.name)) = f_unaccent(lower(restricted_name))
+				))
+			)
+		LIMIT 1`
+
+	var restriction models.ChildRestriction
+	if err := r.db.GetContext(ctx, &restriction, query, childID, caretakerID, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("restrictionRepository.FindActiveMatch: %w", err)
+	}
+	return &restriction, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// RetentionRepository persiste as regras de retenção do campus e as execuções.
+type RetentionRepository interface {
+	ListPolicies(ctx context.Context) ([]*models.RetentionPolicy, error)
+	SavePolicy(ctx context.Context, policy *models.RetentionPolicy) error
+	CreateRun(ctx context.Context, run *models.RetentionRun) error
+	GetRun(ctx context.Context, id string) (*models.RetentionRun, error)
+	ListRuns(ctx context.Context, limit, offset int) ([]*models.RetentionRun, error)
+	StartRun(ctx context.Context, id string) error
+	CompleteRun(ctx context.Context, id string, result []byte) error
+	FailRun(ctx context.Context, id, lastError string) error
+}
+
+type retentionRepository struct {
+	db *tenant.DB
+}
+
+func NewRetentionRepository(db *tenant.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+const retentionRunColumns = `
+	id,
+	dry_run,
+	status,
+	requested_by,
+	result,
+	COALESCE(last_error, '') AS last_error,
+	created_at,
+	started_at,
+	completed_at
+`
+
+// ListPolicies retorna apenas as regras já configuradas no campus.
+func (r *retentionRepository) ListPolicies(ctx context.Context) ([]*models.RetentionPolicy, error) {
+	const query = `
+		SELECT id, rule, after_days, enabled, updated_by, updated_at
+		FROM retention_policies
+		ORDER BY rule
+	`
+
+	var policies []*models.RetentionPolicy
+	if err := r.db.SelectContext(ctx, &policies, query); err != nil {
+		return nil, fmt.Errorf("retentionRepository.ListPolicies: %w", err)
+	}
+	return policies, nil
+}
+
+// SavePolicy cria ou substitui a configuração da regra.
+func (r *retentionRepository) SavePolicy(ctx context.Context, policy *models.RetentionPolicy) error {
+	const query = `
+		INSERT INTO retention_policies (rule, after_days, enabled, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, rule) DO UPDATE SET
+			after_days = EXCLUDED.after_days,
+			enabled = EXCLUDED.enabled,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING id, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		policy.Rule,
+		policy.AfterDays,
+		policy.Enabled,
+		policy.UpdatedBy,
+	).Scan(&policy.ID, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("retentionRepository.SavePolicy: %w", err)
+	}
+	return nil
+}
+
+func (r *retentionRepository) CreateRun(ctx context.Context, run *models.RetentionRun) error {
+	const query = `
+		INSERT INTO retention_runs (dry_run, requested_by)
+		VALUES ($1, $2)
+		RETURNING id, status, created_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, run.DryRun, run.RequestedBy).Scan(&run.ID, &run.Status, &run.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("retentionRepository.CreateRun: %w", err)
+	}
+	return nil
+}
+
+func (r *retentionRepository) GetRun(ctx context.Context, id string) (*models.RetentionRun, error) {
+	query := `SELECT ` + retentionRunColumns + ` FROM retention_runs WHERE id = $1`
+
+	var run models.RetentionRun
+	if err := r.db.GetContext(ctx, &run, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("retentionRepository.GetRun: %w", err)
+	}
+	return &run, nil
+}
+
+func (r *retentionRepository) ListRuns(ctx context.Context, limit, offset int) ([]*models.RetentionRun, error) {
+	query := `SELECT ` + retentionRunColumns + `
+		FROM retention_runs
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	var runs []*models.RetentionRun
+	if err := r.db.SelectContext(ctx, &runs, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("retentionRepository.ListRuns: %w", err)
+	}
+	return runs, nil
+}
+
+// StartRun marca a execução como em andamento. Execuções concluídas não são
+// repetidas quando o trabalho da fila é reprocessado.
+func (r *retentionRepository) StartRun(ctx context.Context, id string) error {
+	const query = `
+		UPDATE retention_runs
+		SET status = 'processing', started_at = COALESCE(started_at, NOW()), last_error = NULL
+		WHERE id = $1 AND status <> 'completed'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("retentionRepository.StartRun: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retentionRepository.StartRun: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *retentionRepository) CompleteRun(ctx context.Context, id string, result []byte) error {
+	const query = `
+		UPDATE retention_runs
+		SET status = 'completed', result = $1, last_error = NULL, completed_at = NOW()
+		WHERE id = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, result, id); err != nil {
+		return fmt.Errorf("retentionRepository.CompleteRun: %w", err)
+	}
+	return nil
+}
+
+func (r *retentionRepository) FailRun(ctx context.Context, id, lastError string) error {
+	const query = `UPDATE retention_runs SET status = 'failed', last_error = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, id); err != nil {
+		return fmt.Errorf("retentionRepository.FailRun: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// ErrRollCallInProgress is returned when the location already has an open roll call.
+var ErrRollCallInProgress = errors.New("location already has an open roll call")
+
+type RollCallRepository interface {
+	Start(ctx context.Context, rollCall *models.RollCall) error
+	GetByID(ctx context.Context, id string) (*models.RollCall, error)
+	List(ctx context.Context, status string, limit, offset int) ([]*models.RollCall, error)
+	ListEntries(ctx context.Context, rollCallID string) ([]*models.RollCallEntry, error)
+	MarkAccounted(ctx context.Context, rollCallID, childID, accountedBy string) (*models.RollCallEntry, error)
+	Close(ctx context.Context, rollCall *models.RollCall) error
+}
+
+type rollCallRepository struct {
+	db *tenant.DB
+}
+
+func NewRollCallRepository(db *tenant.DB) RollCallRepository {
+	return &rollCallRepository{db: db}
+}
+
+const rollCallColumns = `
+	id,
+	location_id,
+	status,
+	started_by,
+	started_at,
+	COALESCE(closed_by, '') AS closed_by,
+	closed_at
+`
+
+// Start abre a chamada e copia, na mesma transação, as presenças abertas do local.
+func (r *rollCallRepository) Start(ctx context.Context, rollCall *models.RollCall) error {
+	const query = `
+		INSERT INTO roll_calls (location_id, status, started_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, started_at
+	`
+
+	const snapshotQuery = `
+		INSERT INTO roll_call_entries (roll_call_id, attendance_id, child_id, group_id)
+		SELECT $1, id, child_id, group_id
+		FROM attendance
+		WHERE location_id = $2 AND checked_out_at IS NULL
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("rollCallRepository.Start: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(ctx, query, rollCall.LocationID, rollCall.Status, rollCall.StartedBy).
+		Scan(&rollCall.ID, &rollCall.StartedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrRollCallInProgress
+		}
+		return fmt.Errorf("rollCallRepository.Start: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, snapshotQuery, rollCall.ID, rollCall.LocationID); err != nil {
+		return fmt.Errorf("rollCallRepository.Start: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("rollCallRepository.Start: %w", err)
+	}
+	return nil
+}
+
+func (r *rollCallRepository) GetByID(ctx context.Context, id string) (*models.RollCall, error) {
+	query := `SELECT ` + rollCallColumns + ` FROM roll_calls WHERE id = $1`
+
+	var rollCall models.RollCall
+	if err := r.db.GetContext(ctx, &rollCall, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("rollCallRepository.GetByID: %w", err)
+	}
+	return &rollCall, nil
+}
+
+func (r *rollCallRepository) List(ctx context.Context, status string, limit, offset int) ([]*models.RollCall, error) {
+	query := `SELECT ` + rollCallColumns + ` FROM roll_calls
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var rollCalls []*models.RollCall
+	if err := r.db.SelectContext(ctx, &rollCalls, query, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("rollCallRepository.List: %w", err)
+	}
+	return rollCalls, nil
+}
+
+const rollCallEntryQuery = `
+	SELECT
+		e.roll_call_id,
+		e.attendance_id,
+		e.child_id,
+		c.name AS child_name,
+		COALESCE(e.group_id::text, '') AS group_id,
+		COALESCE(g.name, '') AS group_name,
+		COALESCE(e.accounted_by, '') AS accounted_by,
+		e.accounted_at
+	FROM roll_call_entries e
+	JOIN children c ON c.id = e.child_id
+	LEFT JOIN groups g ON g.id = e.group_id
+`
+
+func (r *rollCallRepository) ListEntries(ctx context.Context, rollCallID string) ([]*models.RollCallEntry, error) {
+	query := rollCallEntryQuery + ` WHERE e.roll_call_id = $1 ORDER BY g.name, c.name`
+
+	var entries []*models.RollCallEntry
+	if err := r.db.SelectContext(ctx, &entries, query, rollCallID); err != nil {
+		return nil, fmt.Errorf("rollCallRepository.ListEntries: %w", err)
+	}
+	return entries, nil
+}
+
+// MarkAccounted marca a criança como localizada. Marcar de novo mantém o
+// primeiro registro.
+func (r *rollCallRepository) MarkAccounted(ctx context.Context, rollCallID, childID, accountedBy string) (*models.RollCallEntry, error) {
+	const query = `
+		UPDATE roll_call_entries SET
+			accounted_by = COALESCE(accounted_by, $3),
+			accounted_at = COALESCE(accounted_at, NOW())
+		WHERE roll_call_id = $1 AND child_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, rollCallID, childID, accountedBy)
+	if err != nil {
+		return nil, fmt.Errorf("rollCallRepository.MarkAccounted: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrNotFound
+	}
+
+	var entry models.RollCallEntry
+	err = r.db.GetContext(ctx, &entry, rollCallEntryQuery+` WHERE e.roll_call_id = $1 AND e.child_id = $2`, rollCallID, childID)
+	if err != nil {
+		return nil, fmt.Errorf("rollCallRepository.MarkAccounted: %w", err)
+	}
+	return &entry, nil
+}
+
+// Close encerra a chamada; retorna ErrNotFound se ela não está mais aberta.
+func (r *rollCallRepository) Close(ctx context.Context, rollCall *models.RollCall) error {
+	const query = `
+		UPDATE roll_calls SET
+			status = $2,
+			closed_by = $3,
+			closed_at = NOW()
+		WHERE id = $1 AND status = $4
+		RETURNING closed_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, rollCall.ID, models.RollCallClosed, rollCall.ClosedBy, models.RollCallOpen).
+		Scan(&rollCall.ClosedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("rollCallRepository.Close: %w", err)
+	}
+	rollCall.Status = models.RollCallClosed
+	return nil
+}
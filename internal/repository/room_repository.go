@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type RoomRepository interface {
+	Create(ctx context.Context, room *models.Room) error
+	GetByID(ctx context.Context, id string) (*models.Room, error)
+	Update(ctx context.Context, room *models.Room) error
+	Delete(ctx context.Context, id string) error
+	ListByLocation(ctx context.Context, locationID string) ([]*models.Room, error)
+	SetAssignments(ctx context.Context, eventID, groupID string, roomIDs []string) error
+	ListAssignments(ctx context.Context, eventID string) ([]*models.RoomAssignment, error)
+	RoomsForGroup(ctx context.Context, eventID, groupID string) ([]*models.Room, error)
+	SetStaff(ctx context.Context, eventID, roomID string, volunteerIDs []string) error
+	ListStaff(ctx context.Context, eventID, roomID string) ([]string, error)
+}
+
+type roomRepository struct {
+	db *tenant.DB
+}
+
+func NewRoomRepository(db *tenant.DB) RoomRepository {
+	return &roomRepository{db: db}
+}
+
+const roomColumns = `
+	r.id,
+	r.location_id,
+	r.name,
+	COALESCE(r.floor, '') AS floor,
+	r.capacity,
+	r.children_per_volunteer,
+	r.wheelchair_accessible,
+	COALESCE(r.accessibility_notes, '') AS accessibility_notes,
+	r.version,
+	r.created_at,
+	r.updated_at
+`
+
+func (r *roomRepository) Create(ctx context.Context, room *models.Room) error {
+	const query = `
+		INSERT INTO rooms (
+			location_id,
+			name,
+			floor,
+			capacity,
+			children_per_volunteer,
+			wheelchair_accessible,
+			accessibility_notes
+		) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, NULLIF($7, ''))
+		RETURNING id, version, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		room.LocationID,
+		room.Name,
+		room.Floor,
+		room.Capacity,
+		room.ChildrenPerVolunteer,
+		room.WheelchairAccessible,
+		room.AccessibilityNotes,
+	).Scan(&room.ID, &room.Version, &room.CreatedAt, &room.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("roomRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) GetByID(ctx context.Context, id string) (*models.Room, error) {
+	query := `SELECT ` + roomColumns + ` FROM rooms r WHERE r.id = $1 AND r.deleted_at IS NULL`
+
+	var room models.Room
+	if err := r.db.GetContext(ctx, &room, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("roomRepository.GetByID: %w", err)
+	}
+	return &room, nil
+}
+
+func (r *roomRepository) Update(ctx context.Context, room *models.Room) error {
+	const query = `
+		UPDATE rooms SET
+			name = $1,
+			floor = NULLIF($2, ''),
+			capacity = $3,
+			children_per_volunteer = $4,
+			wheelchair_accessible = $5,
+			accessibility_notes = NULLIF($6, ''),
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = $7 AND deleted_at IS NULL AND ($8 = 0 OR version = $8)
+		RETURNING location_id, version, updated_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		room.Name,
+		room.Floor,
+		room.Capacity,
+		room.ChildrenPerVolunteer,
+		room.WheelchairAccessible,
+		room.AccessibilityNotes,
+		room.ID,
+		room.Version,
+	).Scan(&room.LocationID, &room.Version, &room.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "rooms", room.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("roomRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) Delete(ctx context.Context, id string) error {
+	const query = `UPDATE rooms SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("roomRepository.Delete: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *roomRepository) ListByLocation(ctx context.Context, locationID string) ([]*models.Room, error) {
+	query := `SELECT ` + roomColumns + ` FROM rooms r
+		WHERE r.location_id = $1 AND r.deleted_at IS NULL
+		ORDER BY r.floor, r.name`
+
+	var rooms []*models.Room
+	if err := r.db.SelectContext(ctx, &rooms, query, locationID); err != nil {
+		return nil, fmt.Errorf("roomRepository.ListByLocation: %w", err)
+	}
+	return rooms, nil
+}
+
+// SetAssignments substitui as salas do grupo no evento; a ordem da lista
+// define a ordem de preenchimento.
+func (r *roomRepository) SetAssignments(ctx context.Context, eventID, groupID string, roomIDs []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("roomRepository.SetAssignments: %w", err)
+	}
+	defer tx.Rollback()
+
+	const deleteQuery = `DELETE FROM room_assignments WHERE event_id = $1 AND group_id = $2`
+	if _, err := tx.ExecContext(ctx, deleteQuery, eventID, groupID); err != nil {
+		return fmt.Errorf("roomRepository.SetAssignments: %w", err)
+	}
+
+	const insertQuery = `
+		INSERT INTO room_assignments (event_id, group_id, room_id, position)
+		VALUES ($1, $2, $3, $4)
+	`
+	for i, roomID := range roomIDs {
+		if _, err := tx.ExecContext(ctx, insertQuery, eventID, groupID, roomID, i+1); err != nil {
+			return fmt.Errorf("roomRepository.SetAssignments: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("roomRepository.SetAssignments: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) ListAssignments(ctx context.Context, eventID string) ([]*models.RoomAssignment, error) {
+	const query = `
+		SELECT a.event_id, a.group_id, a.room_id, r.name AS room_name, a.position
+		FROM room_assignments a
+		JOIN rooms r ON r.id = a.room_id
+		WHERE a.event_id = $1 AND r.deleted_at IS NULL
+		ORDER BY a.group_id, a.position
+	`
+
+	var assignments []*models.RoomAssignment
+	if err := r.db.SelectContext(ctx, &assignments, query, eventID); err != nil {
+		return nil, fmt.Errorf("roomRepository.ListAssignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// RoomsForGroup retorna as salas do grupo no evento, na ordem de preenchimento.
+func (r *roomRepository) RoomsForGroup(ctx context.Context, eventID, groupID string) ([]*models.Room, error) {
+	query := `SELECT ` + roomColumns + `
+		FROM room_assignments a
+		JOIN rooms r ON r.id = a.room_id
+		WHERE a.event_id = $1 AND a.group_id = $2 AND r.deleted_at IS NULL
+		ORDER BY a.position`
+
+	var rooms []*models.Room
+	if err := r.db.SelectContext(ctx, &rooms, query, eventID, groupID); err != nil {
+		return nil, fmt.Errorf("roomRepository.RoomsForGroup: %w", err)
+	}
+	return rooms, nil
+}
+
+// SetStaff substitui os voluntários escalados na sala durante o evento.
+func (r *roomRepository) SetStaff(ctx context.Context, eventID, roomID string, volunteerIDs []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("roomRepository.SetStaff: %w", err)
+	}
+	defer tx.Rollback()
+
+	const deleteQuery = `DELETE FROM room_staff WHERE event_id = $1 AND room_id = $2`
+	if _, err := tx.ExecContext(ctx, deleteQuery, eventID, roomID); err != nil {
+		return fmt.Errorf("roomRepository.SetStaff: %w", err)
+	}
+
+	const insertQuery = `
+		INSERT INTO room_staff (event_id, room_id, volunteer_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+	for _, volunteerID := range volunteerIDs {
+		if _, err := tx.ExecContext(ctx, insertQuery, eventID, roomID, volunteerID); err != nil {
+			return fmt.Errorf("roomRepository.SetStaff: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("roomRepository.SetStaff: %w", err)
+	}
+	return nil
+}
+
+func (r *roomRepository) ListStaff(ctx context.Context, eventID, roomID string) ([]string, error) {
+	const query = `SELECT volunteer_id FROM room_staff WHERE event_id = $1 AND room_id = $2`
+
+	volunteerIDs := []string{}
+	if err := r.db.SelectContext(ctx, &volunteerIDs, query, eventID, roomID); err != nil {
+		return nil, fmt.Errorf("roomRepository.ListStaff: %w", err)
+	}
+	return volunteerIDs, nil
+}
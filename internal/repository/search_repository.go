@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+type SearchRepository interface {
+	Search(ctx context.Context, term string, limit int) ([]*models.SearchResult, error)
+}
+
+type searchRepository struct {
+	db *tenant.DB
+}
+
+func NewSearchRepository(db *tenant.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search busca crianças, responsáveis e voluntários ignorando acentos e
+// tolerando erros de digitação (pg_trgm). Responsáveis também são encontrados
+// pelo e-mail completo ou pelos últimos 4 dígitos do telefone; como esses
+// campos são cifrados, a comparação é exata, pelos índices cegos.
+func (r *searchRepository) Search(ctx context.Context, term string, limit int) ([]*models.SearchResult, error) {
+	const query = `
+		WITH q AS (
+			SELECT
+				f_unaccent(lower($1)) AS termo
+		)
+		SELECT
+			'child' AS tipo,
+			c.id,
+			c.name AS nome,
+			'' AS detalhe,
+			word_similarity(q.termo, f_unaccent(lower(c.name))) AS score
+		FROM children c, q
+		WHERE c.deleted_at IS NULL
+			AND q.termo <% f_unaccent(lower(c.name))
+
+		UNION ALL
+
+		SELECT
+			'caretaker' AS tipo,
+			ct.id,
+			ct.name,
+			COALESCE(ct.email, '') AS detalhe,
+			GREATEST(
+				word_similarity(q.termo, f_unaccent(lower(ct.name))),
+				CASE WHEN ct.email_index = $3 OR ct.phone_last4_index = $4 THEN 1 ELSE 0 END
+			) AS score
+		FROM caretakers ct, q
+		WHERE ct.deleted_at IS NULL
+			AND (
+				q.termo <% f_unaccent(lower(ct.name))
+				OR ct.email_index = $3
+				OR ct.phone_last4_index = $4
+			)
+
+		UNION ALL
+
+		SELECT
+			'volunteer' AS tipo,
+			v.id,
+			v.name,
+			COALESCE(v.email, '') AS detalhe,
+			GREATEST(
+				word_similarity(q.termo, f_unaccent(lower(v.name))),
+				similarity(q.termo, lower(v.email))
+			) AS score
+		FROM volunteers v, q
+		WHERE v.deleted_at IS NULL
+			AND (
+				q.termo <% f_unaccent(lower(v.name))
+				OR lower(v.email) % q.termo
+			)
+
+		ORDER BY score DESC, nome
+		LIMIT $2
+	`
+
+	var results []*models.SearchResult
+	last4 := ""
+	if digits := onlyDigits(term); len(digits) == 4 {
+		last4 = phoneLast4Index(digits)
+	}
+
+	if err := r.db.SelectContext(ctx, &results, query, term, limit, emailIndex(term), last4); err != nil {
+		return nil, fmt.Errorf("searchRepository.Search: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// purge executa a exclusão física de um registro de table, desde que ele já
+// tenha sido excluído logicamente antes de deletedBefore. As tabelas de
+// associação são removidas em cascata pelo banco.
+func purge(ctx context.Context, db *tenant.DB, table, id string, deletedBefore time.Time) error {
+	selectQuery := fmt.Sprintf(`SELECT deleted_at FROM %s WHERE id = $1`, table)
+
+	var deletedAt sql.NullTime
+	if err := db.GetContext(ctx, &deletedAt, selectQuery, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("repository.purge(%s): %w", table, err)
+	}
+
+	if !deletedAt.Valid || !deletedAt.Time.Before(deletedBefore) {
+		return ErrPurgeNotAllowed
+	}
+
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND deleted_at < $2`, table)
+	result, err := db.ExecContext(ctx, deleteQuery, id, deletedBefore)
+	if err != nil {
+		return fmt.Errorf("repository.purge(%s): %w", table, err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrPurgeNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package repository
+
+// currentTenant é o tenant definido na transação por tenant.DB. Tabelas sem
+// row-level security (quiosques, fila de trabalhos) filtram explicitamente por
+// ele nas consultas feitas em nome de um usuário.
+const currentTenant = `NULLIF(current_setting('app.tenant_id', true), '')::uuid`
@@ -0,0 +1,617 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/pii"
+	"github.com/eduardohass/kids-api/internal/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Os testes de isolamento rodam contra um Postgres real, apontado por
+// TEST_DATABASE_URL. O papel precisa de CREATEDB e não pode ser superusuário
+// nem ter BYPASSRLS, como o papel da API em produção: o banco de teste é
+// criado, migrado e removido a cada execução.
+
+// isolationDB cria um banco vazio, aplica as migrações e o remove ao final.
+func isolationDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set; skipping Postgres integration tests")
+	}
+
+	admin, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer admin.Close()
+
+	var privileged bool
+	if err := admin.Get(&privileged, `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`); err != nil {
+		t.Fatalf("check role: %v", err)
+	}
+	if privileged {
+		t.Fatal("TEST_DATABASE_URL uses a superuser or BYPASSRLS role; row-level security would not apply")
+	}
+
+	name := fmt.Sprintf("kids_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(`CREATE DATABASE ` + pq.QuoteIdentifier(name)); err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+
+	testDSN, err := withDatabase(dsn, name)
+	if err != nil {
+		t.Fatalf("database url: %v", err)
+	}
+	db, err := sqlx.Connect("postgres", testDSN)
+	if err != nil {
+		t.Fatalf("connect to %s: %v", name, err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		cleanup, err := sqlx.Connect("postgres", dsn)
+		if err != nil {
+			t.Logf("drop database %s: %v", name, err)
+			return
+		}
+		defer cleanup.Close()
+		if _, err := cleanup.Exec(`DROP DATABASE IF EXISTS ` + pq.QuoteIdentifier(name) + ` WITH (FORCE)`); err != nil {
+			t.Logf("drop database %s: %v", name, err)
+		}
+	})
+
+	files, err := filepath.Glob(filepath.Join("..", "migrations", "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		script, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		if _, err := db.Exec(string(script)); err != nil {
+			t.Fatalf("apply %s: %v", filepath.Base(file), err)
+		}
+	}
+
+	return db
+}
+
+// withDatabase troca o banco da string de conexão, em formato URL ou chave=valor.
+func withDatabase(dsn, name string) (string, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", err
+		}
+		u.Path = "/" + name
+		return u.String(), nil
+	}
+	return dsn + " dbname=" + name, nil
+}
+
+func createTenant(t *testing.T, db *sqlx.DB, name string) string {
+	t.Helper()
+	var id string
+	if err := db.Get(&id, `INSERT INTO tenants (name) VALUES ($1) RETURNING id`, name); err != nil {
+		t.Fatalf("create tenant %s: %v", name, err)
+	}
+	return id
+}
+
+// isolationRepos reúne os repositórios exercitados pelos testes.
+type isolationRepos struct {
+	groups      GroupRepository
+	children    ChildRepository
+	caretakers  CaretakerRepository
+	volunteers  VolunteerRepository
+	households  HouseholdRepository
+	restriction RestrictionRepository
+	grants      PickupGrantRepository
+	medical     MedicalProfileRepository
+	medications MedicationRepository
+	incidents   IncidentRepository
+	notices     NotificationRepository
+	locations   LocationRepository
+	kiosks      KioskRepository
+	jobs        JobRepository
+	search      SearchRepository
+}
+
+func newIsolationRepos(db *tenant.DB) isolationRepos {
+	return isolationRepos{
+		groups:      NewGroupRepository(db),
+		children:    NewChildRepository(db),
+		caretakers:  NewCaretakerRepository(db),
+		volunteers:  NewVolunteerRepository(db),
+		households:  NewHouseholdRepository(db),
+		restriction: NewRestrictionRepository(db),
+		grants:      NewPickupGrantRepository(db),
+		medical:     NewMedicalProfileRepository(db),
+		medications: NewMedicationRepository(db),
+		incidents:   NewIncidentRepository(db),
+		notices:     NewNotificationRepository(db),
+		locations:   NewLocationRepository(db),
+		kiosks:      NewKioskRepository(db),
+		jobs:        NewJobRepository(db),
+		search:      NewSearchRepository(db),
+	}
+}
+
+// tenantSeed guarda os registros criados para um campus.
+type tenantSeed struct {
+	group        *models.Group
+	child        *models.Child
+	caretaker    *models.Caretaker
+	volunteer    *models.Volunteer
+	household    *models.Household
+	restriction  *models.ChildRestriction
+	grant        *models.PickupGrant
+	order        *models.MedicationOrder
+	incident     *models.Incident
+	notification *models.Notification
+	location     *models.Location
+	kiosk        *models.KioskDevice
+	job          *models.Job
+}
+
+// seedTenant cria um registro de cada tipo; phone distingue os responsáveis
+// dos dois campus na busca por telefone.
+func seedTenant(t *testing.T, ctx context.Context, repos isolationRepos, label, phone string) *tenantSeed {
+	t.Helper()
+	must := func(what string, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("seed %s %s: %v", label, what, err)
+		}
+	}
+
+	now := time.Now()
+	later := now.Add(24 * time.Hour)
+	s := &tenantSeed{}
+
+	s.group = &models.Group{Name: "Berçário " + label, AgeRange: "0-2"}
+	must("group", repos.groups.Create(ctx, s.group))
+
+	s.child = &models.Child{
+		Name:      "Criança " + label,
+		BirthDate: now.AddDate(-1, 0, 0),
+		Gender:    "F",
+		GroupID:   s.group.ID,
+	}
+	must("child", repos.children.Create(ctx, s.child))
+
+	s.caretaker = &models.Caretaker{
+		Name:    "Responsável " + label,
+		Email:   pii.String("resp-" + label + "@example.com"),
+		Phone:   pii.String(phone),
+		Address: pii.String("Rua " + label + ", 1"),
+	}
+	must("caretaker", repos.caretakers.Create(ctx, s.caretaker))
+
+	s.volunteer = &models.Volunteer{Name: "Voluntário " + label, Email: "vol-" + label + "@example.com"}
+	must("volunteer", repos.volunteers.Create(ctx, s.volunteer))
+
+	s.household = &models.Household{Name: "Família " + label, Address: pii.String("Rua " + label + ", 1"), PrimaryContactID: s.caretaker.ID}
+	must("household", repos.households.Create(ctx, s.household))
+	must("household child", repos.households.AddChild(ctx, s.household.ID, s.child.ID))
+	must("household caretaker", repos.households.AddCaretaker(ctx, s.household.ID, s.caretaker.ID))
+
+	s.restriction = &models.ChildRestriction{ChildID: s.child.ID, RestrictedName: "Fulano " + label, Notes: "Ordem judicial", CreatedBy: "auth0|staff"}
+	must("restriction", repos.restriction.Create(ctx, s.restriction))
+
+	s.grant = &models.PickupGrant{ChildID: s.child.ID, GrantedByCaretakerID: s.caretaker.ID, Name: "Avó " + label, ValidFrom: &now, ValidUntil: &later}
+	must("pickup grant", repos.grants.Create(ctx, s.grant))
+
+	must("medical profile", repos.medical.Save(ctx, &models.MedicalProfile{
+		ChildID:    s.child.ID,
+		Conditions: []string{"Asma"},
+		Notes:      "Bombinha na mochila",
+		UpdatedBy:  "auth0|staff",
+	}))
+
+	s.order = &models.MedicationOrder{
+		ChildID:            s.child.ID,
+		Drug:               "Paracetamol",
+		Dose:               "5 ml",
+		Schedule:           "8/8h",
+		ConsentDocumentURL: "https://example.com/termo.pdf",
+		ConsentExpiresAt:   later,
+		CreatedBy:          "auth0|staff",
+	}
+	must("medication order", repos.medications.CreateOrder(ctx, s.order))
+
+	s.incident = &models.Incident{
+		ChildID:     s.child.ID,
+		GroupID:     s.group.ID,
+		Type:        models.IncidentInjury,
+		Description: "Caiu no parquinho",
+		Witnesses:   []string{"Ana"},
+		OccurredAt:  now,
+		Status:      models.IncidentDraft,
+		ReportedBy:  "auth0|staff",
+	}
+	must("incident", repos.incidents.Create(ctx, s.incident))
+
+	s.notification = &models.Notification{
+		ChildID:     s.child.ID,
+		CaretakerID: s.caretaker.ID,
+		Channel:     "email",
+		Recipient:   s.caretaker.Email,
+		Reason:      "diaper",
+		Body:        "Venha até a sala",
+		Status:      models.NotificationPending,
+	}
+	must("notification", repos.notices.Create(ctx, s.notification))
+
+	s.location = &models.Location{Name: "Campus " + label}
+	must("location", repos.locations.Create(ctx, s.location))
+
+	s.kiosk = &models.KioskDevice{Name: "Quiosque " + label, LocationID: s.location.ID, CreatedBy: "auth0|staff"}
+	must("kiosk", repos.kiosks.Create(ctx, s.kiosk, "pairing-"+label, later))
+
+	s.job = &models.Job{Type: "isolation_" + label, Payload: []byte(`{}`), MaxAttempts: 1, RunAt: later}
+	must("job", repos.jobs.Enqueue(ctx, s.job))
+
+	return s
+}
+
+// assertHidden falha se err não indicar que o registro não foi encontrado.
+func assertHidden(t *testing.T, what string, err error) {
+	t.Helper()
+	if !errors.Is(err, ErrNotFound) && !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("%s of the other tenant: err = %v, want not found", what, err)
+	}
+}
+
+// assertRejected falha se a escrita foi aceita.
+func assertRejected(t *testing.T, what string, err error) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s succeeded, want it rejected", what)
+	}
+}
+
+func idsOf[T any](items []T, id func(T) string) map[string]bool {
+	ids := make(map[string]bool, len(items))
+	for _, item := range items {
+		ids[id(item)] = true
+	}
+	return ids
+}
+
+func TestTenantIsolation(t *testing.T) {
+	raw := isolationDB(t)
+	db := tenant.NewDB(raw)
+	repos := newIsolationRepos(db)
+
+	ctx := context.Background()
+	tenantA := createTenant(t, raw, "Campus A")
+	tenantB := createTenant(t, raw, "Campus B")
+	ctxA := tenant.WithID(ctx, tenantA)
+	ctxB := tenant.WithID(ctx, tenantB)
+
+	a := seedTenant(t, ctxA, repos, "A", "11 99999-0001")
+	b := seedTenant(t, ctxB, repos, "B", "11 99999-0002")
+
+	t.Run("reads by id", func(t *testing.T) {
+		_, err := repos.groups.GetByID(ctxB, a.group.ID)
+		assertHidden(t, "group", err)
+		_, err = repos.children.GetByID(ctxB, a.child.ID)
+		assertHidden(t, "child", err)
+		_, err = repos.caretakers.GetByID(ctxB, a.caretaker.ID)
+		assertHidden(t, "caretaker", err)
+		_, err = repos.volunteers.GetByID(ctxB, a.volunteer.ID)
+		assertHidden(t, "volunteer", err)
+		_, err = repos.households.GetByID(ctxB, a.household.ID)
+		assertHidden(t, "household", err)
+		_, err = repos.households.GetByCaretaker(ctxB, a.caretaker.ID)
+		assertHidden(t, "household by caretaker", err)
+		_, err = repos.restriction.GetByID(ctxB, a.restriction.ID)
+		assertHidden(t, "restriction", err)
+		_, err = repos.grants.GetByID(ctxB, a.grant.ID)
+		assertHidden(t, "pickup grant", err)
+		_, err = repos.medications.GetOrder(ctxB, a.order.ID)
+		assertHidden(t, "medication order", err)
+		_, err = repos.incidents.GetByID(ctxB, a.incident.ID)
+		assertHidden(t, "incident", err)
+		_, err = repos.notices.GetByID(ctxB, a.notification.ID)
+		assertHidden(t, "notification", err)
+		_, err = repos.locations.GetByID(ctxB, a.location.ID)
+		assertHidden(t, "location", err)
+		_, err = repos.jobs.GetByID(ctxB, a.job.ID)
+		assertHidden(t, "job", err)
+
+		profile, err := repos.medical.Get(ctxB, a.child.ID)
+		if err != nil {
+			t.Fatalf("medical profile: %v", err)
+		}
+		if profile.Notes != "" || len(profile.Conditions) != 0 || profile.Version != 0 {
+			t.Errorf("medical profile of the other tenant is visible: %+v", profile)
+		}
+	})
+
+	t.Run("lists", func(t *testing.T) {
+		groups, err := repos.groups.List(ctxB, nil, 100, 0)
+		if err != nil {
+			t.Fatalf("groups: %v", err)
+		}
+		if ids := idsOf(groups, func(g *models.Group) string { return g.ID }); ids[a.group.ID] || !ids[b.group.ID] {
+			t.Errorf("group list = %v, want only the tenant's own", ids)
+		}
+
+		children, err := repos.children.List(ctxB, nil, 100, 0)
+		if err != nil {
+			t.Fatalf("children: %v", err)
+		}
+		if ids := idsOf(children, func(c *models.Child) string { return c.ID }); ids[a.child.ID] || !ids[b.child.ID] {
+			t.Errorf("child list = %v, want only the tenant's own", ids)
+		}
+
+		caretakers, err := repos.caretakers.List(ctxB, nil, 100, 0)
+		if err != nil {
+			t.Fatalf("caretakers: %v", err)
+		}
+		if ids := idsOf(caretakers, func(c *models.Caretaker) string { return c.ID }); ids[a.caretaker.ID] || !ids[b.caretaker.ID] {
+			t.Errorf("caretaker list = %v, want only the tenant's own", ids)
+		}
+
+		volunteers, err := repos.volunteers.List(ctxB, nil, 100, 0)
+		if err != nil {
+			t.Fatalf("volunteers: %v", err)
+		}
+		if ids := idsOf(volunteers, func(v *models.Volunteer) string { return v.ID }); ids[a.volunteer.ID] || !ids[b.volunteer.ID] {
+			t.Errorf("volunteer list = %v, want only the tenant's own", ids)
+		}
+
+		incidents, err := repos.incidents.List(ctxB, map[string]interface{}{}, 100, 0)
+		if err != nil {
+			t.Fatalf("incidents: %v", err)
+		}
+		if ids := idsOf(incidents, func(i *models.Incident) string { return i.ID }); ids[a.incident.ID] || !ids[b.incident.ID] {
+			t.Errorf("incident list = %v, want only the tenant's own", ids)
+		}
+
+		locations, err := repos.locations.List(ctxB, 100, 0)
+		if err != nil {
+			t.Fatalf("locations: %v", err)
+		}
+		if ids := idsOf(locations, func(l *models.Location) string { return l.ID }); ids[a.location.ID] || !ids[b.location.ID] {
+			t.Errorf("location list = %v, want only the tenant's own", ids)
+		}
+
+		restrictions, err := repos.restriction.ListByChild(ctxB, a.child.ID)
+		if err != nil || len(restrictions) != 0 {
+			t.Errorf("restrictions of the other tenant's child = %d, %v; want none", len(restrictions), err)
+		}
+		if active, err := repos.restriction.HasActive(ctxB, a.child.ID); err != nil || active {
+			t.Errorf("HasActive on the other tenant's child = %v, %v; want false", active, err)
+		}
+		grants, err := repos.grants.ListByChild(ctxB, a.child.ID)
+		if err != nil || len(grants) != 0 {
+			t.Errorf("pickup grants of the other tenant's child = %d, %v; want none", len(grants), err)
+		}
+		orders, err := repos.medications.ListOrdersByChild(ctxB, a.child.ID, true)
+		if err != nil || len(orders) != 0 {
+			t.Errorf("medication orders of the other tenant's child = %d, %v; want none", len(orders), err)
+		}
+		families, err := repos.households.FindByPhone(ctxB, "0001", 10)
+		if err != nil {
+			t.Fatalf("FindByPhone: %v", err)
+		}
+		for _, family := range families {
+			if family.HouseholdID == a.household.ID {
+				t.Errorf("FindByPhone returned the other tenant's household")
+			}
+		}
+
+		results, err := repos.search.Search(ctxB, "Criança A", 20)
+		if err != nil {
+			t.Fatalf("search: %v", err)
+		}
+		for _, result := range results {
+			if result.ID == a.child.ID || result.ID == a.caretaker.ID || result.ID == a.volunteer.ID {
+				t.Errorf("search returned the other tenant's %s %s", result.Type, result.ID)
+			}
+		}
+	})
+
+	t.Run("writes", func(t *testing.T) {
+		group := *a.group
+		group.Name = "Invadido"
+		assertRejected(t, "group update", repos.groups.Update(ctxB, &group))
+		assertHidden(t, "group delete", repos.groups.Delete(ctxB, a.group.ID))
+
+		child := *a.child
+		child.Name = "Invadido"
+		assertRejected(t, "child update", repos.children.Update(ctxB, &child))
+		assertHidden(t, "child delete", repos.children.Delete(ctxB, a.child.ID))
+
+		caretaker := *a.caretaker
+		caretaker.Name = "Invadido"
+		assertRejected(t, "caretaker update", repos.caretakers.Update(ctxB, &caretaker))
+		assertHidden(t, "caretaker delete", repos.caretakers.Delete(ctxB, a.caretaker.ID))
+
+		volunteer := *a.volunteer
+		volunteer.Name = "Invadido"
+		assertRejected(t, "volunteer update", repos.volunteers.Update(ctxB, &volunteer))
+		assertHidden(t, "volunteer delete", repos.volunteers.Delete(ctxB, a.volunteer.ID))
+
+		household := *a.household
+		household.Name = "Invadido"
+		assertRejected(t, "household update", repos.households.Update(ctxB, &household))
+		assertHidden(t, "household delete", repos.households.Delete(ctxB, a.household.ID))
+
+		location := *a.location
+		location.Name = "Invadido"
+		assertRejected(t, "location update", repos.locations.Update(ctxB, &location))
+		assertHidden(t, "location delete", repos.locations.Delete(ctxB, a.location.ID))
+
+		incident := *a.incident
+		incident.Description = "Invadido"
+		assertRejected(t, "incident update", repos.incidents.Update(ctxB, &incident))
+
+		notification := *a.notification
+		notification.Status = models.NotificationSent
+		assertRejected(t, "notification update", repos.notices.UpdateStatus(ctxB, &notification))
+
+		assertHidden(t, "restriction revoke", repos.restriction.Revoke(ctxB, a.restriction.ID))
+		assertHidden(t, "pickup grant revoke", repos.grants.Revoke(ctxB, a.grant.ID))
+		assertHidden(t, "medication order discontinue", repos.medications.DiscontinueOrder(ctxB, a.order.ID))
+
+		// O upsert encontra a ficha do outro campus pelo child_id, mas o RLS impede a atualização
+		assertRejected(t, "medical profile save", repos.medical.Save(ctxB, &models.MedicalProfile{
+			ChildID:   a.child.ID,
+			Notes:     "Invadido",
+			UpdatedBy: "auth0|intruso",
+		}))
+
+		// Nada mudou no campus A
+		if got, err := repos.groups.GetByID(ctxA, a.group.ID); err != nil || got.Name != a.group.Name {
+			t.Errorf("group of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.children.GetByID(ctxA, a.child.ID); err != nil || got.Name != a.child.Name {
+			t.Errorf("child of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.caretakers.GetByID(ctxA, a.caretaker.ID); err != nil || got.Name != a.caretaker.Name {
+			t.Errorf("caretaker of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.volunteers.GetByID(ctxA, a.volunteer.ID); err != nil || got.Name != a.volunteer.Name {
+			t.Errorf("volunteer of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.households.GetByID(ctxA, a.household.ID); err != nil || got.Name != a.household.Name {
+			t.Errorf("household of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.locations.GetByID(ctxA, a.location.ID); err != nil || got.Name != a.location.Name {
+			t.Errorf("location of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.incidents.GetByID(ctxA, a.incident.ID); err != nil || got.Description != a.incident.Description {
+			t.Errorf("incident of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.notices.GetByID(ctxA, a.notification.ID); err != nil || got.Status != models.NotificationPending {
+			t.Errorf("notification of tenant A = %+v, %v; want unchanged", got, err)
+		}
+		if got, err := repos.restriction.GetByID(ctxA, a.restriction.ID); err != nil || got.RevokedAt != nil {
+			t.Errorf("restriction of tenant A = %+v, %v; want still active", got, err)
+		}
+		if got, err := repos.grants.GetByID(ctxA, a.grant.ID); err != nil || got.RevokedAt != nil {
+			t.Errorf("pickup grant of tenant A = %+v, %v; want still active", got, err)
+		}
+		if got, err := repos.medications.GetOrder(ctxA, a.order.ID); err != nil || got.DiscontinuedAt != nil {
+			t.Errorf("medication order of tenant A = %+v, %v; want still active", got, err)
+		}
+		if got, err := repos.medical.Get(ctxA, a.child.ID); err != nil || got.Notes != "Bombinha na mochila" {
+			t.Errorf("medical profile of tenant A = %+v, %v; want unchanged", got, err)
+		}
+	})
+
+	t.Run("inserts for the other tenant", func(t *testing.T) {
+		// A política WITH CHECK recusa linhas com o tenant_id de outro campus
+		inserts := map[string]string{
+			"groups":     `INSERT INTO groups (name, tenant_id) VALUES ('Invasor', $1)`,
+			"caretakers": `INSERT INTO caretakers (name, tenant_id) VALUES ('Invasor', $1)`,
+			"volunteers": `INSERT INTO volunteers (name, tenant_id) VALUES ('Invasor', $1)`,
+			"households": `INSERT INTO households (name, tenant_id) VALUES ('Invasor', $1)`,
+			"locations":  `INSERT INTO locations (name, tenant_id) VALUES ('Invasor', $1)`,
+		}
+		for table, query := range inserts {
+			if _, err := db.ExecContext(ctxB, query, tenantA); err == nil {
+				t.Errorf("insert into %s with the other tenant's id succeeded", table)
+			}
+		}
+	})
+
+	t.Run("kiosk devices and jobs", func(t *testing.T) {
+		kiosks, err := repos.kiosks.List(ctxB)
+		if err != nil {
+			t.Fatalf("kiosks: %v", err)
+		}
+		if ids := idsOf(kiosks, func(k *models.KioskDevice) string { return k.ID }); ids[a.kiosk.ID] || !ids[b.kiosk.ID] {
+			t.Errorf("kiosk list = %v, want only the tenant's own", ids)
+		}
+		assertHidden(t, "kiosk revoke", repos.kiosks.Revoke(ctxB, a.kiosk.ID))
+
+		jobs, err := repos.jobs.List(ctxB, "", 100, 0)
+		if err != nil {
+			t.Fatalf("jobs: %v", err)
+		}
+		if ids := idsOf(jobs, func(j *models.Job) string { return j.ID }); ids[a.job.ID] || !ids[b.job.ID] {
+			t.Errorf("job list = %v, want only the tenant's own", ids)
+		}
+		if queued, err := repos.jobs.HasQueued(ctxB, a.job.Type); err != nil || queued {
+			t.Errorf("HasQueued(%s) = %v, %v; want false for the other tenant's job", a.job.Type, queued, err)
+		}
+
+		if _, err := raw.Exec(`UPDATE jobs SET status = 'dead' WHERE id = $1`, a.job.ID); err != nil {
+			t.Fatalf("mark job dead: %v", err)
+		}
+		assertHidden(t, "job requeue", repos.jobs.Requeue(ctxB, a.job.ID))
+
+		var tenantID, status string
+		if err := raw.QueryRow(`SELECT tenant_id, status FROM jobs WHERE id = $1`, a.job.ID).Scan(&tenantID, &status); err != nil {
+			t.Fatalf("read job: %v", err)
+		}
+		if tenantID != tenantA || status != "dead" {
+			t.Errorf("job of tenant A = tenant %s, status %s; want %s, dead", tenantID, status, tenantA)
+		}
+		var revoked bool
+		if err := raw.Get(&revoked, `SELECT revoked_at IS NOT NULL FROM kiosk_devices WHERE id = $1`, a.kiosk.ID); err != nil || revoked {
+			t.Errorf("kiosk of tenant A revoked = %v, %v; want still active", revoked, err)
+		}
+	})
+
+	t.Run("without tenant", func(t *testing.T) {
+		var tables []string
+		if err := raw.Select(&tables, `SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND rowsecurity ORDER BY tablename`); err != nil {
+			t.Fatalf("list tables: %v", err)
+		}
+		if len(tables) == 0 {
+			t.Fatal("no table has row-level security enabled")
+		}
+
+		for _, table := range tables {
+			var withTenant, withoutTenant int
+			query := `SELECT COUNT(*) FROM ` + pq.QuoteIdentifier(table)
+			if err := db.GetContext(ctxA, &withTenant, query); err != nil {
+				t.Fatalf("count %s as tenant A: %v", table, err)
+			}
+			if err := db.GetContext(ctx, &withoutTenant, query); err != nil {
+				t.Fatalf("count %s without tenant: %v", table, err)
+			}
+			if withoutTenant != 0 {
+				t.Errorf("%s: %d rows visible without a tenant (tenant A sees %d)", table, withoutTenant, withTenant)
+			}
+		}
+
+		groups, err := repos.groups.List(ctx, nil, 100, 0)
+		if err != nil || len(groups) != 0 {
+			t.Errorf("group list without tenant = %d, %v; want none", len(groups), err)
+		}
+		kiosks, err := repos.kiosks.List(ctx)
+		if err != nil || len(kiosks) != 0 {
+			t.Errorf("kiosk list without tenant = %d, %v; want none", len(kiosks), err)
+		}
+		jobs, err := repos.jobs.List(ctx, "", 100, 0)
+		if err != nil || len(jobs) != 0 {
+			t.Errorf("job list without tenant = %d, %v; want none", len(jobs), err)
+		}
+
+		assertRejected(t, "group create without tenant", repos.groups.Create(ctx, &models.Group{Name: "Sem campus"}))
+		assertRejected(t, "caretaker create without tenant", repos.caretakers.Create(ctx, &models.Caretaker{Name: "Sem campus"}))
+	})
+}
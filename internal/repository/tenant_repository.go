@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// TenantRepository lista os tenants, para tarefas administrativas que
+// percorrem os dados de todos os campi.
+type TenantRepository interface {
+	ListIDs(ctx context.Context) ([]string, error)
+}
+
+type tenantRepository struct {
+	db *tenant.DB
+}
+
+func NewTenantRepository(db *tenant.DB) TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+func (r *tenantRepository) ListIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, `SELECT id FROM tenants ORDER BY created_at`); err != nil {
+		return nil, fmt.Errorf("tenantRepository.ListIDs: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// missingOrConflict explica por que um UPDATE otimista não afetou nenhuma
+// linha: ou o registro não existe (ou foi excluído), ou a versão mudou.
+func missingOrConflict(ctx context.Context, db *tenant.DB, table, id string) error {
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1 AND deleted_at IS NULL)`, table)
+
+	var exists bool
+	if err := db.GetContext(ctx, &exists, query, id); err != nil {
+		return fmt.Errorf("repository.missingOrConflict(%s): %w", table, err)
+	}
+
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrVersionConflict
+}
@@ -3,25 +3,31 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
-	"github.com/jmoiron/sqlx"
+	"github.com/eduardohass/kids-api/internal/tenant"
 )
 
 type VolunteerRepository interface {
 	Create(ctx context.Context, volunteer *models.Volunteer) error
 	GetByID(ctx context.Context, id string) (*models.Volunteer, error)
+	GetByAuth0ID(ctx context.Context, auth0ID string) (*models.Volunteer, error)
 	Update(ctx context.Context, volunteer *models.Volunteer) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string, deletedBefore time.Time) error
 	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Volunteer, error)
 }
 
 type volunteerRepository struct {
-	db *sqlx.DB
+	db *tenant.DB
 }
 
-func NewVolunteerRepository(db *sqlx.DB) VolunteerRepository {
+func NewVolunteerRepository(db *tenant.DB) VolunteerRepository {
 	return &volunteerRepository{db: db}
 }
 
@@ -34,7 +40,7 @@ func (r *volunteerRepository) Create(ctx context.Context, volunteer *models.Volu
 			skills,
 			availability
 		) VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at, updated_at
+		RETURNING id, version, created_at, updated_at
 	`
 
 	return r.db.QueryRowxContext(
@@ -45,23 +51,46 @@ func (r *volunteerRepository) Create(ctx context.Context, volunteer *models.Volu
 		volunteer.Phone,
 		volunteer.Skills,
 		volunteer.Availability,
-	).Scan(&volunteer.ID, &volunteer.CreatedAt, &volunteer.UpdatedAt)
+	).Scan(&volunteer.ID, &volunteer.Version, &volunteer.CreatedAt, &volunteer.UpdatedAt)
 }
 
 func (r *volunteerRepository) GetByID(ctx context.Context, id string) (*models.Volunteer, error) {
 	const query = `
-		SELECT id, name, email, phone, skills, availability, created_at, updated_at
+		SELECT id, name, email, phone, skills, availability, version, created_at, updated_at
 		FROM volunteers
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var volunteer models.Volunteer
 	if err := r.db.GetContext(ctx, &volunteer, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
 		return nil, fmt.Errorf("volunteerRepository.GetByID: %w", err)
 	}
 	return &volunteer, nil
 }
 
+// GetByAuth0ID busca o voluntário vinculado ao usuário do Auth0 (claim "sub").
+func (r *volunteerRepository) GetByAuth0ID(ctx context.Context, auth0ID string) (*models.Volunteer, error) {
+	const query = `
+		SELECT id, name, email, phone, skills, availability, version, created_at, updated_at
+		FROM volunteers
+		WHERE auth0_id = $1 AND deleted_at IS NULL
+	`
+
+	var volunteer models.Volunteer
+	if err := r.db.GetContext(ctx, &volunteer, query, auth0ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("volunteerRepository.GetByAuth0ID: %w", err)
+	}
+	return &volunteer, nil
+}
+
+// Update grava as alterações usando controle de concorrência otimista: quando
+// volunteer.Version é informado, a atualização só ocorre se a versão persistida for a mesma.
 func (r *volunteerRepository) Update(ctx context.Context, volunteer *models.Volunteer) error {
 	const query = `
 		UPDATE volunteers SET
@@ -70,12 +99,13 @@ func (r *volunteerRepository) Update(ctx context.Context, volunteer *models.Volu
 			phone = $3,
 			skills = $4,
 			availability = $5,
+			version = version + 1,
 			updated_at = NOW()
-		WHERE id = $6
-		RETURNING updated_at
+		WHERE id = $6 AND deleted_at IS NULL AND ($7 = 0 OR version = $7)
+		RETURNING version, updated_at
 	`
 
-	result, err := r.db.ExecContext(
+	err := r.db.QueryRowxContext(
 		ctx,
 		query,
 		volunteer.Name,
@@ -84,14 +114,29 @@ func (r *volunteerRepository) Update(ctx context.Context, volunteer *models.Volu
 		volunteer.Skills,
 		volunteer.Availability,
 		volunteer.ID,
-	)
+		volunteer.Version,
+	).Scan(&volunteer.Version, &volunteer.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return missingOrConflict(ctx, r.db, "volunteers", volunteer.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("volunteerRepository.Update: %w", err)
 	}
 
+	return nil
+}
+
+func (r *volunteerRepository) Delete(ctx context.Context, id string) error {
+	const query = `UPDATE volunteers SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("volunteerRepository.Delete: %w", err)
+	}
+
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("volunteerRepository.Update: %w", err)
+		return fmt.Errorf("volunteerRepository.Delete: %w", err)
 	}
 
 	if rows == 0 {
@@ -101,17 +146,18 @@ func (r *volunteerRepository) Update(ctx context.Context, volunteer *models.Volu
 	return nil
 }
 
-func (r *volunteerRepository) Delete(ctx context.Context, id string) error {
-	const query = `DELETE FROM volunteers WHERE id = $1`
+// Restore desfaz a exclusão lógica de um registro.
+func (r *volunteerRepository) Restore(ctx context.Context, id string) error {
+	const query = `UPDATE volunteers SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("volunteerRepository.Delete: %w", err)
+		return fmt.Errorf("volunteerRepository.Restore: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("volunteerRepository.Delete: %w", err)
+		return fmt.Errorf("volunteerRepository.Restore: %w", err)
 	}
 
 	if rows == 0 {
@@ -121,10 +167,16 @@ func (r *volunteerRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Purge remove fisicamente um registro excluído logicamente antes de deletedBefore.
+func (r *volunteerRepository) Purge(ctx context.Context, id string, deletedBefore time.Time) error {
+	return purge(ctx, r.db, "volunteers", id, deletedBefore)
+}
+
 func (r *volunteerRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*models.Volunteer, error) {
 	const query = `
-		SELECT id, name, email, phone, skills, availability, created_at, updated_at
+		SELECT id, name, email, phone, skills, availability, version, created_at, updated_at
 		FROM volunteers
+		WHERE deleted_at IS NULL
 		LIMIT $1 OFFSET $2
 	`
 
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// defaultReportPeriod é o período do relatório quando since não é informado.
+const defaultReportPeriod = 7 * 24 * time.Hour
+
+type accessorKey struct{}
+
+// WithAccessor retorna um contexto cujas leituras de dados sensíveis são
+// registradas em nome do accessor.
+func WithAccessor(ctx context.Context, accessor models.Accessor) context.Context {
+	return context.WithValue(ctx, accessorKey{}, accessor)
+}
+
+// AccessorFromContext retorna quem está lendo os dados na requisição, se houver.
+func AccessorFromContext(ctx context.Context) (models.Accessor, bool) {
+	accessor, ok := ctx.Value(accessorKey{}).(models.Accessor)
+	return accessor, ok && accessor.Actor != ""
+}
+
+// ValidAccessReason informa se o motivo é um dos códigos aceitos.
+func ValidAccessReason(reason string) bool {
+	for _, valid := range models.AccessReasons {
+		if reason == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessLogService registra quem leu dados sensíveis e aponta os padrões de
+// acesso incomuns.
+type AccessLogService interface {
+	RecordAccess(ctx context.Context, resource, entityType string, entityIDs ...string) error
+	List(ctx context.Context, filter models.AccessLogFilter, page, pageSize int) ([]*models.AccessLogEntry, error)
+	Report(ctx context.Context, since, until time.Time) (*models.AccessReport, error)
+}
+
+type accessLogService struct {
+	repo               repository.AccessLogRepository
+	highVolumeEntities int
+}
+
+// NewAccessLogService cria o serviço; highVolumeEntities é o número de
+// entidades distintas lidas por um ator no período a partir do qual o
+// relatório aponta o acesso em massa.
+func NewAccessLogService(repo repository.AccessLogRepository, highVolumeEntities int) AccessLogService {
+	return &accessLogService{
+		repo:               repo,
+		highVolumeEntities: highVolumeEntities,
+	}
+}
+
+// RecordAccess registra a leitura das entidades pelo accessor do contexto.
+// Leituras sem accessor (trabalhos em segundo plano) não são registradas.
+func (s *accessLogService) RecordAccess(ctx context.Context, resource, entityType string, entityIDs ...string) error {
+	accessor, ok := AccessorFromContext(ctx)
+	if !ok || len(entityIDs) == 0 {
+		return nil
+	}
+	if accessor.Reason == "" {
+		accessor.Reason = models.AccessReasonUnspecified
+	}
+	return s.repo.Create(ctx, accessor, resource, entityType, entityIDs)
+}
+
+func (s *accessLogService) List(ctx context.Context, filter models.AccessLogFilter, page, pageSize int) ([]*models.AccessLogEntry, error) {
+	var err error
+	if filter.Since, filter.Until, err = reportPeriod(filter.Since, filter.Until); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * pageSize
+	return s.repo.List(ctx, filter, pageSize, offset)
+}
+
+// Report aponta, no período, voluntários que leram dados de crianças fora dos
+// seus grupos, leituras em massa e leituras sem motivo informado.
+func (s *accessLogService) Report(ctx context.Context, since, until time.Time) (*models.AccessReport, error) {
+	since, until, err := reportPeriod(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.AccessReport{
+		Since:     since,
+		Until:     until,
+		Anomalies: []*models.AccessAnomaly{},
+	}
+
+	// Coordenação e equipe de saúde atendem todas as crianças
+	privileged := []string{auth.RoleAdmin, auth.RoleCoordinator, auth.RoleMedical}
+	outside, err := s.repo.OutsideGroups(ctx, since, until, privileged)
+	if err != nil {
+		return nil, err
+	}
+	report.Anomalies = append(report.Anomalies, outside...)
+
+	if s.highVolumeEntities > 0 {
+		bulk, err := s.repo.HighVolume(ctx, since, until, s.highVolumeEntities)
+		if err != nil {
+			return nil, err
+		}
+		report.Anomalies = append(report.Anomalies, bulk...)
+	}
+
+	missing, err := s.repo.MissingReason(ctx, since, until)
+	if err != nil {
+		return nil, err
+	}
+	report.Anomalies = append(report.Anomalies, missing...)
+
+	return report, nil
+}
+
+// reportPeriod completa o período: until vazio vale agora e since vazio, os
+// sete dias anteriores.
+func reportPeriod(since, until time.Time) (time.Time, time.Time, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	if since.IsZero() {
+		since = until.Add(-defaultReportPeriod)
+	}
+	if !since.Before(until) {
+		return since, until, invalid("since must be before until")
+	}
+	return since, until, nil
+}
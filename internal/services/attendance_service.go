@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// securityCodeAlphabet omite caracteres ambíguos (0/O, 1/I/L) para facilitar a
+// conferência visual da etiqueta na retirada.
+const (
+	securityCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	securityCodeLength   = 4
+)
+
+// ErrInvalidSecurityCode is returned when the pickup code does not match the check-in.
+var ErrInvalidSecurityCode = errors.New("invalid security code")
+
+// ErrPickupNotAuthorized is returned when the pickup person is neither a caretaker
+// allowed to pick up the child nor the holder of a valid pickup grant.
+var ErrPickupNotAuthorized = errors.New("pickup person is not authorized")
+
+// ErrPickupRestricted is returned when a custody restriction bars the pickup person.
+var ErrPickupRestricted = errors.New("pickup person is not allowed to take this child")
+
+// ErrRoomFull is returned when every room assigned to the group is at capacity
+// or out of volunteer ratio.
+var ErrRoomFull = errors.New("no room available for this group")
+
+type AttendanceService interface {
+	CheckIn(ctx context.Context, req models.CheckInRequest, checkedInBy string) ([]*models.Attendance, error)
+	CheckOut(ctx context.Context, id string, req models.CheckOutRequest, checkedOutBy string) (*models.Attendance, error)
+	GetAttendance(ctx context.Context, id string) (*models.Attendance, error)
+}
+
+type attendanceService struct {
+	attendanceRepo  repository.AttendanceRepository
+	childRepo       repository.ChildRepository
+	restrictionRepo repository.RestrictionRepository
+	caretakerRepo   repository.CaretakerRepository
+	grantRepo       repository.PickupGrantRepository
+	auditRepo       repository.AuditRepository
+	groupRepo       repository.GroupRepository
+	roomRepo        repository.RoomRepository
+	consents        ConsentService
+	events          EventPublisher
+}
+
+func NewAttendanceService(
+	attendanceRepo repository.AttendanceRepository,
+	childRepo repository.ChildRepository,
+	restrictionRepo repository.RestrictionRepository,
+	caretakerRepo repository.CaretakerRepository,
+	grantRepo repository.PickupGrantRepository,
+	auditRepo repository.AuditRepository,
+	groupRepo repository.GroupRepository,
+	roomRepo repository.RoomRepository,
+	consents ConsentService,
+	events EventPublisher,
+) AttendanceService {
+	return &attendanceService{
+		attendanceRepo:  attendanceRepo,
+		childRepo:       childRepo,
+		restrictionRepo: restrictionRepo,
+		caretakerRepo:   caretakerRepo,
+		grantRepo:       grantRepo,
+		auditRepo:       auditRepo,
+		groupRepo:       groupRepo,
+		roomRepo:        roomRepo,
+		consents:        consents,
+		events:          events,
+	}
+}
+
+// CheckIn registra a entrada de uma ou mais crianças no grupo de cada uma.
+// Todas as crianças da mesma requisição recebem o mesmo código de segurança,
+// que o responsável apresenta na retirada. Quando o grupo tem salas atribuídas
+// no evento, a criança vai para a primeira sala com vaga. Termos obrigatórios
+// pendentes ou desatualizados geram avisos, mas não impedem a entrada.
+func (s *attendanceService) CheckIn(ctx context.Context, req models.CheckInRequest, checkedInBy string) ([]*models.Attendance, error) {
+	if len(req.ChildIDs) == 0 {
+		return nil, invalid("at least one child is required")
+	}
+
+	code, err := generateSecurityCode()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*models.Attendance, 0, len(req.ChildIDs))
+	pending := make(map[string]int)
+	for _, childID := range req.ChildIDs {
+		child, err := s.childRepo.GetByID(ctx, childID)
+		if err != nil {
+			return nil, err
+		}
+
+		record := &models.Attendance{
+			ChildID:      child.ID,
+			GroupID:      child.GroupID,
+			EventID:      req.EventID,
+			LocationID:   req.LocationID,
+			SecurityCode: code,
+			CheckedInBy:  checkedInBy,
+		}
+
+		if req.EventID != "" && child.GroupID != "" {
+			room, err := s.pickRoom(ctx, req.EventID, child.GroupID, pending)
+			if err != nil {
+				return nil, err
+			}
+			if room != nil {
+				pending[room.ID]++
+				record.RoomID = room.ID
+				record.RoomName = room.Name
+				if record.LocationID == "" {
+					record.LocationID = room.LocationID
+				}
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	warnings, err := s.consents.CheckInWarnings(ctx, req.ChildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attendanceRepo.CreateMany(ctx, records); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		record.Warnings = warnings[record.ChildID]
+	}
+
+	s.publishCheckIns(ctx, records)
+	return records, nil
+}
+
+// CheckOut libera a criança para quem vai retirá-la: um responsável com
+// permissão de retirada ou o portador de uma autorização temporária válida.
+// A retirada é recusada se o código de segurança não confere ou se existe
+// restrição de guarda ativa contra a pessoa; toda tentativa bloqueada é auditada.
+func (s *attendanceService) CheckOut(ctx context.Context, id string, req models.CheckOutRequest, checkedOutBy string) (*models.Attendance, error) {
+	if (req.CaretakerID == "") == (req.PickupGrantID == "") {
+		return nil, invalid("exactly one of caretaker_id or pickup_grant_id is required")
+	}
+	// IDs malformados falhariam na conversão para uuid no banco
+	if req.CaretakerID != "" && !isUUID(req.CaretakerID) {
+		return nil, invalid("caretaker_id must be a UUID")
+	}
+	if req.PickupGrantID != "" && !isUUID(req.PickupGrantID) {
+		return nil, invalid("pickup_grant_id must be a UUID")
+	}
+
+	record, err := s.attendanceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.CheckedOutAt != nil {
+		return nil, repository.ErrAlreadyCheckedOut
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.SecurityCode), []byte(record.SecurityCode)) != 1 {
+		return nil, ErrInvalidSecurityCode
+	}
+
+	var grant *models.PickupGrant
+	if req.PickupGrantID != "" {
+		grant, err = s.authorizedGrant(ctx, record, req.PickupGrantID)
+	} else {
+		err = s.authorizeCaretaker(ctx, record, req.CaretakerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pickupName := ""
+	if grant != nil {
+		pickupName = grant.Name
+	}
+
+	restriction, err := s.restrictionRepo.FindActiveMatch(ctx, record.ChildID, req.CaretakerID, pickupName)
+	switch {
+	case err == nil:
+		details := map[string]string{
+			"attendance_id":   record.ID,
+			"restriction_id":  restriction.ID,
+			"caretaker_id":    req.CaretakerID,
+			"pickup_grant_id": req.PickupGrantID,
+			"pickup_name":     pickupName,
+		}
+		if err := recordAudit(ctx, s.auditRepo, checkedOutBy, AuditReleaseBlocked, AuditEntityChild, record.ChildID, details); err != nil {
+			return nil, err
+		}
+		return nil, ErrPickupRestricted
+	case !errors.Is(err, repository.ErrNotFound):
+		return nil, err
+	}
+
+	record.CheckedOutBy = checkedOutBy
+	record.ReleasedToCaretakerID = req.CaretakerID
+	record.ReleasedToName = pickupName
+	record.PickupGrantID = req.PickupGrantID
+	if err := s.attendanceRepo.CheckOut(ctx, record); err != nil {
+		return nil, err
+	}
+
+	if grant != nil {
+		details := map[string]string{"pickup_grant_id": grant.ID, "pickup_name": grant.Name}
+		if err := recordAudit(ctx, s.auditRepo, checkedOutBy, AuditReleasedWithGrant, AuditEntityAttendance, record.ID, details); err != nil {
+			return nil, err
+		}
+	}
+
+	s.events.Publish(ctx, record.GroupID, models.GroupEventCheckOut, models.NewAttendanceEvent(record))
+	return record, nil
+}
+
+func (s *attendanceService) authorizeCaretaker(ctx context.Context, record *models.Attendance, caretakerID string) error {
+	allowed, err := s.caretakerRepo.CanPickup(ctx, record.ChildID, caretakerID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrPickupNotAuthorized
+	}
+	return nil
+}
+
+func (s *attendanceService) authorizedGrant(ctx context.Context, record *models.Attendance, grantID string) (*models.PickupGrant, error) {
+	grant, err := s.grantRepo.GetByID(ctx, grantID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrPickupNotAuthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if grant.ChildID != record.ChildID || !grant.IsValidFor(time.Now(), record.EventID) {
+		return nil, ErrPickupNotAuthorized
+	}
+	return grant, nil
+}
+
+func (s *attendanceService) GetAttendance(ctx context.Context, id string) (*models.Attendance, error) {
+	return s.attendanceRepo.GetByID(ctx, id)
+}
+
+func generateSecurityCode() (string, error) {
+	code := make([]byte, securityCodeLength)
+	max := big.NewInt(int64(len(securityCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = securityCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// pickRoom escolhe a primeira sala do grupo no evento que ainda comporta uma
+// criança, descontando as já alocadas nesta requisição (pending). Retorna nil
+// quando o grupo não tem sala atribuída. A escolha não reserva a vaga: o
+// CreateMany confere a lotação de novo, com a sala travada.
+func (s *attendanceService) pickRoom(ctx context.Context, eventID, groupID string, pending map[string]int) (*models.Room, error) {
+	rooms, err := s.roomRepo.RoomsForGroup(ctx, eventID, groupID)
+	if err != nil || len(rooms) == 0 {
+		return nil, err
+	}
+
+	for _, room := range rooms {
+		occupancy, err := roomOccupancy(ctx, s.roomRepo, s.attendanceRepo, eventID, room)
+		if err != nil {
+			return nil, err
+		}
+		if occupancy.Available == nil || *occupancy.Available > pending[room.ID] {
+			return room, nil
+		}
+	}
+	return nil, ErrRoomFull
+}
+
+// publishCheckIns avisa o painel de cada grupo e emite o alerta de lotação
+// quando a sala (ou, sem sala atribuída, o grupo) atinge o limite.
+func (s *attendanceService) publishCheckIns(ctx context.Context, records []*models.Attendance) {
+	groups := make(map[string]bool)
+	rooms := make(map[string]string)
+	for _, record := range records {
+		s.events.Publish(ctx, record.GroupID, models.GroupEventCheckIn, models.NewAttendanceEvent(record))
+		switch {
+		case record.RoomID != "":
+			rooms[record.RoomID] = record.EventID
+		case record.GroupID != "":
+			groups[record.GroupID] = true
+		}
+	}
+
+	for roomID, eventID := range rooms {
+		s.publishRoomFull(ctx, eventID, roomID)
+	}
+
+	for groupID := range groups {
+		group, err := s.groupRepo.GetByID(ctx, groupID)
+		if err != nil || group.Capacity <= 0 {
+			continue
+		}
+
+		present, err := s.attendanceRepo.CountPresent(ctx, groupID)
+		if err != nil || present < group.Capacity {
+			continue
+		}
+
+		s.events.Publish(ctx, groupID, models.GroupEventCapacityReached, map[string]int{
+			"present":  present,
+			"capacity": group.Capacity,
+		})
+	}
+}
+
+// publishRoomFull avisa todos os grupos que dividem a sala quando ela lota.
+func (s *attendanceService) publishRoomFull(ctx context.Context, eventID, roomID string) {
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return
+	}
+
+	occupancy, err := roomOccupancy(ctx, s.roomRepo, s.attendanceRepo, eventID, room)
+	if err != nil || occupancy.Available == nil || *occupancy.Available > 0 {
+		return
+	}
+
+	assignments, err := s.roomRepo.ListAssignments(ctx, eventID)
+	if err != nil {
+		return
+	}
+
+	for _, assignment := range assignments {
+		if assignment.RoomID != roomID {
+			continue
+		}
+		s.events.Publish(ctx, assignment.GroupID, models.GroupEventCapacityReached, map[string]interface{}{
+			"room_id":   room.ID,
+			"room_name": room.Name,
+			"present":   occupancy.Present,
+			"capacity":  *occupancy.Limit,
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eduardohass/kids-api/internal/models"
+)
+
+func TestCheckOutRejectsMalformedIDs(t *testing.T) {
+	// Sem repositórios: a validação precisa acontecer antes de qualquer consulta
+	s := &attendanceService{}
+
+	for _, req := range []models.CheckOutRequest{
+		{SecurityCode: "A1B2", CaretakerID: "not-a-uuid"},
+		{SecurityCode: "A1B2", PickupGrantID: "42"},
+	} {
+		if _, err := s.CheckOut(context.Background(), "6f1c2a34-0000-4000-8000-000000000001", req, "auth0|staff"); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("CheckOut(%+v) error = %v, want ErrInvalidInput", req, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// Ações registradas no log de auditoria.
+const (
+	AuditRestrictionCreated       = "restriction.created"
+	AuditRestrictionRevoked       = "restriction.revoked"
+	AuditReleaseBlocked           = "custody.release_blocked"
+	AuditReleasedWithGrant        = "attendance.released_with_grant"
+	AuditIncidentSubmitted        = "incident.submitted"
+	AuditIncidentReviewed         = "incident.reviewed"
+	AuditIncidentClosed           = "incident.closed"
+	AuditIncidentAcknowledged     = "incident.acknowledged"
+	AuditRollCallClosed           = "roll_call.closed"
+	AuditDataRequestCreated       = "data_subject.requested"
+	AuditDataExported             = "data_subject.exported"
+	AuditDataErased               = "data_subject.erased"
+	AuditDataRequestFailed        = "data_subject.failed"
+	AuditRetentionPolicyUpdated   = "retention.policy_updated"
+	AuditRetentionChildAnonymized = "retention.child_anonymized"
+	AuditRetentionPhotoRemoved    = "retention.photo_removed"
+	AuditRetentionDraftPurged     = "retention.incident_draft_purged"
+	AuditRetentionAccessLogPurged = "retention.access_log_purged"
+)
+
+// Tipos de entidade referenciados pelas entradas de auditoria.
+const (
+	AuditEntityChild           = "child"
+	AuditEntityAttendance      = "attendance"
+	AuditEntityRestriction     = "restriction"
+	AuditEntityIncident        = "incident"
+	AuditEntityRollCall        = "roll_call"
+	AuditEntityDataRequest     = "data_subject_request"
+	AuditEntityRetentionPolicy = "retention_policy"
+	AuditEntityRetentionRun    = "retention_run"
+)
+
+// recordAudit grava uma entrada de auditoria; details é serializado em JSON.
+func recordAudit(
+	ctx context.Context,
+	repo repository.AuditRepository,
+	actor, action, entityType, entityID string,
+	details interface{},
+) error {
+	entry := &models.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	if details != nil {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		entry.Details = raw
+	}
+
+	return repo.Create(ctx, entry)
+}
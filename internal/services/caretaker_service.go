@@ -3,6 +3,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
 	"github.com/eduardohass/kids-api/internal/repository"
@@ -13,28 +14,47 @@ type CaretakerService interface {
 	GetCaretaker(ctx context.Context, id string) (*models.Caretaker, error)
 	UpdateCaretaker(ctx context.Context, caretaker *models.Caretaker) error
 	DeleteCaretaker(ctx context.Context, id string) error
+	RestoreCaretaker(ctx context.Context, id string) error
+	PurgeCaretaker(ctx context.Context, id string, retention time.Duration) error
 	ListCaretakers(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Caretaker, error)
 }
 
 type caretakerService struct {
-	repo repository.CaretakerRepository
+	repo   repository.CaretakerRepository
+	access AccessLogService
 }
 
-func NewCaretakerService(repo repository.CaretakerRepository) CaretakerService {
+func NewCaretakerService(repo repository.CaretakerRepository, access AccessLogService) CaretakerService {
 	return &caretakerService{
-		repo: repo,
+		repo:   repo,
+		access: access,
 	}
 }
 
 func (s *caretakerService) CreateCaretaker(ctx context.Context, caretaker *models.Caretaker) error {
+	if err := validateCaretaker(caretaker); err != nil {
+		return err
+	}
 	return s.repo.Create(ctx, caretaker)
 }
 
+// GetCaretaker retorna o responsável; a leitura dos contatos é registrada.
 func (s *caretakerService) GetCaretaker(ctx context.Context, id string) (*models.Caretaker, error) {
-	return s.repo.GetByID(ctx, id)
+	caretaker, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.access.RecordAccess(ctx, models.AccessContactDetails, models.AccessEntityCaretaker, caretaker.ID); err != nil {
+		return nil, err
+	}
+	return caretaker, nil
 }
 
 func (s *caretakerService) UpdateCaretaker(ctx context.Context, caretaker *models.Caretaker) error {
+	if err := validateCaretaker(caretaker); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, caretaker)
 }
 
@@ -42,6 +62,28 @@ func (s *caretakerService) DeleteCaretaker(ctx context.Context, id string) error
 	return s.repo.Delete(ctx, id)
 }
 
+func (s *caretakerService) RestoreCaretaker(ctx context.Context, id string) error {
+	return s.repo.Restore(ctx, id)
+}
+
+// PurgeCaretaker remove definitivamente o registro, respeitando o período de retenção
+// contado a partir da exclusão lógica.
+func (s *caretakerService) PurgeCaretaker(ctx context.Context, id string, retention time.Duration) error {
+	return s.repo.Purge(ctx, id, time.Now().Add(-retention))
+}
+
 func (s *caretakerService) ListCaretakers(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Caretaker, error) {
-	return s.repo.List(ctx, filter, page, pageSize)
+	caretakers, err := s.repo.List(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(caretakers))
+	for _, caretaker := range caretakers {
+		ids = append(ids, caretaker.ID)
+	}
+	if err := s.access.RecordAccess(ctx, models.AccessContactDetails, models.AccessEntityCaretaker, ids...); err != nil {
+		return nil, err
+	}
+	return caretakers, nil
 }
@@ -3,7 +3,7 @@ package services
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
 	"github.com/eduardohass/kids-api/internal/repository"
@@ -14,6 +14,8 @@ type ChildService interface {
 	GetChild(ctx context.Context, id string) (*models.Child, error)
 	UpdateChild(ctx context.Context, child *models.Child) error
 	DeleteChild(ctx context.Context, id string) error
+	RestoreChild(ctx context.Context, id string) error
+	PurgeChild(ctx context.Context, id string, retention time.Duration) error
 	ListChildren(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Child, error)
 }
 
@@ -37,16 +39,8 @@ func NewChildService(
 
 func (s *childService) CreateChild(ctx context.Context, child *models.Child) error {
 	// Validações
-	if child.Name == "" {
-		return errors.New("child name is required")
-	}
-
-	if child.BirthDate.IsZero() {
-		return errors.New("birth date is required")
-	}
-
-	if child.Gender == "" {
-		return errors.New("gender is required")
+	if err := validateChild(child); err != nil {
+		return err
 	}
 
 	// Verificar se necessidades existem
@@ -80,11 +74,24 @@ func (s *childService) DeleteChild(ctx context.Context, id string) error {
 	return s.childRepo.Delete(ctx, id)
 }
 
+func (s *childService) RestoreChild(ctx context.Context, id string) error {
+	return s.childRepo.Restore(ctx, id)
+}
+
+// PurgeChild remove definitivamente o registro, respeitando o período de retenção
+// contado a partir da exclusão lógica.
+func (s *childService) PurgeChild(ctx context.Context, id string, retention time.Duration) error {
+	return s.childRepo.Purge(ctx, id, time.Now().Add(-retention))
+}
+
 func (s *childService) GetChild(ctx context.Context, id string) (*models.Child, error) {
 	return s.childRepo.GetByID(ctx, id)
 }
 
 func (s *childService) UpdateChild(ctx context.Context, child *models.Child) error {
+	if err := validateChild(child); err != nil {
+		return err
+	}
 	return s.childRepo.Update(ctx, child)
 }
 
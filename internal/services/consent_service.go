@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ConsentService publica os termos e registra os aceites dos responsáveis.
+// Os métodos "My" recebem o claim "sub" do JWT e valem apenas para o próprio responsável.
+type ConsentService interface {
+	PublishDocument(ctx context.Context, document *models.ConsentDocument, publishedBy string) error
+	GetDocument(ctx context.Context, id string) (*models.ConsentDocument, error)
+	ListDocuments(ctx context.Context, kind string) ([]*models.ConsentDocument, error)
+	RecordConsent(ctx context.Context, record *models.ConsentRecord, recordedBy string) error
+	RecordMyConsent(ctx context.Context, sub, childID string, record *models.ConsentRecord) error
+	ListConsents(ctx context.Context, childID string) ([]*models.ConsentRecord, error)
+	ListMyConsents(ctx context.Context, sub, childID string) ([]*models.ConsentRecord, error)
+	RevokeConsent(ctx context.Context, id, revokedBy, reason string) (*models.ConsentRecord, error)
+	RevokeMyConsent(ctx context.Context, sub, id, reason string) (*models.ConsentRecord, error)
+	ApplyStatus(ctx context.Context, children ...*models.Child) error
+	CheckInWarnings(ctx context.Context, childIDs []string) (map[string][]string, error)
+}
+
+type consentService struct {
+	repo          repository.ConsentRepository
+	childRepo     repository.ChildRepository
+	caretakerRepo repository.CaretakerRepository
+	me            MeService
+}
+
+func NewConsentService(
+	repo repository.ConsentRepository,
+	childRepo repository.ChildRepository,
+	caretakerRepo repository.CaretakerRepository,
+	me MeService,
+) ConsentService {
+	return &consentService{
+		repo:          repo,
+		childRepo:     childRepo,
+		caretakerRepo: caretakerRepo,
+		me:            me,
+	}
+}
+
+// PublishDocument grava uma nova versão do termo; os aceites das versões
+// anteriores passam a constar como desatualizados.
+func (s *consentService) PublishDocument(ctx context.Context, document *models.ConsentDocument, publishedBy string) error {
+	if err := validateConsentDocument(document); err != nil {
+		return err
+	}
+
+	document.PublishedBy = publishedBy
+	return s.repo.CreateDocument(ctx, document)
+}
+
+func (s *consentService) GetDocument(ctx context.Context, id string) (*models.ConsentDocument, error) {
+	return s.repo.GetDocument(ctx, id)
+}
+
+func (s *consentService) ListDocuments(ctx context.Context, kind string) ([]*models.ConsentDocument, error) {
+	return s.repo.ListDocuments(ctx, kind)
+}
+
+// RecordConsent registra, pela equipe, um aceite colhido em papel. O
+// responsável precisa estar vinculado à criança.
+func (s *consentService) RecordConsent(ctx context.Context, record *models.ConsentRecord, recordedBy string) error {
+	if record.CaretakerID == "" {
+		return invalid("caretaker_id is required")
+	}
+	record.RecordedBy = recordedBy
+	return s.record(ctx, record)
+}
+
+// RecordMyConsent registra o aceite do próprio responsável autenticado.
+func (s *consentService) RecordMyConsent(ctx context.Context, sub, childID string, record *models.ConsentRecord) error {
+	caretaker, err := s.me.GetProfile(ctx, sub)
+	if err != nil {
+		return err
+	}
+
+	record.ChildID = childID
+	record.CaretakerID = caretaker.ID
+	record.RecordedBy = sub
+	return s.record(ctx, record)
+}
+
+func (s *consentService) record(ctx context.Context, record *models.ConsentRecord) error {
+	if record.DocumentID == "" {
+		return invalid("document_id is required")
+	}
+
+	document, err := s.repo.GetDocument(ctx, record.DocumentID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.childRepo.GetByID(ctx, record.ChildID); err != nil {
+		return err
+	}
+
+	if err := s.requireLinked(ctx, record.ChildID, record.CaretakerID); err != nil {
+		return err
+	}
+
+	record.Kind = document.Kind
+	record.DocumentVersion = document.Version
+	return s.repo.CreateRecord(ctx, record)
+}
+
+func (s *consentService) ListConsents(ctx context.Context, childID string) ([]*models.ConsentRecord, error) {
+	if _, err := s.childRepo.GetByID(ctx, childID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRecords(ctx, []string{childID})
+}
+
+func (s *consentService) ListMyConsents(ctx context.Context, sub, childID string) ([]*models.ConsentRecord, error) {
+	caretaker, err := s.me.GetProfile(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requireLinked(ctx, childID, caretaker.ID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRecords(ctx, []string{childID})
+}
+
+func (s *consentService) RevokeConsent(ctx context.Context, id, revokedBy, reason string) (*models.ConsentRecord, error) {
+	record, err := s.repo.GetRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.revoke(ctx, record, revokedBy, reason)
+}
+
+// RevokeMyConsent revoga um aceite dado pelo próprio responsável.
+func (s *consentService) RevokeMyConsent(ctx context.Context, sub, id, reason string) (*models.ConsentRecord, error) {
+	caretaker, err := s.me.GetProfile(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.repo.GetRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.CaretakerID != caretaker.ID {
+		return nil, repository.ErrNotFound
+	}
+	return s.revoke(ctx, record, sub, reason)
+}
+
+func (s *consentService) revoke(ctx context.Context, record *models.ConsentRecord, revokedBy, reason string) (*models.ConsentRecord, error) {
+	record.RevokedBy = revokedBy
+	record.RevocationReason = reason
+	if err := s.repo.RevokeRecord(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ApplyStatus preenche media_release e care_terms das crianças. Tipos sem
+// termo publicado ficam em branco.
+func (s *consentService) ApplyStatus(ctx context.Context, children ...*models.Child) error {
+	if len(children) == 0 {
+		return nil
+	}
+
+	childIDs := make([]string, 0, len(children))
+	for _, child := range children {
+		childIDs = append(childIDs, child.ID)
+	}
+
+	statuses, err := s.statuses(ctx, childIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		child.MediaRelease = statuses[child.ID][models.ConsentMediaRelease]
+		child.CareTerms = statuses[child.ID][models.ConsentCareTerms]
+	}
+	return nil
+}
+
+// CheckInWarnings lista, por criança, os termos obrigatórios sem aceite
+// válido na versão vigente.
+func (s *consentService) CheckInWarnings(ctx context.Context, childIDs []string) (map[string][]string, error) {
+	statuses, err := s.statuses(ctx, childIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make(map[string][]string)
+	for _, childID := range childIDs {
+		for _, kind := range models.ConsentKinds {
+			status := statuses[childID][kind]
+			if status == nil || !status.Required || status.Valid() {
+				continue
+			}
+			warnings[childID] = append(warnings[childID], fmt.Sprintf("%s consent is %s", kind, status.Status))
+		}
+	}
+	return warnings, nil
+}
+
+// statuses calcula a situação de cada tipo de termo por criança: vale a
+// resposta mais recente não revogada, comparada com a versão vigente.
+func (s *consentService) statuses(ctx context.Context, childIDs []string) (map[string]map[string]*models.ConsentStatus, error) {
+	documents, err := s.repo.CurrentDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListRecords(ctx, childIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]*models.ConsentStatus, len(childIDs))
+	for _, childID := range childIDs {
+		result[childID] = make(map[string]*models.ConsentStatus)
+		for _, document := range documents {
+			result[childID][document.Kind] = &models.ConsentStatus{
+				Status:         models.ConsentMissing,
+				Required:       document.Required,
+				CurrentVersion: document.Version,
+			}
+		}
+	}
+
+	// records vem do mais recente ao mais antigo
+	for _, record := range records {
+		status, ok := result[record.ChildID][record.Kind]
+		if !ok || (status.Status != models.ConsentMissing && status.Status != models.ConsentRevoked) {
+			continue
+		}
+
+		if record.RevokedAt != nil {
+			status.Status = models.ConsentRevoked
+			continue
+		}
+
+		acceptedAt := record.AcceptedAt
+		status.DocumentVersion = record.DocumentVersion
+		status.CaretakerID = record.CaretakerID
+		status.AcceptedAt = &acceptedAt
+		switch {
+		case record.DocumentVersion < status.CurrentVersion:
+			status.Status = models.ConsentOutdated
+		case record.Granted:
+			status.Status = models.ConsentGranted
+		default:
+			status.Status = models.ConsentDenied
+		}
+	}
+
+	return result, nil
+}
+
+// requireLinked garante que o responsável está vinculado à criança.
+func (s *consentService) requireLinked(ctx context.Context, childID, caretakerID string) error {
+	contacts, err := s.caretakerRepo.ListContacts(ctx, childID)
+	if err != nil {
+		return err
+	}
+	if !containsCaretaker(contacts, caretakerID) {
+		return invalid("caretaker is not linked to this child")
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/jobs"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// JobProcessDataRequest é o tipo do trabalho que executa um pedido do titular.
+const JobProcessDataRequest = "data_subject.process"
+
+// ProcessDataRequestPayload identifica o pedido a ser executado.
+type ProcessDataRequestPayload struct {
+	RequestID string `json:"request_id"`
+}
+
+const (
+	// Tamanho das páginas lidas ao montar a exportação.
+	exportPageSize = 500
+	// Limite de cada foto incluída no arquivo.
+	maxExportPhotoBytes = 10 << 20
+)
+
+type DataSubjectService interface {
+	CreateRequest(ctx context.Context, request *models.DataSubjectRequest, requestedBy string) error
+	GetRequest(ctx context.Context, id string) (*models.DataSubjectRequest, error)
+	ListRequests(ctx context.Context, caretakerID string, page, pageSize int) ([]*models.DataSubjectRequest, error)
+	GetArchive(ctx context.Context, id string) ([]byte, error)
+	Process(ctx context.Context, job *models.Job, payload ProcessDataRequestPayload) error
+}
+
+type dataSubjectService struct {
+	repo               repository.DataSubjectRepository
+	caretakerRepo      repository.CaretakerRepository
+	householdRepo      repository.HouseholdRepository
+	childRepo          repository.ChildRepository
+	medicalProfileRepo repository.MedicalProfileRepository
+	attendanceRepo     repository.AttendanceRepository
+	pickupGrantRepo    repository.PickupGrantRepository
+	medicationRepo     repository.MedicationRepository
+	incidentRepo       repository.IncidentRepository
+	consentRepo        repository.ConsentRepository
+	auditRepo          repository.AuditRepository
+	queue              *jobs.Queue
+	photos             *photoClient
+}
+
+func NewDataSubjectService(
+	repo repository.DataSubjectRepository,
+	caretakerRepo repository.CaretakerRepository,
+	householdRepo repository.HouseholdRepository,
+	childRepo repository.ChildRepository,
+	medicalProfileRepo repository.MedicalProfileRepository,
+	attendanceRepo repository.AttendanceRepository,
+	pickupGrantRepo repository.PickupGrantRepository,
+	medicationRepo repository.MedicationRepository,
+	incidentRepo repository.IncidentRepository,
+	consentRepo repository.ConsentRepository,
+	auditRepo repository.AuditRepository,
+	queue *jobs.Queue,
+	photoHosts []string,
+) DataSubjectService {
+	return &dataSubjectService{
+		repo:               repo,
+		caretakerRepo:      caretakerRepo,
+		householdRepo:      householdRepo,
+		childRepo:          childRepo,
+		medicalProfileRepo: medicalProfileRepo,
+		attendanceRepo:     attendanceRepo,
+		pickupGrantRepo:    pickupGrantRepo,
+		medicationRepo:     medicationRepo,
+		incidentRepo:       incidentRepo,
+		consentRepo:        consentRepo,
+		auditRepo:          auditRepo,
+		queue:              queue,
+		photos:             newPhotoClient(photoHosts),
+	}
+}
+
+// CreateRequest registra o pedido e agenda a execução na fila.
+func (s *dataSubjectService) CreateRequest(ctx context.Context, request *models.DataSubjectRequest, requestedBy string) error {
+	if err := validateDataSubjectRequest(request); err != nil {
+		return err
+	}
+
+	if _, err := s.caretakerRepo.GetByID(ctx, request.CaretakerID); err != nil {
+		return err
+	}
+
+	request.RequestedBy = requestedBy
+	if err := s.repo.Create(ctx, request); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{
+		"caretaker_id": request.CaretakerID,
+		"type":         request.Type,
+		"reason":       request.Reason,
+	}
+	if err := recordAudit(ctx, s.auditRepo, requestedBy, AuditDataRequestCreated, AuditEntityDataRequest, request.ID, details); err != nil {
+		return err
+	}
+
+	_, err := s.queue.Enqueue(ctx, JobProcessDataRequest, ProcessDataRequestPayload{RequestID: request.ID})
+	return err
+}
+
+func (s *dataSubjectService) GetRequest(ctx context.Context, id string) (*models.DataSubjectRequest, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *dataSubjectService) ListRequests(ctx context.Context, caretakerID string, page, pageSize int) ([]*models.DataSubjectRequest, error) {
+	offset := (page - 1) * pageSize
+	return s.repo.List(ctx, caretakerID, pageSize, offset)
+}
+
+func (s *dataSubjectService) GetArchive(ctx context.Context, id string) ([]byte, error) {
+	return s.repo.GetArchive(ctx, id)
+}
+
+// Process é o handler do trabalho JobProcessDataRequest. Em caso de falha o
+// erro é devolvido para que a fila tente de novo; o pedido só é marcado como
+// falho na última tentativa.
+func (s *dataSubjectService) Process(ctx context.Context, job *models.Job, payload ProcessDataRequestPayload) error {
+	request, err := s.repo.GetByID(ctx, payload.RequestID)
+	if err != nil {
+		return err
+	}
+	if request.Status == models.DataRequestCompleted {
+		return nil
+	}
+
+	if err := s.repo.Start(ctx, request.ID); err != nil {
+		return err
+	}
+
+	var (
+		result  interface{}
+		archive []byte
+		action  string
+	)
+	switch request.Type {
+	case models.DataRequestExport:
+		action = AuditDataExported
+		var export *models.DataSubjectExport
+		if export, archive, err = s.export(ctx, request.CaretakerID); err == nil {
+			result = map[string]interface{}{
+				"children":      len(export.Children),
+				"notifications": len(export.Notifications),
+				"archive_bytes": len(archive),
+			}
+		}
+	case models.DataRequestErasure:
+		action = AuditDataErased
+		result, err = s.erase(ctx, request.CaretakerID)
+	default:
+		err = invalid("unknown request type " + request.Type)
+	}
+
+	if err != nil {
+		if job.IsLastAttempt() || errors.Is(err, ErrInvalidInput) {
+			if failErr := s.repo.Fail(ctx, request.ID, err.Error()); failErr != nil {
+				return failErr
+			}
+			details := map[string]interface{}{"caretaker_id": request.CaretakerID, "error": err.Error()}
+			if auditErr := recordAudit(ctx, s.auditRepo, request.RequestedBy, AuditDataRequestFailed, AuditEntityDataRequest, request.ID, details); auditErr != nil {
+				return auditErr
+			}
+		}
+		return err
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Complete(ctx, request.ID, raw, archive); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"caretaker_id": request.CaretakerID, "result": result}
+	return recordAudit(ctx, s.auditRepo, request.RequestedBy, action, AuditEntityDataRequest, request.ID, details)
+}
+
+// export monta o arquivo zip com data.json e as fotos das crianças.
+func (s *dataSubjectService) export(ctx context.Context, caretakerID string) (*models.DataSubjectExport, []byte, error) {
+	caretaker, err := s.caretakerRepo.GetByID(ctx, caretakerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	export := &models.DataSubjectExport{
+		GeneratedAt: time.Now().UTC(),
+		Caretaker:   caretaker,
+		Children:    []models.DataSubjectChild{},
+	}
+
+	// Apenas os dados da família; os demais membros não fazem parte do pedido
+	household, err := s.householdRepo.GetByCaretaker(ctx, caretakerID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, err
+	}
+	export.Household = household
+
+	linked, err := s.repo.ListLinkedChildren(ctx, caretakerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	childIDs := make([]string, 0, len(linked))
+	for _, link := range linked {
+		entry, err := s.exportChild(ctx, link.ChildID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if entry.Child.PhotoURL != "" {
+			entry.Photo, err = s.addPhoto(ctx, archive, entry.Child)
+			if err != nil {
+				// A falha ao baixar a foto não impede a exportação; fica registrada no arquivo
+				entry.Photo = ""
+				entry.PhotoError = err.Error()
+			}
+		}
+
+		childIDs = append(childIDs, link.ChildID)
+		export.Children = append(export.Children, *entry)
+	}
+
+	if export.Notifications, err = s.repo.ListNotifications(ctx, caretakerID, childIDs); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := archive.Create("data.json")
+	if err != nil {
+		return nil, nil, err
+	}
+	encoder := json.NewEncoder(data)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return nil, nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, nil, err
+	}
+	return export, buf.Bytes(), nil
+}
+
+func (s *dataSubjectService) exportChild(ctx context.Context, childID string) (*models.DataSubjectChild, error) {
+	child, err := s.childRepo.GetByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.DataSubjectChild{Child: child}
+
+	if entry.MedicalProfile, err = s.medicalProfileRepo.Get(ctx, childID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	entry.Attendance = []*models.Attendance{}
+	for offset := 0; ; offset += exportPageSize {
+		page, err := s.attendanceRepo.ListByChildren(ctx, []string{childID}, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		entry.Attendance = append(entry.Attendance, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	if entry.PickupGrants, err = s.pickupGrantRepo.ListByChild(ctx, childID); err != nil {
+		return nil, err
+	}
+
+	if entry.MedicationOrders, err = s.medicationRepo.ListOrdersByChild(ctx, childID, true); err != nil {
+		return nil, err
+	}
+
+	entry.MedicationAdministrations = []*models.MedicationAdministration{}
+	for offset := 0; ; offset += exportPageSize {
+		page, err := s.medicationRepo.ListAdministrationsByChild(ctx, childID, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		entry.MedicationAdministrations = append(entry.MedicationAdministrations, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	entry.Incidents = []*models.Incident{}
+	filter := map[string]interface{}{"child_id": childID}
+	for offset := 0; ; offset += exportPageSize {
+		page, err := s.incidentRepo.List(ctx, filter, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		entry.Incidents = append(entry.Incidents, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	if entry.Consents, err = s.consentRepo.ListRecords(ctx, []string{childID}); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// addPhoto baixa a foto da criança e a grava em photos/ no arquivo, retornando o caminho.
+func (s *dataSubjectService) addPhoto(ctx context.Context, archive *zip.Writer, child *models.Child) (string, error) {
+	resp, err := s.photos.Get(ctx, child.PhotoURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("photo download returned %s", resp.Status)
+	}
+
+	photo, err := io.ReadAll(io.LimitReader(resp.Body, maxExportPhotoBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(photo) > maxExportPhotoBytes {
+		return "", fmt.Errorf("photo exceeds %d bytes", maxExportPhotoBytes)
+	}
+
+	ext := path.Ext(resp.Request.URL.Path)
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+
+	name := "photos/" + child.ID + ext
+	file, err := archive.Create(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(photo); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// erase anonimiza o responsável e as crianças vinculadas apenas a ele. As
+// crianças com outro responsável ativo são mantidas, pois os dados também
+// pertencem a ele; o vínculo com o titular é removido.
+func (s *dataSubjectService) erase(ctx context.Context, caretakerID string) (*models.DataSubjectErasure, error) {
+	linked, err := s.repo.ListLinkedChildren(ctx, caretakerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.DataSubjectErasure{
+		ErasedChildren:   []string{},
+		RetainedChildren: []string{},
+	}
+	for _, link := range linked {
+		if link.OtherCaretakers > 0 {
+			result.RetainedChildren = append(result.RetainedChildren, link.ChildID)
+			continue
+		}
+		result.ErasedChildren = append(result.ErasedChildren, link.ChildID)
+	}
+
+	if err := s.repo.Erase(ctx, caretakerID, result.ErasedChildren); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/pii"
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// ErrUnknownMasterKey is returned when a data key was wrapped by a master key
+// that is neither the current nor the previous one in the configuration.
+var ErrUnknownMasterKey = errors.New("data key wrapped by an unknown master key")
+
+// EncryptionKeys reúne as chaves da configuração. Previous é opcional e só é
+// necessária enquanto houver chaves de dados cifradas pela chave mestra anterior.
+type EncryptionKeys struct {
+	Master     []byte
+	Previous   []byte
+	BlindIndex []byte
+}
+
+// RotationResult resume uma rotação de chaves.
+type RotationResult struct {
+	ActiveKeyID int `json:"active_key_id"`
+	Rewrapped   int `json:"rewrapped"`
+	Reencrypted int `json:"reencrypted"`
+}
+
+type EncryptionService interface {
+	LoadKeyring(ctx context.Context) (*pii.Keyring, error)
+	Rotate(ctx context.Context) (*RotationResult, error)
+}
+
+type encryptionService struct {
+	keyRepo    repository.EncryptionKeyRepository
+	piiRepo    repository.PIIRepository
+	tenantRepo repository.TenantRepository
+	keys       EncryptionKeys
+	batchSize  int
+}
+
+func NewEncryptionService(
+	keyRepo repository.EncryptionKeyRepository,
+	piiRepo repository.PIIRepository,
+	tenantRepo repository.TenantRepository,
+	keys EncryptionKeys,
+	batchSize int,
+) EncryptionService {
+	return &encryptionService{
+		keyRepo:    keyRepo,
+		piiRepo:    piiRepo,
+		tenantRepo: tenantRepo,
+		keys:       keys,
+		batchSize:  batchSize,
+	}
+}
+
+// LoadKeyring abre todas as chaves de dados com a chave mestra. Na primeira
+// execução, cria a chave de dados inicial.
+func (s *encryptionService) LoadKeyring(ctx context.Context) (*pii.Keyring, error) {
+	keys, err := s.keyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		key, err := s.createKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	keyring := pii.NewKeyring(s.keys.BlindIndex)
+	for _, key := range keys {
+		dataKey, err := s.unwrap(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %d: %w", key.ID, err)
+		}
+		if err := keyring.Add(key.ID, dataKey); err != nil {
+			return nil, fmt.Errorf("encryption key %d: %w", key.ID, err)
+		}
+		if key.Active {
+			if err := keyring.SetActive(key.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return keyring, nil
+}
+
+// Rotate cifra novamente com a chave mestra atual as chaves de dados que ainda
+// usam a anterior, cria uma nova chave de dados ativa e recifra, em lotes, os
+// dados pessoais de todos os tenants com ela.
+func (s *encryptionService) Rotate(ctx context.Context) (*RotationResult, error) {
+	result := &RotationResult{}
+
+	keys, err := s.keyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	masterID := pii.MasterKeyID(s.keys.Master)
+	for _, key := range keys {
+		if key.MasterKeyID == masterID {
+			continue
+		}
+
+		dataKey, err := s.unwrap(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %d: %w", key.ID, err)
+		}
+		if key.WrappedKey, err = pii.Wrap(s.keys.Master, dataKey); err != nil {
+			return nil, err
+		}
+		key.MasterKeyID = masterID
+		if err := s.keyRepo.Rewrap(ctx, key); err != nil {
+			return nil, err
+		}
+		result.Rewrapped++
+	}
+
+	active, err := s.createKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.ActiveKeyID = active.ID
+
+	keyring, err := s.LoadKeyring(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Os valores pii.String passam a ser gravados com a nova chave
+	pii.SetKeyring(keyring)
+
+	tenants, err := s.tenantRepo.ListIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tenantID := range tenants {
+		tenantCtx := tenant.WithID(ctx, tenantID)
+		for _, column := range repository.PIIColumns {
+			for {
+				count, err := s.piiRepo.ReencryptBatch(tenantCtx, column, keyring.ActivePrefix(), s.batchSize)
+				if err != nil {
+					return nil, err
+				}
+				result.Reencrypted += count
+				if count < s.batchSize {
+					break
+				}
+			}
+			log.Printf("Re-encrypted %s.%s for tenant %s", column.Table, column.Column, tenantID)
+		}
+	}
+
+	return result, nil
+}
+
+// createKey gera uma chave de dados e a grava, cifrada, como a chave ativa.
+func (s *encryptionService) createKey(ctx context.Context) (*models.EncryptionKey, error) {
+	dataKey, err := pii.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := pii.Wrap(s.keys.Master, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &models.EncryptionKey{
+		WrappedKey:  wrapped,
+		MasterKeyID: pii.MasterKeyID(s.keys.Master),
+	}
+	if err := s.keyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// unwrap abre a chave de dados com a chave mestra que a cifrou.
+func (s *encryptionService) unwrap(key *models.EncryptionKey) ([]byte, error) {
+	switch {
+	case key.MasterKeyID == pii.MasterKeyID(s.keys.Master):
+		return pii.Unwrap(s.keys.Master, key.WrappedKey)
+	case s.keys.Previous != nil && key.MasterKeyID == pii.MasterKeyID(s.keys.Previous):
+		return pii.Unwrap(s.keys.Previous, key.WrappedKey)
+	default:
+		return nil, ErrUnknownMasterKey
+	}
+}
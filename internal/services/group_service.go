@@ -3,6 +3,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
 	"github.com/eduardohass/kids-api/internal/repository"
@@ -13,6 +14,8 @@ type GroupService interface {
 	GetGroup(ctx context.Context, id string) (*models.Group, error)
 	UpdateGroup(ctx context.Context, group *models.Group) error
 	DeleteGroup(ctx context.Context, id string) error
+	RestoreGroup(ctx context.Context, id string) error
+	PurgeGroup(ctx context.Context, id string, retention time.Duration) error
 	ListGroups(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Group, error)
 }
 
@@ -27,6 +30,9 @@ func NewGroupService(repo repository.GroupRepository) GroupService {
 }
 
 func (s *groupService) CreateGroup(ctx context.Context, group *models.Group) error {
+	if err := validateGroup(group); err != nil {
+		return err
+	}
 	return s.repo.Create(ctx, group)
 }
 
@@ -35,6 +41,9 @@ func (s *groupService) GetGroup(ctx context.Context, id string) (*models.Group,
 }
 
 func (s *groupService) UpdateGroup(ctx context.Context, group *models.Group) error {
+	if err := validateGroup(group); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, group)
 }
 
@@ -42,6 +51,16 @@ func (s *groupService) DeleteGroup(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
 
+func (s *groupService) RestoreGroup(ctx context.Context, id string) error {
+	return s.repo.Restore(ctx, id)
+}
+
+// PurgeGroup remove definitivamente o registro, respeitando o período de retenção
+// contado a partir da exclusão lógica.
+func (s *groupService) PurgeGroup(ctx context.Context, id string, retention time.Duration) error {
+	return s.repo.Purge(ctx, id, time.Now().Add(-retention))
+}
+
 func (s *groupService) ListGroups(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Group, error) {
 	return s.repo.List(ctx, filter, page, pageSize)
 }
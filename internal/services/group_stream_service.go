@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/realtime"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// Limites do replay de eventos para clientes que reconectam.
+const (
+	groupEventReplayWindow = 24 * time.Hour
+	groupEventReplayLimit  = 500
+)
+
+// EventPublisher publica eventos no painel em tempo real das salas.
+type EventPublisher interface {
+	Publish(ctx context.Context, groupID, eventType string, data interface{})
+}
+
+type GroupStreamService interface {
+	EventPublisher
+	Subscribe(groupID string) (<-chan *models.GroupEvent, func())
+	Replay(ctx context.Context, groupID string, afterID int64) ([]*models.GroupEvent, error)
+}
+
+type groupStreamService struct {
+	repo      repository.GroupEventRepository
+	groupRepo repository.GroupRepository
+	broker    *realtime.Broker
+}
+
+func NewGroupStreamService(
+	repo repository.GroupEventRepository,
+	groupRepo repository.GroupRepository,
+	broker *realtime.Broker,
+) GroupStreamService {
+	return &groupStreamService{
+		repo:      repo,
+		groupRepo: groupRepo,
+		broker:    broker,
+	}
+}
+
+// Publish grava e distribui o evento. O painel é informativo: uma falha aqui
+// é registrada no log e não interrompe a operação que gerou o evento.
+func (s *groupStreamService) Publish(ctx context.Context, groupID, eventType string, data interface{}) {
+	if groupID == "" {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Group event %s for group %s: %v", eventType, groupID, err)
+		return
+	}
+
+	event := &models.GroupEvent{GroupID: groupID, Type: eventType, Data: raw}
+	if err := s.repo.Publish(ctx, event); err != nil {
+		log.Printf("Group event %s for group %s: %v", eventType, groupID, err)
+	}
+}
+
+func (s *groupStreamService) Subscribe(groupID string) (<-chan *models.GroupEvent, func()) {
+	return s.broker.Subscribe(groupID)
+}
+
+// Replay retorna os eventos da sala posteriores a afterID, limitados às
+// últimas 24 horas. Também valida que a sala existe.
+func (s *groupStreamService) Replay(ctx context.Context, groupID string, afterID int64) ([]*models.GroupEvent, error) {
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	if afterID <= 0 {
+		return []*models.GroupEvent{}, nil
+	}
+
+	since := time.Now().Add(-groupEventReplayWindow)
+	return s.repo.ListSince(ctx, groupID, afterID, since, groupEventReplayLimit)
+}
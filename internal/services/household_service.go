@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+type HouseholdService interface {
+	CreateHousehold(ctx context.Context, household *models.Household) error
+	GetHousehold(ctx context.Context, id string) (*models.Household, error)
+	UpdateHousehold(ctx context.Context, household *models.Household) error
+	DeleteHousehold(ctx context.Context, id string) error
+	AddChild(ctx context.Context, householdID, childID string) error
+	AddCaretaker(ctx context.Context, householdID, caretakerID string) error
+	CheckIn(ctx context.Context, householdID string, req models.CheckInRequest, checkedInBy string) ([]*models.Attendance, error)
+}
+
+type householdService struct {
+	repo              repository.HouseholdRepository
+	attendanceService AttendanceService
+	access            AccessLogService
+}
+
+func NewHouseholdService(
+	repo repository.HouseholdRepository,
+	attendanceService AttendanceService,
+	access AccessLogService,
+) HouseholdService {
+	return &householdService{
+		repo:              repo,
+		attendanceService: attendanceService,
+		access:            access,
+	}
+}
+
+func (s *householdService) CreateHousehold(ctx context.Context, household *models.Household) error {
+	if err := validateHousehold(household); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, household)
+}
+
+// GetHousehold retorna a família com responsáveis, crianças e relações. A
+// leitura do endereço e dos contatos é registrada.
+func (s *householdService) GetHousehold(ctx context.Context, id string) (*models.Household, error) {
+	household, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.LoadMembers(ctx, household); err != nil {
+		return nil, err
+	}
+
+	if err := s.access.RecordAccess(ctx, models.AccessContactDetails, models.AccessEntityHousehold, household.ID); err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+func (s *householdService) UpdateHousehold(ctx context.Context, household *models.Household) error {
+	if err := validateHousehold(household); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, household)
+}
+
+func (s *householdService) DeleteHousehold(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *householdService) AddChild(ctx context.Context, householdID, childID string) error {
+	if _, err := s.repo.GetByID(ctx, householdID); err != nil {
+		return err
+	}
+	return s.repo.AddChild(ctx, householdID, childID)
+}
+
+func (s *householdService) AddCaretaker(ctx context.Context, householdID, caretakerID string) error {
+	if _, err := s.repo.GetByID(ctx, householdID); err != nil {
+		return err
+	}
+	return s.repo.AddCaretaker(ctx, householdID, caretakerID)
+}
+
+// CheckIn faz o check-in das crianças da família de uma só vez. Quando
+// req.ChildIDs é informado, apenas essas crianças (da própria família) entram.
+func (s *householdService) CheckIn(ctx context.Context, householdID string, req models.CheckInRequest, checkedInBy string) ([]*models.Attendance, error) {
+	household, err := s.GetHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ChildIDs, err = householdChildIDs(household, req.ChildIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.attendanceService.CheckIn(ctx, req, checkedInBy)
+}
+
+// householdChildIDs valida que os IDs pertencem à família; sem IDs, retorna
+// todas as crianças da família.
+func householdChildIDs(household *models.Household, childIDs []string) ([]string, error) {
+	members := make(map[string]bool, len(household.Children))
+	for _, child := range household.Children {
+		members[child.ID] = true
+	}
+
+	if len(childIDs) == 0 {
+		for _, child := range household.Children {
+			childIDs = append(childIDs, child.ID)
+		}
+	}
+
+	for _, childID := range childIDs {
+		if !members[childID] {
+			return nil, invalid("child " + childID + " does not belong to this household")
+		}
+	}
+
+	return childIDs, nil
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/pdf"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ErrInvalidTransition is returned when an incident action does not apply to
+// the report's current workflow status.
+var ErrInvalidTransition = errors.New("action not allowed in the current incident status")
+
+type IncidentService interface {
+	CreateIncident(ctx context.Context, incident *models.Incident, reportedBy string) error
+	GetIncident(ctx context.Context, id string) (*models.Incident, error)
+	ListIncidents(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Incident, error)
+	UpdateIncident(ctx context.Context, incident *models.Incident) error
+	Submit(ctx context.Context, id string, version int, actor string) (*models.Incident, error)
+	Review(ctx context.Context, id string, version int, review models.IncidentReview, reviewer string) (*models.Incident, error)
+	Close(ctx context.Context, id string, version int, actor string) (*models.Incident, error)
+	Acknowledge(ctx context.Context, id, caretakerID, actor string) (*models.Incident, error)
+	ListForCaretaker(ctx context.Context, sub string) ([]*models.Incident, error)
+	AcknowledgeAsCaretaker(ctx context.Context, id, sub string) (*models.Incident, error)
+	ExportPDF(ctx context.Context, id string, w io.Writer) error
+}
+
+type incidentService struct {
+	repo          repository.IncidentRepository
+	childRepo     repository.ChildRepository
+	groupRepo     repository.GroupRepository
+	volunteerRepo repository.VolunteerRepository
+	caretakerRepo repository.CaretakerRepository
+	householdRepo repository.HouseholdRepository
+	auditRepo     repository.AuditRepository
+}
+
+func NewIncidentService(
+	repo repository.IncidentRepository,
+	childRepo repository.ChildRepository,
+	groupRepo repository.GroupRepository,
+	volunteerRepo repository.VolunteerRepository,
+	caretakerRepo repository.CaretakerRepository,
+	householdRepo repository.HouseholdRepository,
+	auditRepo repository.AuditRepository,
+) IncidentService {
+	return &incidentService{
+		repo:          repo,
+		childRepo:     childRepo,
+		groupRepo:     groupRepo,
+		volunteerRepo: volunteerRepo,
+		caretakerRepo: caretakerRepo,
+		householdRepo: householdRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// CreateIncident abre o relatório como rascunho. Sem group_id, usa a sala da criança.
+func (s *incidentService) CreateIncident(ctx context.Context, incident *models.Incident, reportedBy string) error {
+	if err := validateIncident(incident); err != nil {
+		return err
+	}
+
+	child, err := s.childRepo.GetByID(ctx, incident.ChildID)
+	if err != nil {
+		return err
+	}
+	if incident.GroupID == "" {
+		incident.GroupID = child.GroupID
+	}
+
+	normalizeIncident(incident)
+	incident.Status = models.IncidentDraft
+	incident.ReportedBy = reportedBy
+	return s.repo.Create(ctx, incident)
+}
+
+func (s *incidentService) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *incidentService) ListIncidents(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Incident, error) {
+	offset := (page - 1) * pageSize
+	return s.repo.List(ctx, filter, pageSize, offset)
+}
+
+// UpdateIncident altera o conteúdo do relatório; só é permitido no rascunho.
+func (s *incidentService) UpdateIncident(ctx context.Context, incident *models.Incident) error {
+	current, err := s.repo.GetByID(ctx, incident.ID)
+	if err != nil {
+		return err
+	}
+	if current.Status != models.IncidentDraft {
+		return ErrInvalidTransition
+	}
+
+	incident.ChildID = current.ChildID
+	if err := validateIncident(incident); err != nil {
+		return err
+	}
+
+	// Campos do fluxo não são alterados pela edição.
+	incident.Status = current.Status
+	incident.ReportedBy = current.ReportedBy
+	incident.CreatedAt = current.CreatedAt
+
+	normalizeIncident(incident)
+	return s.repo.Update(ctx, incident)
+}
+
+// Submit envia o rascunho para revisão. Exige ao menos um voluntário envolvido.
+func (s *incidentService) Submit(ctx context.Context, id string, version int, actor string) (*models.Incident, error) {
+	return s.transition(ctx, id, version, models.IncidentDraft, actor, AuditIncidentSubmitted, nil, func(incident *models.Incident, now time.Time) error {
+		if len(incident.VolunteerIDs) == 0 {
+			return invalid("at least one volunteer is required to submit the report")
+		}
+		incident.Status = models.IncidentSubmitted
+		incident.SubmittedAt = &now
+		return nil
+	})
+}
+
+// Review registra a assinatura do revisor, que não pode ser quem relatou.
+func (s *incidentService) Review(ctx context.Context, id string, version int, review models.IncidentReview, reviewer string) (*models.Incident, error) {
+	details := map[string]string{"notes": review.Notes}
+	return s.transition(ctx, id, version, models.IncidentSubmitted, reviewer, AuditIncidentReviewed, details, func(incident *models.Incident, now time.Time) error {
+		if reviewer == "" || reviewer == incident.ReportedBy {
+			return invalid("the report must be reviewed by someone other than the reporter")
+		}
+		incident.Status = models.IncidentReviewed
+		incident.ReviewedBy = reviewer
+		incident.ReviewedAt = &now
+		incident.ReviewNotes = review.Notes
+		return nil
+	})
+}
+
+func (s *incidentService) Close(ctx context.Context, id string, version int, actor string) (*models.Incident, error) {
+	return s.transition(ctx, id, version, models.IncidentReviewed, actor, AuditIncidentClosed, nil, func(incident *models.Incident, now time.Time) error {
+		incident.Status = models.IncidentClosed
+		incident.ClosedAt = &now
+		return nil
+	})
+}
+
+// transition aplica uma mudança de etapa a partir de from e a registra na auditoria.
+func (s *incidentService) transition(
+	ctx context.Context,
+	id string,
+	version int,
+	from, actor, action string,
+	details interface{},
+	apply func(incident *models.Incident, now time.Time) error,
+) (*models.Incident, error) {
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != from {
+		return nil, ErrInvalidTransition
+	}
+
+	if err := apply(incident, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if version != 0 {
+		incident.Version = version
+	}
+	if err := s.repo.Update(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, s.auditRepo, actor, action, AuditEntityIncident, incident.ID, details); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// Acknowledge registra a ciência do responsável, que precisa estar vinculado
+// à criança. Rascunhos ainda não são mostrados às famílias.
+func (s *incidentService) Acknowledge(ctx context.Context, id, caretakerID, actor string) (*models.Incident, error) {
+	if caretakerID == "" {
+		return nil, invalid("caretaker_id is required")
+	}
+
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status == models.IncidentDraft {
+		return nil, ErrInvalidTransition
+	}
+	if incident.AcknowledgedAt != nil {
+		return incident, nil
+	}
+
+	contacts, err := s.caretakerRepo.ListContacts(ctx, incident.ChildID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsCaretaker(contacts, caretakerID) {
+		return nil, invalid("caretaker is not linked to this child")
+	}
+
+	now := time.Now()
+	incident.AcknowledgedByCaretakerID = caretakerID
+	incident.AcknowledgedAt = &now
+	if err := s.repo.Update(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"caretaker_id": caretakerID}
+	if err := recordAudit(ctx, s.auditRepo, actor, AuditIncidentAcknowledged, AuditEntityIncident, incident.ID, details); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// ListForCaretaker lista os relatórios já enviados das crianças da família do
+// responsável autenticado.
+func (s *incidentService) ListForCaretaker(ctx context.Context, sub string) ([]*models.Incident, error) {
+	caretaker, err := s.caretakerBySubject(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := s.householdRepo.GetByCaretaker(ctx, caretaker.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.householdRepo.LoadMembers(ctx, household); err != nil {
+		return nil, err
+	}
+
+	incidents := []*models.Incident{}
+	for _, child := range household.Children {
+		childIncidents, err := s.repo.List(ctx, map[string]interface{}{"child_id": child.ID}, 100, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, incident := range childIncidents {
+			if incident.Status != models.IncidentDraft {
+				incidents = append(incidents, incident)
+			}
+		}
+	}
+	return incidents, nil
+}
+
+func (s *incidentService) AcknowledgeAsCaretaker(ctx context.Context, id, sub string) (*models.Incident, error) {
+	caretaker, err := s.caretakerBySubject(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	incident, err := s.Acknowledge(ctx, id, caretaker.ID, sub)
+	if errors.Is(err, ErrInvalidInput) || errors.Is(err, ErrInvalidTransition) {
+		// Não revela relatórios de outras famílias nem rascunhos.
+		return nil, repository.ErrNotFound
+	}
+	return incident, err
+}
+
+func (s *incidentService) caretakerBySubject(ctx context.Context, sub string) (*models.Caretaker, error) {
+	if sub == "" {
+		return nil, ErrNoCaretakerProfile
+	}
+
+	caretaker, err := s.caretakerRepo.GetByAuth0ID(ctx, sub)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrNoCaretakerProfile
+	}
+	return caretaker, err
+}
+
+// ExportPDF gera o relatório imprimível do incidente.
+func (s *incidentService) ExportPDF(ctx context.Context, id string, w io.Writer) error {
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	child, err := s.childRepo.GetByID(ctx, incident.ChildID)
+	if err != nil {
+		return err
+	}
+
+	groupName := ""
+	if incident.GroupID != "" {
+		if group, err := s.groupRepo.GetByID(ctx, incident.GroupID); err == nil {
+			groupName = group.Name
+		}
+	}
+
+	volunteers := make([]string, 0, len(incident.VolunteerIDs))
+	for _, volunteerID := range incident.VolunteerIDs {
+		if volunteer, err := s.volunteerRepo.GetByID(ctx, volunteerID); err == nil {
+			volunteers = append(volunteers, volunteer.Name)
+		}
+	}
+
+	acknowledgement := ""
+	if incident.AcknowledgedAt != nil {
+		acknowledgement = formatReportTime(incident.AcknowledgedAt)
+		if caretaker, err := s.caretakerRepo.GetByID(ctx, incident.AcknowledgedByCaretakerID); err == nil {
+			acknowledgement = caretaker.Name + " em " + acknowledgement
+		}
+	}
+
+	doc := pdf.New()
+	doc.Title("Relatório de Incidente")
+	doc.Field("Protocolo", incident.ID)
+	doc.Field("Situação", incident.Status)
+
+	doc.Heading("Ocorrência")
+	doc.Field("Criança", child.Name)
+	doc.Field("Sala", groupName)
+	doc.Field("Data e hora", formatReportTime(&incident.OccurredAt))
+	doc.Field("Tipo", incident.Type)
+	doc.Field("Descrição", incident.Description)
+	doc.Field("Primeiros socorros", incident.FirstAid)
+	doc.Field("Testemunhas", strings.Join(incident.Witnesses, ", "))
+	doc.Field("Voluntários", strings.Join(volunteers, ", "))
+
+	doc.Heading("Acompanhamento")
+	doc.Field("Relatado por", incident.ReportedBy)
+	doc.Field("Enviado em", formatReportTime(incident.SubmittedAt))
+	doc.Field("Revisado por", incident.ReviewedBy)
+	doc.Field("Revisado em", formatReportTime(incident.ReviewedAt))
+	doc.Field("Observações da revisão", incident.ReviewNotes)
+	doc.Field("Encerrado em", formatReportTime(incident.ClosedAt))
+	doc.Field("Ciência do responsável", acknowledgement)
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+func formatReportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("02/01/2006 15:04")
+}
+
+// normalizeIncident evita NULL nas colunas de lista.
+func normalizeIncident(incident *models.Incident) {
+	if incident.Witnesses == nil {
+		incident.Witnesses = []string{}
+	}
+	if incident.VolunteerIDs == nil {
+		incident.VolunteerIDs = []string{}
+	}
+}
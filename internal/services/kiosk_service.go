@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/auth"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ErrInvalidPairingCode is returned when a pairing code is unknown, expired or already used.
+var ErrInvalidPairingCode = errors.New("invalid or expired pairing code")
+
+const (
+	kioskPairingTTL        = 15 * time.Minute
+	kioskPairingCodeDigits = 8
+	maxKioskFamilies       = 10
+)
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+type KioskService interface {
+	Register(ctx context.Context, device *models.KioskDevice) (*models.KioskRegistration, error)
+	Pair(ctx context.Context, pairingCode string) (*models.KioskCredential, error)
+	List(ctx context.Context) ([]*models.KioskDevice, error)
+	Revoke(ctx context.Context, id string) error
+	VerifyDeviceToken(ctx context.Context, token string) (*models.KioskDevice, error)
+	SearchFamilies(ctx context.Context, phone string) ([]*models.KioskFamily, error)
+}
+
+type kioskService struct {
+	repo          repository.KioskRepository
+	householdRepo repository.HouseholdRepository
+	locationRepo  repository.LocationRepository
+}
+
+func NewKioskService(
+	repo repository.KioskRepository,
+	householdRepo repository.HouseholdRepository,
+	locationRepo repository.LocationRepository,
+) KioskService {
+	return &kioskService{
+		repo:          repo,
+		householdRepo: householdRepo,
+		locationRepo:  locationRepo,
+	}
+}
+
+// Register cadastra o quiosque e gera o código de pareamento de uso único,
+// que é devolvido apenas nesta resposta.
+func (s *kioskService) Register(ctx context.Context, device *models.KioskDevice) (*models.KioskRegistration, error) {
+	if strings.TrimSpace(device.Name) == "" {
+		return nil, invalid("kiosk name is required")
+	}
+	if device.LocationID == "" {
+		return nil, invalid("location_id is required")
+	}
+	if _, err := s.locationRepo.GetByID(ctx, device.LocationID); err != nil {
+		return nil, err
+	}
+
+	code, err := pairingCode()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(kioskPairingTTL)
+	if err := s.repo.Create(ctx, device, hashSecret(code), expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &models.KioskRegistration{
+		Device:           device,
+		PairingCode:      code,
+		PairingExpiresAt: expiresAt,
+	}, nil
+}
+
+// Pair troca o código de pareamento pela credencial do dispositivo. A
+// credencial é devolvida apenas nesta resposta; o banco guarda somente o hash.
+func (s *kioskService) Pair(ctx context.Context, code string) (*models.KioskCredential, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, invalid("pairing_code is required")
+	}
+
+	token, err := deviceToken()
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := s.repo.Pair(ctx, hashSecret(code), hashSecret(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidPairingCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.KioskCredential{Device: device, Token: token}, nil
+}
+
+func (s *kioskService) List(ctx context.Context) ([]*models.KioskDevice, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *kioskService) Revoke(ctx context.Context, id string) error {
+	return s.repo.Revoke(ctx, id)
+}
+
+// VerifyDeviceToken implementa auth.DeviceVerifier.
+func (s *kioskService) VerifyDeviceToken(ctx context.Context, token string) (*models.KioskDevice, error) {
+	return s.repo.GetByTokenHash(ctx, hashSecret(token))
+}
+
+// SearchFamilies busca famílias pelo telefone (últimos 4 dígitos ou número completo).
+func (s *kioskService) SearchFamilies(ctx context.Context, phone string) ([]*models.KioskFamily, error) {
+	digits := nonDigits.ReplaceAllString(phone, "")
+	if len(digits) < 4 {
+		return nil, invalid("phone must have at least 4 digits")
+	}
+
+	return s.householdRepo.FindByPhone(ctx, digits, maxKioskFamilies)
+}
+
+// pairingCode gera um código numérico fácil de digitar no tablet.
+func pairingCode() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(kioskPairingCodeDigits), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", kioskPairingCodeDigits, n), nil
+}
+
+func deviceToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return auth.DeviceTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
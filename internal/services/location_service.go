@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+type LocationService interface {
+	CreateLocation(ctx context.Context, location *models.Location) error
+	GetLocation(ctx context.Context, id string) (*models.Location, error)
+	UpdateLocation(ctx context.Context, location *models.Location) error
+	DeleteLocation(ctx context.Context, id string) error
+	ListLocations(ctx context.Context, page, pageSize int) ([]*models.Location, error)
+
+	CreateRoom(ctx context.Context, room *models.Room) error
+	GetRoom(ctx context.Context, id string) (*models.Room, error)
+	UpdateRoom(ctx context.Context, room *models.Room) error
+	DeleteRoom(ctx context.Context, id string) error
+	ListRooms(ctx context.Context, locationID string) ([]*models.Room, error)
+
+	AssignRooms(ctx context.Context, eventID, groupID string, roomIDs []string) ([]*models.RoomAssignment, error)
+	ListAssignments(ctx context.Context, eventID string) ([]*models.RoomAssignment, error)
+	SetRoomStaff(ctx context.Context, eventID, roomID string, volunteerIDs []string) (*models.RoomOccupancy, error)
+	RoomOccupancy(ctx context.Context, eventID, roomID string) (*models.RoomOccupancy, error)
+}
+
+type locationService struct {
+	repo           repository.LocationRepository
+	roomRepo       repository.RoomRepository
+	groupRepo      repository.GroupRepository
+	volunteerRepo  repository.VolunteerRepository
+	attendanceRepo repository.AttendanceRepository
+}
+
+func NewLocationService(
+	repo repository.LocationRepository,
+	roomRepo repository.RoomRepository,
+	groupRepo repository.GroupRepository,
+	volunteerRepo repository.VolunteerRepository,
+	attendanceRepo repository.AttendanceRepository,
+) LocationService {
+	return &locationService{
+		repo:           repo,
+		roomRepo:       roomRepo,
+		groupRepo:      groupRepo,
+		volunteerRepo:  volunteerRepo,
+		attendanceRepo: attendanceRepo,
+	}
+}
+
+func (s *locationService) CreateLocation(ctx context.Context, location *models.Location) error {
+	if err := validateLocation(location); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, location)
+}
+
+func (s *locationService) GetLocation(ctx context.Context, id string) (*models.Location, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *locationService) UpdateLocation(ctx context.Context, location *models.Location) error {
+	if err := validateLocation(location); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, location)
+}
+
+func (s *locationService) DeleteLocation(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *locationService) ListLocations(ctx context.Context, page, pageSize int) ([]*models.Location, error) {
+	offset := (page - 1) * pageSize
+	return s.repo.List(ctx, pageSize, offset)
+}
+
+func (s *locationService) CreateRoom(ctx context.Context, room *models.Room) error {
+	if err := validateRoom(room); err != nil {
+		return err
+	}
+	if _, err := s.repo.GetByID(ctx, room.LocationID); err != nil {
+		return err
+	}
+	return s.roomRepo.Create(ctx, room)
+}
+
+func (s *locationService) GetRoom(ctx context.Context, id string) (*models.Room, error) {
+	return s.roomRepo.GetByID(ctx, id)
+}
+
+func (s *locationService) UpdateRoom(ctx context.Context, room *models.Room) error {
+	if err := validateRoom(room); err != nil {
+		return err
+	}
+	return s.roomRepo.Update(ctx, room)
+}
+
+func (s *locationService) DeleteRoom(ctx context.Context, id string) error {
+	return s.roomRepo.Delete(ctx, id)
+}
+
+func (s *locationService) ListRooms(ctx context.Context, locationID string) ([]*models.Room, error) {
+	if _, err := s.repo.GetByID(ctx, locationID); err != nil {
+		return nil, err
+	}
+	return s.roomRepo.ListByLocation(ctx, locationID)
+}
+
+// AssignRooms define as salas do grupo no evento. A ordem de roomIDs é a
+// ordem em que as salas são preenchidas; lista vazia remove a atribuição.
+func (s *locationService) AssignRooms(ctx context.Context, eventID, groupID string, roomIDs []string) ([]*models.RoomAssignment, error) {
+	if eventID == "" {
+		return nil, invalid("event_id is required")
+	}
+
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, roomID := range roomIDs {
+		if seen[roomID] {
+			return nil, invalid("room " + roomID + " is listed twice")
+		}
+		seen[roomID] = true
+
+		if _, err := s.roomRepo.GetByID(ctx, roomID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.roomRepo.SetAssignments(ctx, eventID, groupID, roomIDs); err != nil {
+		return nil, err
+	}
+	return s.ListAssignments(ctx, eventID)
+}
+
+func (s *locationService) ListAssignments(ctx context.Context, eventID string) ([]*models.RoomAssignment, error) {
+	return s.roomRepo.ListAssignments(ctx, eventID)
+}
+
+// SetRoomStaff define os voluntários escalados na sala durante o evento, que
+// determinam o limite pela proporção crianças/voluntário.
+func (s *locationService) SetRoomStaff(ctx context.Context, eventID, roomID string, volunteerIDs []string) (*models.RoomOccupancy, error) {
+	if eventID == "" {
+		return nil, invalid("event_id is required")
+	}
+
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, volunteerID := range volunteerIDs {
+		if _, err := s.volunteerRepo.GetByID(ctx, volunteerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.roomRepo.SetStaff(ctx, eventID, roomID, volunteerIDs); err != nil {
+		return nil, err
+	}
+	return roomOccupancy(ctx, s.roomRepo, s.attendanceRepo, eventID, room)
+}
+
+func (s *locationService) RoomOccupancy(ctx context.Context, eventID, roomID string) (*models.RoomOccupancy, error) {
+	room, err := s.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return roomOccupancy(ctx, s.roomRepo, s.attendanceRepo, eventID, room)
+}
+
+// roomOccupancy calcula a ocupação da sala: crianças presentes de todos os
+// grupos e o limite pela capacidade e pelos voluntários escalados no evento.
+func roomOccupancy(
+	ctx context.Context,
+	roomRepo repository.RoomRepository,
+	attendanceRepo repository.AttendanceRepository,
+	eventID string,
+	room *models.Room,
+) (*models.RoomOccupancy, error) {
+	present, err := attendanceRepo.CountPresentInRoom(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	staff, err := roomRepo.ListStaff(ctx, eventID, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy := &models.RoomOccupancy{
+		Room:    room,
+		Present: present,
+		Staff:   len(staff),
+	}
+	if limit, ok := room.Limit(len(staff)); ok {
+		available := limit - present
+		if available < 0 {
+			available = 0
+		}
+		occupancy.Limit = &limit
+		occupancy.Available = &available
+	}
+	return occupancy, nil
+}
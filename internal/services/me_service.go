@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ErrNoCaretakerProfile is returned when the authenticated user is not linked
+// to any caretaker through caretakers.auth0_id.
+var ErrNoCaretakerProfile = errors.New("authenticated user has no caretaker profile")
+
+// MeService expõe ao responsável autenticado apenas os dados da própria família.
+// Todos os métodos recebem o claim "sub" do JWT, usado para localizar o responsável.
+type MeService interface {
+	GetProfile(ctx context.Context, sub string) (*models.Caretaker, error)
+	UpdateProfile(ctx context.Context, sub string, caretaker *models.Caretaker) error
+	GetHousehold(ctx context.Context, sub string) (*models.Household, error)
+	GetChild(ctx context.Context, sub, childID string) (*models.Child, error)
+	AddNeed(ctx context.Context, sub, childID string, need *models.Need) error
+	RemoveNeed(ctx context.Context, sub, childID, needID string) error
+	AddAllergy(ctx context.Context, sub, childID string, allergy *models.Allergy) error
+	RemoveAllergy(ctx context.Context, sub, childID, allergyID string) error
+	ListPickupGrants(ctx context.Context, sub string) ([]*models.PickupGrant, error)
+	CreatePickupGrant(ctx context.Context, sub string, grant *models.PickupGrant) error
+	RevokePickupGrant(ctx context.Context, sub, grantID string) error
+	ListAttendance(ctx context.Context, sub string, page, pageSize int) ([]*models.Attendance, error)
+}
+
+type meService struct {
+	caretakerRepo  repository.CaretakerRepository
+	householdRepo  repository.HouseholdRepository
+	childRepo      repository.ChildRepository
+	needRepo       repository.NeedRepository
+	allergyRepo    repository.AllergyRepository
+	attendanceRepo repository.AttendanceRepository
+	grantService   PickupGrantService
+}
+
+func NewMeService(
+	caretakerRepo repository.CaretakerRepository,
+	householdRepo repository.HouseholdRepository,
+	childRepo repository.ChildRepository,
+	needRepo repository.NeedRepository,
+	allergyRepo repository.AllergyRepository,
+	attendanceRepo repository.AttendanceRepository,
+	grantService PickupGrantService,
+) MeService {
+	return &meService{
+		caretakerRepo:  caretakerRepo,
+		householdRepo:  householdRepo,
+		childRepo:      childRepo,
+		needRepo:       needRepo,
+		allergyRepo:    allergyRepo,
+		attendanceRepo: attendanceRepo,
+		grantService:   grantService,
+	}
+}
+
+func (s *meService) GetProfile(ctx context.Context, sub string) (*models.Caretaker, error) {
+	if sub == "" {
+		return nil, ErrNoCaretakerProfile
+	}
+
+	caretaker, err := s.caretakerRepo.GetByAuth0ID(ctx, sub)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrNoCaretakerProfile
+	}
+	return caretaker, err
+}
+
+// UpdateProfile atualiza o cadastro do próprio responsável; o ID vem sempre do token.
+func (s *meService) UpdateProfile(ctx context.Context, sub string, caretaker *models.Caretaker) error {
+	current, err := s.GetProfile(ctx, sub)
+	if err != nil {
+		return err
+	}
+
+	caretaker.ID = current.ID
+	if err := validateCaretaker(caretaker); err != nil {
+		return err
+	}
+	return s.caretakerRepo.Update(ctx, caretaker)
+}
+
+func (s *meService) GetHousehold(ctx context.Context, sub string) (*models.Household, error) {
+	caretaker, err := s.GetProfile(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := s.householdRepo.GetByCaretaker(ctx, caretaker.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.householdRepo.LoadMembers(ctx, household); err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+func (s *meService) GetChild(ctx context.Context, sub, childID string) (*models.Child, error) {
+	if _, err := s.ownChild(ctx, sub, childID); err != nil {
+		return nil, err
+	}
+	return s.childRepo.GetByID(ctx, childID)
+}
+
+func (s *meService) AddNeed(ctx context.Context, sub, childID string, need *models.Need) error {
+	if _, err := s.ownChild(ctx, sub, childID); err != nil {
+		return err
+	}
+
+	if need.Type == "" {
+		return invalid("need type is required")
+	}
+
+	if err := s.needRepo.Create(ctx, need); err != nil {
+		return err
+	}
+	return s.childRepo.AssociateNeed(ctx, childID, need.ID)
+}
+
+func (s *meService) RemoveNeed(ctx context.Context, sub, childID, needID string) error {
+	if _, err := s.ownChild(ctx, sub, childID); err != nil {
+		return err
+	}
+	return s.childRepo.DissociateNeed(ctx, childID, needID)
+}
+
+func (s *meService) AddAllergy(ctx context.Context, sub, childID string, allergy *models.Allergy) error {
+	if _, err := s.ownChild(ctx, sub, childID); err != nil {
+		return err
+	}
+
+	if allergy.Type == "" {
+		return invalid("allergy type is required")
+	}
+
+	if err := s.allergyRepo.Create(ctx, allergy); err != nil {
+		return err
+	}
+	return s.childRepo.AssociateAllergy(ctx, childID, allergy.ID)
+}
+
+func (s *meService) RemoveAllergy(ctx context.Context, sub, childID, allergyID string) error {
+	if _, err := s.ownChild(ctx, sub, childID); err != nil {
+		return err
+	}
+	return s.childRepo.DissociateAllergy(ctx, childID, allergyID)
+}
+
+func (s *meService) ListPickupGrants(ctx context.Context, sub string) ([]*models.PickupGrant, error) {
+	household, err := s.GetHousehold(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []*models.PickupGrant
+	for _, child := range household.Children {
+		childGrants, err := s.grantService.ListGrants(ctx, child.ID)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, childGrants...)
+	}
+	return grants, nil
+}
+
+// CreatePickupGrant cria a autorização em nome do próprio responsável.
+func (s *meService) CreatePickupGrant(ctx context.Context, sub string, grant *models.PickupGrant) error {
+	caretaker, err := s.ownChild(ctx, sub, grant.ChildID)
+	if err != nil {
+		return err
+	}
+
+	grant.GrantedByCaretakerID = caretaker.ID
+	return s.grantService.CreateGrant(ctx, grant)
+}
+
+func (s *meService) RevokePickupGrant(ctx context.Context, sub, grantID string) error {
+	grant, err := s.grantService.GetGrant(ctx, grantID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.ownChild(ctx, sub, grant.ChildID); err != nil {
+		return err
+	}
+	return s.grantService.RevokeGrant(ctx, grantID)
+}
+
+func (s *meService) ListAttendance(ctx context.Context, sub string, page, pageSize int) ([]*models.Attendance, error) {
+	household, err := s.GetHousehold(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	childIDs := make([]string, 0, len(household.Children))
+	for _, child := range household.Children {
+		childIDs = append(childIDs, child.ID)
+	}
+
+	if len(childIDs) == 0 {
+		return []*models.Attendance{}, nil
+	}
+	return s.attendanceRepo.ListByChildren(ctx, childIDs, pageSize, (page-1)*pageSize)
+}
+
+// ownChild garante que a criança pertence à família do responsável autenticado.
+// Crianças de outras famílias são tratadas como inexistentes.
+func (s *meService) ownChild(ctx context.Context, sub, childID string) (*models.Caretaker, error) {
+	caretaker, err := s.GetProfile(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := s.householdRepo.GetByCaretaker(ctx, caretaker.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.householdRepo.LoadMembers(ctx, household); err != nil {
+		return nil, err
+	}
+
+	for _, child := range household.Children {
+		if child.ID == childID {
+			return caretaker, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+type MedicalProfileService interface {
+	GetProfile(ctx context.Context, childID string) (*models.MedicalProfile, error)
+	UpdateProfile(ctx context.Context, profile *models.MedicalProfile, updatedBy string) error
+	EmergencyRoster(ctx context.Context, groupID string) ([]*models.RosterEntry, error)
+}
+
+type medicalProfileService struct {
+	repo           repository.MedicalProfileRepository
+	childRepo      repository.ChildRepository
+	groupRepo      repository.GroupRepository
+	caretakerRepo  repository.CaretakerRepository
+	attendanceRepo repository.AttendanceRepository
+}
+
+func NewMedicalProfileService(
+	repo repository.MedicalProfileRepository,
+	childRepo repository.ChildRepository,
+	groupRepo repository.GroupRepository,
+	caretakerRepo repository.CaretakerRepository,
+	attendanceRepo repository.AttendanceRepository,
+) MedicalProfileService {
+	return &medicalProfileService{
+		repo:           repo,
+		childRepo:      childRepo,
+		groupRepo:      groupRepo,
+		caretakerRepo:  caretakerRepo,
+		attendanceRepo: attendanceRepo,
+	}
+}
+
+func (s *medicalProfileService) GetProfile(ctx context.Context, childID string) (*models.MedicalProfile, error) {
+	if _, err := s.childRepo.GetByID(ctx, childID); err != nil {
+		return nil, err
+	}
+	return s.repo.Get(ctx, childID)
+}
+
+// UpdateProfile substitui a ficha médica. A ordem dos contatos de emergência
+// na lista define a prioridade; responsáveis indicados como contato precisam
+// estar vinculados à criança.
+func (s *medicalProfileService) UpdateProfile(ctx context.Context, profile *models.MedicalProfile, updatedBy string) error {
+	if err := validateMedicalProfile(profile); err != nil {
+		return err
+	}
+
+	if _, err := s.childRepo.GetByID(ctx, profile.ChildID); err != nil {
+		return err
+	}
+
+	var linked []*models.Caretaker
+	for i := range profile.EmergencyContacts {
+		contact := &profile.EmergencyContacts[i]
+		contact.Position = i + 1
+
+		if contact.CaretakerID == "" {
+			continue
+		}
+		if linked == nil {
+			var err error
+			if linked, err = s.caretakerRepo.ListContacts(ctx, profile.ChildID); err != nil {
+				return err
+			}
+		}
+		if !containsCaretaker(linked, contact.CaretakerID) {
+			return invalid("caretaker " + contact.CaretakerID + " is not linked to this child")
+		}
+	}
+
+	if profile.Conditions == nil {
+		profile.Conditions = []string{}
+	}
+	if profile.DietaryRestrictions == nil {
+		profile.DietaryRestrictions = []string{}
+	}
+	profile.UpdatedBy = updatedBy
+
+	if err := s.repo.Save(ctx, profile); err != nil {
+		return err
+	}
+
+	// Recarrega para devolver os contatos completados com os dados dos responsáveis.
+	saved, err := s.repo.Get(ctx, profile.ChildID)
+	if err != nil {
+		return err
+	}
+	*profile = *saved
+	return nil
+}
+
+// EmergencyRoster lista as crianças presentes na sala com alergias, ficha
+// médica e contatos de emergência.
+func (s *medicalProfileService) EmergencyRoster(ctx context.Context, groupID string) ([]*models.RosterEntry, error) {
+	if _, err := s.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.attendanceRepo.ListPresent(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	roster := make([]*models.RosterEntry, 0, len(records))
+	for _, record := range records {
+		child, err := s.childRepo.GetByID(ctx, record.ChildID)
+		if err != nil {
+			return nil, err
+		}
+
+		profile, err := s.repo.Get(ctx, record.ChildID)
+		if err != nil {
+			return nil, err
+		}
+
+		roster = append(roster, &models.RosterEntry{
+			ChildID:      child.ID,
+			ChildName:    child.Name,
+			BirthDate:    child.BirthDate,
+			AttendanceID: record.ID,
+			SecurityCode: record.SecurityCode,
+			CheckedInAt:  record.CheckedInAt,
+			Allergies:    child.Allergies,
+			Medical:      profile,
+		})
+	}
+	return roster, nil
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ErrConsentExpired is returned when a dose is recorded against an order whose
+// parental consent has expired or that was discontinued.
+var ErrConsentExpired = errors.New("medication consent has expired or the order was discontinued")
+
+// ErrNotDoseVolunteer is returned when the authenticated user is not one of the
+// two volunteers named on the dose, or tries to verify a dose they recorded.
+var ErrNotDoseVolunteer = errors.New("authenticated user may not record or verify this dose")
+
+// Limite para registrar uma dose depois de administrada; registros mais
+// antigos precisam passar pela coordenação.
+const maxAdministrationDelay = 12 * time.Hour
+
+type MedicationService interface {
+	CreateOrder(ctx context.Context, order *models.MedicationOrder, createdBy string) error
+	GetOrder(ctx context.Context, id string) (*models.MedicationOrder, error)
+	ListOrders(ctx context.Context, childID string, includeDiscontinued bool) ([]*models.MedicationOrder, error)
+	DiscontinueOrder(ctx context.Context, id string) error
+	Administer(ctx context.Context, orderID string, administration *models.MedicationAdministration, recordedBy string) error
+	Verify(ctx context.Context, administrationID, verifiedBy string) (*models.MedicationAdministration, error)
+	ListAdministrations(ctx context.Context, orderID string) ([]*models.MedicationAdministration, error)
+	ChildLog(ctx context.Context, childID string, page, pageSize int) ([]*models.MedicationAdministration, error)
+}
+
+type medicationService struct {
+	repo          repository.MedicationRepository
+	childRepo     repository.ChildRepository
+	volunteerRepo repository.VolunteerRepository
+	caretakerRepo repository.CaretakerRepository
+}
+
+func NewMedicationService(
+	repo repository.MedicationRepository,
+	childRepo repository.ChildRepository,
+	volunteerRepo repository.VolunteerRepository,
+	caretakerRepo repository.CaretakerRepository,
+) MedicationService {
+	return &medicationService{
+		repo:          repo,
+		childRepo:     childRepo,
+		volunteerRepo: volunteerRepo,
+		caretakerRepo: caretakerRepo,
+	}
+}
+
+// CreateOrder cadastra a prescrição. Quando informado, o responsável que
+// assinou a autorização precisa estar vinculado à criança.
+func (s *medicationService) CreateOrder(ctx context.Context, order *models.MedicationOrder, createdBy string) error {
+	if err := validateMedicationOrder(order); err != nil {
+		return err
+	}
+
+	if _, err := s.childRepo.GetByID(ctx, order.ChildID); err != nil {
+		return err
+	}
+
+	if order.ConsentCaretakerID != "" {
+		contacts, err := s.caretakerRepo.ListContacts(ctx, order.ChildID)
+		if err != nil {
+			return err
+		}
+		if !containsCaretaker(contacts, order.ConsentCaretakerID) {
+			return invalid("consent caretaker is not linked to this child")
+		}
+	}
+
+	order.CreatedBy = createdBy
+	return s.repo.CreateOrder(ctx, order)
+}
+
+func (s *medicationService) GetOrder(ctx context.Context, id string) (*models.MedicationOrder, error) {
+	return s.repo.GetOrder(ctx, id)
+}
+
+func (s *medicationService) ListOrders(ctx context.Context, childID string, includeDiscontinued bool) ([]*models.MedicationOrder, error) {
+	return s.repo.ListOrdersByChild(ctx, childID, includeDiscontinued)
+}
+
+func (s *medicationService) DiscontinueOrder(ctx context.Context, id string) error {
+	return s.repo.DiscontinueOrder(ctx, id)
+}
+
+// Administer registra uma dose. Aplica a regra das duas pessoas: quem
+// administra e quem confere são voluntários diferentes, quem registra é um
+// deles e a dose só fica conferida quando o outro a confirma em Verify. Recusa
+// doses fora da validade da autorização dos pais, tanto no horário informado
+// quanto agora.
+func (s *medicationService) Administer(ctx context.Context, orderID string, administration *models.MedicationAdministration, recordedBy string) error {
+	order, err := s.repo.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if administration.AdministeredAt.IsZero() {
+		administration.AdministeredAt = now
+	}
+	if administration.AdministeredAt.After(now.Add(time.Minute)) {
+		return invalid("administered_at must not be in the future")
+	}
+	if administration.AdministeredAt.Before(now.Add(-maxAdministrationDelay)) {
+		return invalid("administered_at is too far in the past")
+	}
+
+	if !order.CanAdminister(administration.AdministeredAt) || !order.CanAdminister(now) {
+		return ErrConsentExpired
+	}
+
+	if administration.AdministeredByVolunteerID == "" || administration.VerifiedByVolunteerID == "" {
+		return invalid("administering and verifying volunteers are required")
+	}
+	if administration.AdministeredByVolunteerID == administration.VerifiedByVolunteerID {
+		return invalid("the dose must be verified by a second volunteer")
+	}
+	for _, volunteerID := range []string{administration.AdministeredByVolunteerID, administration.VerifiedByVolunteerID} {
+		if _, err := s.volunteerRepo.GetByID(ctx, volunteerID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return invalid("volunteer " + volunteerID + " not found")
+			}
+			return err
+		}
+	}
+
+	if _, err := s.doseVolunteer(ctx, administration, recordedBy); err != nil {
+		return err
+	}
+
+	if administration.DoseGiven == "" {
+		administration.DoseGiven = order.Dose
+	}
+
+	administration.OrderID = order.ID
+	administration.ChildID = order.ChildID
+	administration.RecordedBy = recordedBy
+	administration.VerifiedAt = nil
+	administration.VerifiedBy = ""
+	return s.repo.CreateAdministration(ctx, administration)
+}
+
+// Verify é a confirmação da dose pelo segundo voluntário: precisa ser um dos
+// dois nomeados no registro e não pode ser quem o registrou.
+func (s *medicationService) Verify(ctx context.Context, administrationID, verifiedBy string) (*models.MedicationAdministration, error) {
+	administration, err := s.repo.GetAdministration(ctx, administrationID)
+	if err != nil {
+		return nil, err
+	}
+	if administration.VerifiedAt != nil {
+		return nil, repository.ErrAlreadyVerified
+	}
+
+	if verifiedBy == administration.RecordedBy {
+		return nil, ErrNotDoseVolunteer
+	}
+	if _, err := s.doseVolunteer(ctx, administration, verifiedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.VerifyAdministration(ctx, administration.ID, verifiedBy); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAdministration(ctx, administration.ID)
+}
+
+// doseVolunteer retorna o voluntário do usuário autenticado, desde que seja um
+// dos dois nomeados na dose.
+func (s *medicationService) doseVolunteer(ctx context.Context, administration *models.MedicationAdministration, sub string) (*models.Volunteer, error) {
+	if sub == "" {
+		return nil, ErrNotDoseVolunteer
+	}
+
+	volunteer, err := s.volunteerRepo.GetByAuth0ID(ctx, sub)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrNotDoseVolunteer
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if volunteer.ID != administration.AdministeredByVolunteerID && volunteer.ID != administration.VerifiedByVolunteerID {
+		return nil, ErrNotDoseVolunteer
+	}
+	return volunteer, nil
+}
+
+func (s *medicationService) ListAdministrations(ctx context.Context, orderID string) ([]*models.MedicationAdministration, error) {
+	if _, err := s.repo.GetOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAdministrationsByOrder(ctx, orderID)
+}
+
+func (s *medicationService) ChildLog(ctx context.Context, childID string, page, pageSize int) ([]*models.MedicationAdministration, error) {
+	offset := (page - 1) * pageSize
+	return s.repo.ListAdministrationsByChild(ctx, childID, pageSize, offset)
+}
+
+func containsCaretaker(caretakers []*models.Caretaker, id string) bool {
+	for _, caretaker := range caretakers {
+		if caretaker.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+
+	"github.com/eduardohass/kids-api/internal/metrics"
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// MetricsService registers the domain gauges, read from every campus at
+// scrape time.
+type MetricsService interface {
+	Register(reg *metrics.Registry)
+}
+
+type metricsService struct {
+	tenantRepo       repository.TenantRepository
+	attendanceRepo   repository.AttendanceRepository
+	notificationRepo repository.NotificationRepository
+}
+
+// NewMetricsService creates a new MetricsService instance.
+func NewMetricsService(
+	tenantRepo repository.TenantRepository,
+	attendanceRepo repository.AttendanceRepository,
+	notificationRepo repository.NotificationRepository,
+) MetricsService {
+	return &metricsService{
+		tenantRepo:       tenantRepo,
+		attendanceRepo:   attendanceRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (s *metricsService) Register(reg *metrics.Registry) {
+	reg.GaugeFunc(
+		"kids_children_checked_in",
+		"Number of children currently checked in, by campus and group.",
+		[]string{"tenant", "group_id", "group"},
+		s.collectPresence,
+	)
+	reg.GaugeFunc(
+		"kids_notifications_pending",
+		"Number of notifications awaiting delivery, by campus and channel.",
+		[]string{"tenant", "channel"},
+		s.collectPendingNotifications,
+	)
+}
+
+func (s *metricsService) collectPresence(ctx context.Context) ([]metrics.Sample, error) {
+	var samples []metrics.Sample
+	err := s.eachTenant(ctx, func(ctx context.Context, tenantID string) error {
+		counts, err := s.attendanceRepo.CountPresentByGroup(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range counts {
+			samples = append(samples, metrics.Sample{
+				Labels: []string{tenantID, c.GroupID, c.GroupName},
+				Value:  float64(c.Count),
+			})
+		}
+		return nil
+	})
+	return samples, err
+}
+
+func (s *metricsService) collectPendingNotifications(ctx context.Context) ([]metrics.Sample, error) {
+	var samples []metrics.Sample
+	err := s.eachTenant(ctx, func(ctx context.Context, tenantID string) error {
+		counts, err := s.notificationRepo.CountPendingByChannel(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range counts {
+			samples = append(samples, metrics.Sample{
+				Labels: []string{tenantID, c.Channel},
+				Value:  float64(c.Count),
+			})
+		}
+		return nil
+	})
+	return samples, err
+}
+
+// eachTenant executa fn no escopo de cada campus; as tabelas têm RLS, então
+// não há consulta que some todos de uma vez.
+func (s *metricsService) eachTenant(ctx context.Context, fn func(ctx context.Context, tenantID string) error) error {
+	tenants, err := s.tenantRepo.ListIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, tenantID := range tenants {
+		if err := fn(tenant.WithID(ctx, tenantID), tenantID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
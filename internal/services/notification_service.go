@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/jobs"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/notifications"
+	"github.com/eduardohass/kids-api/internal/pii"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ErrNoContact is returned when none of the child's caretakers can be reached
+// through a configured channel.
+var ErrNoContact = errors.New("no reachable caretaker for this child")
+
+// JobDeliverNotification é o tipo do trabalho que entrega uma notificação.
+const JobDeliverNotification = "notification.deliver"
+
+// DeliverNotificationPayload identifica a notificação a ser entregue.
+type DeliverNotificationPayload struct {
+	NotificationID string `json:"notification_id"`
+}
+
+type NotificationService interface {
+	PageParent(ctx context.Context, attendanceID string, req models.PageParentRequest, sentBy string) (*models.Notification, error)
+	GetNotification(ctx context.Context, id string) (*models.Notification, error)
+	ListByAttendance(ctx context.Context, attendanceID string) ([]*models.Notification, error)
+	Retry(ctx context.Context, id string) (*models.Notification, error)
+	Deliver(ctx context.Context, job *models.Job, payload DeliverNotificationPayload) error
+}
+
+type notificationService struct {
+	repo           repository.NotificationRepository
+	attendanceRepo repository.AttendanceRepository
+	childRepo      repository.ChildRepository
+	groupRepo      repository.GroupRepository
+	caretakerRepo  repository.CaretakerRepository
+	notifiers      *notifications.Registry
+	queue          *jobs.Queue
+	events         EventPublisher
+}
+
+func NewNotificationService(
+	repo repository.NotificationRepository,
+	attendanceRepo repository.AttendanceRepository,
+	childRepo repository.ChildRepository,
+	groupRepo repository.GroupRepository,
+	caretakerRepo repository.CaretakerRepository,
+	notifiers *notifications.Registry,
+	queue *jobs.Queue,
+	events EventPublisher,
+) NotificationService {
+	return &notificationService{
+		repo:           repo,
+		attendanceRepo: attendanceRepo,
+		childRepo:      childRepo,
+		groupRepo:      groupRepo,
+		caretakerRepo:  caretakerRepo,
+		notifiers:      notifiers,
+		queue:          queue,
+		events:         events,
+	}
+}
+
+// PageParent chama o responsável da criança presente. O contato é escolhido
+// pela ordem de preferência de children_caretakers; o SMS tem prioridade sobre
+// o e-mail por ser lido mais rapidamente durante o culto.
+func (s *notificationService) PageParent(ctx context.Context, attendanceID string, req models.PageParentRequest, sentBy string) (*models.Notification, error) {
+	if req.Reason == "" {
+		req.Reason = notifications.ReasonOther
+	}
+	if !notifications.KnownReason(req.Reason) {
+		return nil, invalid("unknown reason " + req.Reason)
+	}
+
+	record, err := s.attendanceRepo.GetByID(ctx, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if record.CheckedOutAt != nil {
+		return nil, repository.ErrAlreadyCheckedOut
+	}
+
+	child, err := s.childRepo.GetByID(ctx, record.ChildID)
+	if err != nil {
+		return nil, err
+	}
+
+	caretakers, err := s.caretakerRepo.ListContacts(ctx, child.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	caretaker, channel, recipient := s.pickContact(caretakers)
+	if caretaker == nil {
+		return nil, ErrNoContact
+	}
+
+	data := notifications.PageData{
+		CaretakerName: caretaker.Name,
+		ChildName:     child.Name,
+		SecurityCode:  record.SecurityCode,
+		Note:          req.Note,
+	}
+	if record.GroupID != "" {
+		if group, err := s.groupRepo.GetByID(ctx, record.GroupID); err == nil {
+			data.GroupName = group.Name
+		}
+	}
+
+	subject, body, err := notifications.RenderPage(req.Reason, data)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &models.Notification{
+		AttendanceID: record.ID,
+		ChildID:      child.ID,
+		CaretakerID:  caretaker.ID,
+		Channel:      channel,
+		Recipient:    recipient,
+		Reason:       req.Reason,
+		Subject:      subject,
+		Body:         pii.String(body),
+		Status:       models.NotificationPending,
+		SentBy:       sentBy,
+	}
+	if err := s.repo.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueueDelivery(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, record.GroupID, models.GroupEventParentPaged, map[string]string{
+		"attendance_id":   record.ID,
+		"child_id":        child.ID,
+		"notification_id": notification.ID,
+		"reason":          req.Reason,
+	})
+	return notification, nil
+}
+
+func (s *notificationService) GetNotification(ctx context.Context, id string) (*models.Notification, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *notificationService) ListByAttendance(ctx context.Context, attendanceID string) ([]*models.Notification, error) {
+	return s.repo.ListByAttendance(ctx, attendanceID)
+}
+
+// Retry agenda um novo ciclo de entrega para uma notificação que falhou.
+func (s *notificationService) Retry(ctx context.Context, id string) (*models.Notification, error) {
+	notification, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if notification.Status == models.NotificationSent {
+		return notification, nil
+	}
+
+	notification.Status = models.NotificationPending
+	if err := s.repo.UpdateStatus(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueueDelivery(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+func (s *notificationService) enqueueDelivery(ctx context.Context, notification *models.Notification) error {
+	_, err := s.queue.Enqueue(ctx, JobDeliverNotification, DeliverNotificationPayload{NotificationID: notification.ID})
+	return err
+}
+
+// pickContact escolhe o primeiro responsável alcançável, preferindo SMS.
+func (s *notificationService) pickContact(caretakers []*models.Caretaker) (*models.Caretaker, string, pii.String) {
+	for _, channel := range []string{notifications.ChannelSMS, notifications.ChannelEmail} {
+		if !s.notifiers.Has(channel) {
+			continue
+		}
+		for _, caretaker := range caretakers {
+			if channel == notifications.ChannelSMS && caretaker.Phone != "" {
+				return caretaker, channel, caretaker.Phone
+			}
+			if channel == notifications.ChannelEmail && caretaker.Email != "" {
+				return caretaker, channel, caretaker.Email
+			}
+		}
+	}
+	return nil, "", ""
+}
+
+// Deliver é o handler do trabalho JobDeliverNotification: faz uma tentativa de
+// entrega e registra o resultado. Em caso de falha o erro é devolvido para que
+// a fila tente de novo com backoff; a notificação só é marcada como falha na
+// última tentativa.
+func (s *notificationService) Deliver(ctx context.Context, job *models.Job, payload DeliverNotificationPayload) error {
+	notification, err := s.repo.GetByID(ctx, payload.NotificationID)
+	if err != nil {
+		return err
+	}
+	if notification.Status == models.NotificationSent {
+		return nil
+	}
+
+	msg := notifications.Message{
+		To:      string(notification.Recipient),
+		Subject: notification.Subject,
+		Body:    string(notification.Body),
+	}
+
+	notification.Attempts++
+	sendErr := s.notifiers.Send(ctx, notification.Channel, msg)
+	if sendErr == nil {
+		now := time.Now()
+		notification.Status = models.NotificationSent
+		notification.LastError = ""
+		notification.SentAt = &now
+		return s.repo.UpdateStatus(ctx, notification)
+	}
+
+	notification.LastError = sendErr.Error()
+	if job.IsLastAttempt() {
+		notification.Status = models.NotificationFailed
+	}
+	if err := s.repo.UpdateStatus(ctx, notification); err != nil {
+		return err
+	}
+	return sendErr
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Número máximo de redirecionamentos seguidos ao baixar uma foto.
+const maxPhotoRedirects = 3
+
+var (
+	errPhotoDownloadDisabled = errors.New("photo download is not configured")
+	errPhotoURLNotAllowed    = errors.New("photo url is not allowed")
+	errPhotoAddressBlocked   = errors.New("photo host resolves to a non-public address")
+)
+
+// Faixas que não são privadas pelo netip, mas também não devem ser acessadas:
+// rede "this host", CGNAT, IETF, testes de desempenho, reservada e NAT64 (que
+// pode levar a endereços IPv4 internos).
+var blockedPhotoPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// photoClient baixa as fotos das crianças para a exportação do titular. O
+// endereço da foto vem do cadastro, então só são aceitos HTTPS e os hosts
+// configurados; a conexão é recusada se o host resolver para um endereço que
+// não seja público, o que também cobre redirecionamentos e DNS apontado para
+// a rede interna.
+type photoClient struct {
+	hosts  map[string]bool
+	client *http.Client
+}
+
+func newPhotoClient(hosts []string) *photoClient {
+	c := &photoClient{hosts: make(map[string]bool, len(hosts))}
+	for _, host := range hosts {
+		c.hosts[strings.ToLower(host)] = true
+	}
+
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		// Control roda com o endereço já resolvido, logo antes de conectar
+		Control: func(_, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		},
+	}
+	c.client = &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			// Sem proxy: a verificação do endereço vale para a conexão direta
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxPhotoRedirects {
+				return fmt.Errorf("photo download stopped after %d redirects", len(via))
+			}
+			return c.checkURL(req.URL)
+		},
+	}
+	return c
+}
+
+// Get baixa a foto; quem chama fecha o corpo da resposta.
+func (c *photoClient) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	if len(c.hosts) == 0 {
+		return nil, errPhotoDownloadDisabled
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errPhotoURLNotAllowed
+	}
+	if err := c.checkURL(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req)
+}
+
+// checkURL aceita apenas HTTPS, na porta padrão, para um dos hosts configurados.
+func (c *photoClient) checkURL(u *url.URL) error {
+	if u.Scheme != "https" || u.User != nil || (u.Port() != "" && u.Port() != "443") {
+		return errPhotoURLNotAllowed
+	}
+	if !c.hosts[strings.ToLower(u.Hostname())] {
+		return errPhotoURLNotAllowed
+	}
+	return nil
+}
+
+// checkPublicAddress recusa loopback, redes privadas, link-local (incluindo o
+// serviço de metadados da nuvem), multicast e as faixas reservadas.
+func checkPublicAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return errPhotoAddressBlocked
+	}
+	for _, prefix := range blockedPhotoPrefixes {
+		if prefix.Contains(ip) {
+			return errPhotoAddressBlocked
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+type PickupGrantService interface {
+	CreateGrant(ctx context.Context, grant *models.PickupGrant) error
+	GetGrant(ctx context.Context, id string) (*models.PickupGrant, error)
+	ListGrants(ctx context.Context, childID string) ([]*models.PickupGrant, error)
+	RevokeGrant(ctx context.Context, id string) error
+}
+
+type pickupGrantService struct {
+	repo          repository.PickupGrantRepository
+	caretakerRepo repository.CaretakerRepository
+}
+
+func NewPickupGrantService(
+	repo repository.PickupGrantRepository,
+	caretakerRepo repository.CaretakerRepository,
+) PickupGrantService {
+	return &pickupGrantService{
+		repo:          repo,
+		caretakerRepo: caretakerRepo,
+	}
+}
+
+// CreateGrant registra uma autorização temporária de retirada. Só quem pode
+// retirar a criança pode autorizar outra pessoa. GrantedByCaretakerID vem do
+// responsável autenticado (/me) ou da equipe de coordenação, que registra a
+// autorização em nome dele; as rotas garantem uma das duas origens.
+func (s *pickupGrantService) CreateGrant(ctx context.Context, grant *models.PickupGrant) error {
+	if err := validatePickupGrant(grant); err != nil {
+		return err
+	}
+
+	allowed, err := s.caretakerRepo.CanPickup(ctx, grant.ChildID, grant.GrantedByCaretakerID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrPickupNotAuthorized
+	}
+
+	return s.repo.Create(ctx, grant)
+}
+
+func (s *pickupGrantService) GetGrant(ctx context.Context, id string) (*models.PickupGrant, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *pickupGrantService) ListGrants(ctx context.Context, childID string) ([]*models.PickupGrant, error) {
+	return s.repo.ListByChild(ctx, childID)
+}
+
+func (s *pickupGrantService) RevokeGrant(ctx context.Context, id string) error {
+	return s.repo.Revoke(ctx, id)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/precheckin"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+type PreCheckInService interface {
+	PreCheckIn(ctx context.Context, sub string, req models.CheckInRequest) (*models.PreCheckIn, error)
+	Redeem(ctx context.Context, token, locationID, checkedInBy string) ([]*models.Attendance, error)
+}
+
+type preCheckInService struct {
+	signer            *precheckin.Signer
+	repo              repository.PreCheckInRepository
+	meService         MeService
+	attendanceService AttendanceService
+}
+
+func NewPreCheckInService(
+	signer *precheckin.Signer,
+	repo repository.PreCheckInRepository,
+	meService MeService,
+	attendanceService AttendanceService,
+) PreCheckInService {
+	return &preCheckInService{
+		signer:            signer,
+		repo:              repo,
+		meService:         meService,
+		attendanceService: attendanceService,
+	}
+}
+
+// PreCheckIn gera o QR code assinado para as crianças da família do
+// responsável autenticado. Sem child_ids, todas as crianças são incluídas.
+func (s *preCheckInService) PreCheckIn(ctx context.Context, sub string, req models.CheckInRequest) (*models.PreCheckIn, error) {
+	if req.EventID == "" {
+		return nil, invalid("event_id is required")
+	}
+
+	household, err := s.meService.GetHousehold(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	childIDs, err := householdChildIDs(household, req.ChildIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(childIDs) == 0 {
+		return nil, invalid("household has no children to check in")
+	}
+
+	caretaker, err := s.meService.GetProfile(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &precheckin.Payload{
+		CaretakerID: caretaker.ID,
+		ChildIDs:    childIDs,
+		EventID:     req.EventID,
+	}
+	token, err := s.signer.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PreCheckIn{
+		Token:     token,
+		ChildIDs:  payload.ChildIDs,
+		EventID:   payload.EventID,
+		ExpiresAt: payload.ExpiresAt,
+	}, nil
+}
+
+// Redeem valida o QR lido no quiosque e conclui o check-in, emitindo os
+// códigos de segurança. O nonce é consumido antes do check-in, de modo que o
+// mesmo QR nunca é aceito duas vezes ao mesmo tempo; se o check-in falhar, o
+// nonce é liberado e o responsável pode tentar de novo com o mesmo QR.
+func (s *preCheckInService) Redeem(ctx context.Context, token, locationID, checkedInBy string) ([]*models.Attendance, error) {
+	payload, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Redeem(ctx, payload.Nonce, payload.CaretakerID, payload.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	req := models.CheckInRequest{
+		ChildIDs:   payload.ChildIDs,
+		EventID:    payload.EventID,
+		LocationID: locationID,
+	}
+	records, err := s.attendanceService.CheckIn(ctx, req, checkedInBy)
+	if err != nil {
+		if releaseErr := s.repo.Release(ctx, payload.Nonce); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, err
+	}
+
+	// Limpeza oportunista dos nonces expirados; uma falha aqui não desfaz o check-in
+	if _, err := s.repo.PurgeExpired(ctx, time.Now()); err != nil {
+		log.Printf("Error purging expired pre-check-in codes: %v", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+type RestrictionService interface {
+	CreateRestriction(ctx context.Context, restriction *models.ChildRestriction) error
+	ListRestrictions(ctx context.Context, childID string) ([]*models.ChildRestriction, error)
+	RevokeRestriction(ctx context.Context, id, actor string) error
+	HasActiveRestriction(ctx context.Context, childID string) (bool, error)
+}
+
+type restrictionService struct {
+	repo      repository.RestrictionRepository
+	childRepo repository.ChildRepository
+	auditRepo repository.AuditRepository
+}
+
+func NewRestrictionService(
+	repo repository.RestrictionRepository,
+	childRepo repository.ChildRepository,
+	auditRepo repository.AuditRepository,
+) RestrictionService {
+	return &restrictionService{
+		repo:      repo,
+		childRepo: childRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *restrictionService) CreateRestriction(ctx context.Context, restriction *models.ChildRestriction) error {
+	if restriction.RestrictedCaretakerID == "" && restriction.RestrictedName == "" {
+		return invalid("restricted caretaker or name is required")
+	}
+
+	if _, err := s.childRepo.GetByID(ctx, restriction.ChildID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(ctx, restriction); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, s.auditRepo, restriction.CreatedBy, AuditRestrictionCreated,
+		AuditEntityRestriction, restriction.ID, map[string]string{"child_id": restriction.ChildID})
+}
+
+func (s *restrictionService) ListRestrictions(ctx context.Context, childID string) ([]*models.ChildRestriction, error) {
+	return s.repo.ListByChild(ctx, childID)
+}
+
+func (s *restrictionService) RevokeRestriction(ctx context.Context, id, actor string) error {
+	restriction, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, s.auditRepo, actor, AuditRestrictionRevoked,
+		AuditEntityRestriction, id, map[string]string{"child_id": restriction.ChildID})
+}
+
+func (s *restrictionService) HasActiveRestriction(ctx context.Context, childID string) (bool, error) {
+	return s.repo.HasActive(ctx, childID)
+}
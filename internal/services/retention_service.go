@@ -0,0 +1,394 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/jobs"
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+	"github.com/eduardohass/kids-api/internal/tenant"
+)
+
+// Tipos dos trabalhos de retenção.
+const (
+	// JobRunRetention executa uma execução solicitada (RunRetentionPayload).
+	JobRunRetention = "retention.run"
+	// JobScheduleRetention abre a execução periódica do campus e agenda a próxima.
+	JobScheduleRetention = "retention.schedule"
+)
+
+// RetentionScheduler é o solicitante das execuções periódicas.
+const RetentionScheduler = "scheduler"
+
+const (
+	// Entidades lidas e tratadas por vez em cada regra.
+	retentionBatchSize = 500
+	// Prazo mínimo aceito nas regras, para evitar perda de dados por engano.
+	minRetentionDays = 30
+)
+
+// retentionPhotoNote acompanha o resultado da regra de fotos: só a referência
+// é removida do cadastro.
+const retentionPhotoNote = "photo files are not deleted: only photo_url is cleared, the files must be removed from the photo hosting service"
+
+// RunRetentionPayload identifica a execução a ser processada.
+type RunRetentionPayload struct {
+	RunID string `json:"run_id"`
+}
+
+// ScheduleRetentionPayload é o payload (vazio) do trabalho periódico.
+type ScheduleRetentionPayload struct{}
+
+type RetentionService interface {
+	ListPolicies(ctx context.Context) ([]*models.RetentionPolicy, error)
+	UpdatePolicy(ctx context.Context, policy *models.RetentionPolicy, updatedBy string) error
+	RequestRun(ctx context.Context, dryRun bool, requestedBy string) (*models.RetentionRun, error)
+	GetRun(ctx context.Context, id string) (*models.RetentionRun, error)
+	ListRuns(ctx context.Context, page, pageSize int) ([]*models.RetentionRun, error)
+	ProcessRun(ctx context.Context, job *models.Job, payload RunRetentionPayload) error
+	RunScheduled(ctx context.Context, job *models.Job, payload ScheduleRetentionPayload) error
+	Schedule(ctx context.Context) error
+}
+
+type retentionService struct {
+	repo            repository.RetentionRepository
+	childRepo       repository.ChildRepository
+	dataSubjectRepo repository.DataSubjectRepository
+	incidentRepo    repository.IncidentRepository
+	accessLogRepo   repository.AccessLogRepository
+	groupEventRepo  repository.GroupEventRepository
+	tenantRepo      repository.TenantRepository
+	auditRepo       repository.AuditRepository
+	queue           *jobs.Queue
+	interval        time.Duration
+}
+
+// NewRetentionService cria o serviço; interval é o intervalo entre as
+// execuções periódicas de cada campus.
+func NewRetentionService(
+	repo repository.RetentionRepository,
+	childRepo repository.ChildRepository,
+	dataSubjectRepo repository.DataSubjectRepository,
+	incidentRepo repository.IncidentRepository,
+	accessLogRepo repository.AccessLogRepository,
+	groupEventRepo repository.GroupEventRepository,
+	tenantRepo repository.TenantRepository,
+	auditRepo repository.AuditRepository,
+	queue *jobs.Queue,
+	interval time.Duration,
+) RetentionService {
+	return &retentionService{
+		repo:            repo,
+		childRepo:       childRepo,
+		dataSubjectRepo: dataSubjectRepo,
+		incidentRepo:    incidentRepo,
+		accessLogRepo:   accessLogRepo,
+		groupEventRepo:  groupEventRepo,
+		tenantRepo:      tenantRepo,
+		auditRepo:       auditRepo,
+		queue:           queue,
+		interval:        interval,
+	}
+}
+
+// ListPolicies retorna todas as regras suportadas; as ainda não configuradas
+// aparecem desativadas, com o prazo sugerido.
+func (s *retentionService) ListPolicies(ctx context.Context) ([]*models.RetentionPolicy, error) {
+	saved, err := s.repo.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byRule := make(map[string]*models.RetentionPolicy, len(saved))
+	for _, policy := range saved {
+		byRule[policy.Rule] = policy
+	}
+
+	policies := make([]*models.RetentionPolicy, 0, len(models.RetentionRuleDefaults))
+	for _, defaults := range models.RetentionRuleDefaults {
+		if policy, ok := byRule[defaults.Rule]; ok {
+			policies = append(policies, policy)
+			continue
+		}
+		policy := defaults
+		policies = append(policies, &policy)
+	}
+	return policies, nil
+}
+
+func (s *retentionService) UpdatePolicy(ctx context.Context, policy *models.RetentionPolicy, updatedBy string) error {
+	if err := validateRetentionPolicy(policy); err != nil {
+		return err
+	}
+
+	policy.UpdatedBy = updatedBy
+	if err := s.repo.SavePolicy(ctx, policy); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{
+		"rule":       policy.Rule,
+		"after_days": policy.AfterDays,
+		"enabled":    policy.Enabled,
+	}
+	return recordAudit(ctx, s.auditRepo, updatedBy, AuditRetentionPolicyUpdated, AuditEntityRetentionPolicy, policy.ID, details)
+}
+
+// RequestRun registra a execução e a envia para a fila. Com dryRun, a
+// execução apenas relata o que seria afetado.
+func (s *retentionService) RequestRun(ctx context.Context, dryRun bool, requestedBy string) (*models.RetentionRun, error) {
+	run := &models.RetentionRun{
+		DryRun:      dryRun,
+		RequestedBy: requestedBy,
+	}
+	if err := s.repo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queue.Enqueue(ctx, JobRunRetention, RunRetentionPayload{RunID: run.ID}); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *retentionService) GetRun(ctx context.Context, id string) (*models.RetentionRun, error) {
+	return s.repo.GetRun(ctx, id)
+}
+
+func (s *retentionService) ListRuns(ctx context.Context, page, pageSize int) ([]*models.RetentionRun, error) {
+	offset := (page - 1) * pageSize
+	return s.repo.ListRuns(ctx, pageSize, offset)
+}
+
+// ProcessRun é o handler do trabalho JobRunRetention. A execução só é marcada
+// como falha na última tentativa.
+func (s *retentionService) ProcessRun(ctx context.Context, job *models.Job, payload RunRetentionPayload) error {
+	run, err := s.repo.GetRun(ctx, payload.RunID)
+	if err != nil {
+		return err
+	}
+	if run.Status == models.RetentionRunCompleted {
+		return nil
+	}
+
+	if err := s.repo.StartRun(ctx, run.ID); err != nil {
+		return err
+	}
+
+	result, err := s.apply(ctx, run)
+	if err != nil {
+		if job.IsLastAttempt() {
+			if failErr := s.repo.FailRun(ctx, run.ID, err.Error()); failErr != nil {
+				return failErr
+			}
+		}
+		return err
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return s.repo.CompleteRun(ctx, run.ID, raw)
+}
+
+// RunScheduled é o handler do trabalho JobScheduleRetention: agenda a próxima
+// execução periódica, remove os eventos do painel que saíram da janela de
+// replay e, se o campus tiver alguma regra ativa, solicita uma execução.
+func (s *retentionService) RunScheduled(ctx context.Context, job *models.Job, payload ScheduleRetentionPayload) error {
+	if err := s.scheduleNext(ctx, jobs.RunIn(s.interval)); err != nil {
+		return err
+	}
+
+	// Os eventos do painel não dependem das regras do campus: só servem ao
+	// replay e são removidos assim que saem da janela
+	pruned, err := s.groupEventRepo.DeleteBefore(ctx, time.Now().Add(-groupEventReplayWindow))
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		log.Printf("Pruned %d group events older than the replay window", pruned)
+	}
+
+	policies, err := s.ListPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if policy.Enabled {
+			_, err := s.RequestRun(ctx, false, RetentionScheduler)
+			return err
+		}
+	}
+	return nil
+}
+
+// Schedule garante que cada campus tenha a execução periódica na fila. É
+// chamado na inicialização; a primeira execução acontece em seguida.
+func (s *retentionService) Schedule(ctx context.Context) error {
+	tenants, err := s.tenantRepo.ListIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, tenantID := range tenants {
+		if err := s.scheduleNext(tenant.WithID(ctx, tenantID), jobs.RunIn(0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleNext enfileira o trabalho periódico, a menos que já haja um
+// aguardando (vários processos podem agendar ao mesmo tempo).
+func (s *retentionService) scheduleNext(ctx context.Context, at jobs.Option) error {
+	queued, err := s.queue.HasQueued(ctx, JobScheduleRetention)
+	if err != nil || queued {
+		return err
+	}
+
+	_, err = s.queue.Enqueue(ctx, JobScheduleRetention, ScheduleRetentionPayload{}, at)
+	return err
+}
+
+// apply executa as regras ativas. Fora do dry run, cada entidade afetada é
+// registrada na auditoria em nome de quem solicitou a execução.
+func (s *retentionService) apply(ctx context.Context, run *models.RetentionRun) (*models.RetentionResult, error) {
+	policies, err := s.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.RetentionResult{Rules: []models.RetentionRuleResult{}}
+	now := time.Now()
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+
+		ruleResult := models.RetentionRuleResult{
+			Rule:      policy.Rule,
+			AfterDays: policy.AfterDays,
+			Cutoff:    now.AddDate(0, 0, -policy.AfterDays),
+		}
+		if err := s.applyRule(ctx, run, &ruleResult); err != nil {
+			return nil, err
+		}
+		result.Rules = append(result.Rules, ruleResult)
+
+		if !run.DryRun && ruleResult.Affected > 0 {
+			log.Printf("Retention rule %s affected %d records", ruleResult.Rule, ruleResult.Affected)
+		}
+	}
+	return result, nil
+}
+
+func (s *retentionService) applyRule(ctx context.Context, run *models.RetentionRun, result *models.RetentionRuleResult) error {
+	var err error
+	switch result.Rule {
+	case models.RetentionInactiveChildAnonymize:
+		list := func(ctx context.Context, limit, offset int) ([]string, error) {
+			return s.childRepo.ListInactive(ctx, result.Cutoff, false, limit, offset)
+		}
+		// A anonimização altera todas as crianças informadas
+		anonymize := func(ctx context.Context, ids []string) ([]string, error) {
+			if err := s.dataSubjectRepo.AnonymizeChildren(ctx, ids); err != nil {
+				return nil, err
+			}
+			return ids, nil
+		}
+		return s.applyBatches(ctx, run, result, list, anonymize, AuditRetentionChildAnonymized, AuditEntityChild)
+
+	case models.RetentionInactiveChildPhoto:
+		result.Note = retentionPhotoNote
+		list := func(ctx context.Context, limit, offset int) ([]string, error) {
+			return s.childRepo.ListInactive(ctx, result.Cutoff, true, limit, offset)
+		}
+		return s.applyBatches(ctx, run, result, list, s.childRepo.ClearPhotos, AuditRetentionPhotoRemoved, AuditEntityChild)
+
+	case models.RetentionIncidentDraftPurge:
+		list := func(ctx context.Context, limit, offset int) ([]string, error) {
+			return s.incidentRepo.ListStaleDrafts(ctx, result.Cutoff, limit, offset)
+		}
+		purge := func(ctx context.Context, ids []string) ([]string, error) {
+			return s.incidentRepo.DeleteDrafts(ctx, ids, result.Cutoff)
+		}
+		return s.applyBatches(ctx, run, result, list, purge, AuditRetentionDraftPurged, AuditEntityIncident)
+
+	case models.RetentionAccessLogPurge:
+		// Registros sem identificação individual: apenas a contagem é relatada
+		if run.DryRun {
+			result.Affected, err = s.accessLogRepo.CountBefore(ctx, result.Cutoff)
+			return err
+		}
+		if result.Affected, err = s.accessLogRepo.DeleteBefore(ctx, result.Cutoff); err != nil {
+			return err
+		}
+		details := map[string]interface{}{"run_id": run.ID, "cutoff": result.Cutoff, "deleted": result.Affected}
+		return recordAudit(ctx, s.auditRepo, run.RequestedBy, AuditRetentionAccessLogPurged, AuditEntityRetentionRun, run.ID, details)
+
+	default:
+		return errors.New("unknown retention rule " + result.Rule)
+	}
+}
+
+// applyBatches percorre, em lotes, todas as entidades alcançadas pela regra.
+// No dry run os lotes são apenas paginados; fora dele, cada lote é aplicado e
+// as entidades que apply de fato alterou são registradas na auditoria antes
+// de buscar o próximo lote, que deixa de trazê-las. As que o lote não alterou
+// continuam na listagem e são puladas.
+func (s *retentionService) applyBatches(
+	ctx context.Context,
+	run *models.RetentionRun,
+	result *models.RetentionRuleResult,
+	list func(ctx context.Context, limit, offset int) ([]string, error),
+	apply func(ctx context.Context, ids []string) ([]string, error),
+	action, entityType string,
+) error {
+	for offset := 0; ; {
+		ids, err := list(ctx, retentionBatchSize, offset)
+		if err != nil {
+			return err
+		}
+
+		if run.DryRun {
+			result.EntityIDs = append(result.EntityIDs, ids...)
+			result.Affected += len(ids)
+			offset += len(ids)
+		} else if len(ids) > 0 {
+			changed, err := apply(ctx, ids)
+			if err != nil {
+				return err
+			}
+			if err := s.auditEach(ctx, run, result, changed, action, entityType); err != nil {
+				return err
+			}
+			result.EntityIDs = append(result.EntityIDs, changed...)
+			result.Affected += len(changed)
+			offset += len(ids) - len(changed)
+		}
+
+		if len(ids) < retentionBatchSize {
+			return nil
+		}
+	}
+}
+
+// auditEach registra na auditoria cada entidade alterada pela regra.
+func (s *retentionService) auditEach(ctx context.Context, run *models.RetentionRun, result *models.RetentionRuleResult, ids []string, action, entityType string) error {
+	details := map[string]interface{}{
+		"run_id":     run.ID,
+		"rule":       result.Rule,
+		"after_days": result.AfterDays,
+	}
+	for _, id := range ids {
+		if err := recordAudit(ctx, s.auditRepo, run.RequestedBy, action, entityType, id, details); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/eduardohass/kids-api/internal/models"
+)
+
+type countingAuditRepo struct {
+	entries  int
+	entities map[string]bool
+}
+
+func (r *countingAuditRepo) Create(ctx context.Context, entry *models.AuditEntry) error {
+	r.entries++
+	if r.entities == nil {
+		r.entities = map[string]bool{}
+	}
+	r.entities[entry.EntityID] = true
+	return nil
+}
+
+// fakeRetentionTable guarda as entidades pendentes em ordem, como a listagem
+// do banco; skip marca as que apply não consegue tratar.
+type fakeRetentionTable struct {
+	pending []string
+	skip    map[string]bool
+}
+
+func newFakeRetentionTable(n int) *fakeRetentionTable {
+	t := &fakeRetentionTable{skip: map[string]bool{}}
+	for i := 0; i < n; i++ {
+		t.pending = append(t.pending, fmt.Sprintf("id-%04d", i))
+	}
+	return t
+}
+
+func (t *fakeRetentionTable) list(ctx context.Context, limit, offset int) ([]string, error) {
+	if offset >= len(t.pending) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(t.pending) {
+		end = len(t.pending)
+	}
+	return append([]string(nil), t.pending[offset:end]...), nil
+}
+
+func (t *fakeRetentionTable) apply(ctx context.Context, ids []string) ([]string, error) {
+	done := map[string]bool{}
+	var changed []string
+	for _, id := range ids {
+		if !t.skip[id] {
+			done[id] = true
+			changed = append(changed, id)
+		}
+	}
+	remaining := t.pending[:0]
+	for _, id := range t.pending {
+		if !done[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	t.pending = remaining
+	return changed, nil
+}
+
+func TestApplyBatchesProcessesEveryBatch(t *testing.T) {
+	total := 2*retentionBatchSize + 7
+	table := newFakeRetentionTable(total)
+	// Entidades que o lote não altera (por exemplo, rascunho editado no meio da execução)
+	table.skip["id-0003"] = true
+	table.skip["id-0600"] = true
+
+	audit := &countingAuditRepo{}
+	s := &retentionService{auditRepo: audit}
+	result := &models.RetentionRuleResult{Rule: models.RetentionIncidentDraftPurge}
+
+	if err := s.applyBatches(context.Background(), &models.RetentionRun{}, result, table.list, table.apply, AuditRetentionDraftPurged, AuditEntityIncident); err != nil {
+		t.Fatalf("applyBatches: %v", err)
+	}
+
+	if result.Affected != total-2 {
+		t.Errorf("Affected = %d, want %d", result.Affected, total-2)
+	}
+	if len(table.pending) != 2 {
+		t.Errorf("pending = %v, want only the skipped entities", table.pending)
+	}
+	if len(result.EntityIDs) != total-2 {
+		t.Errorf("EntityIDs has %d entries, want %d", len(result.EntityIDs), total-2)
+	}
+	if audit.entries != total-2 {
+		t.Errorf("audit entries = %d, want %d", audit.entries, total-2)
+	}
+	// Só o que foi alterado entra na auditoria
+	for id := range table.skip {
+		if audit.entities[id] {
+			t.Errorf("unchanged entity %s was audited", id)
+		}
+	}
+}
+
+func TestApplyBatchesDryRunCountsEverything(t *testing.T) {
+	total := retentionBatchSize + 1
+	table := newFakeRetentionTable(total)
+
+	audit := &countingAuditRepo{}
+	s := &retentionService{auditRepo: audit}
+	result := &models.RetentionRuleResult{Rule: models.RetentionInactiveChildPhoto}
+
+	if err := s.applyBatches(context.Background(), &models.RetentionRun{DryRun: true}, result, table.list, table.apply, AuditRetentionPhotoRemoved, AuditEntityChild); err != nil {
+		t.Fatalf("applyBatches: %v", err)
+	}
+
+	if result.Affected != total {
+		t.Errorf("Affected = %d, want %d", result.Affected, total)
+	}
+	if len(table.pending) != total || audit.entries != 0 {
+		t.Errorf("dry run changed %d entities and wrote %d audit entries", total-len(table.pending), audit.entries)
+	}
+}
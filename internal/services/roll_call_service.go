@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+// ErrRollCallClosed is returned when a closed roll call is changed.
+var ErrRollCallClosed = errors.New("roll call is already closed")
+
+type RollCallService interface {
+	Start(ctx context.Context, locationID, startedBy string) (*models.RollCallSummary, error)
+	List(ctx context.Context, status string, page, pageSize int) ([]*models.RollCall, error)
+	Summary(ctx context.Context, id string, includeMedical bool) (*models.RollCallSummary, error)
+	MarkAccounted(ctx context.Context, id, childID, accountedBy string) (*models.RollCallEntry, error)
+	Close(ctx context.Context, id, closedBy string) (*models.RollCallSummary, error)
+}
+
+type rollCallService struct {
+	repo         repository.RollCallRepository
+	locationRepo repository.LocationRepository
+	childRepo    repository.ChildRepository
+	profileRepo  repository.MedicalProfileRepository
+	auditRepo    repository.AuditRepository
+	events       EventPublisher
+	access       AccessLogService
+}
+
+func NewRollCallService(
+	repo repository.RollCallRepository,
+	locationRepo repository.LocationRepository,
+	childRepo repository.ChildRepository,
+	profileRepo repository.MedicalProfileRepository,
+	auditRepo repository.AuditRepository,
+	events EventPublisher,
+	access AccessLogService,
+) RollCallService {
+	return &rollCallService{
+		repo:         repo,
+		locationRepo: locationRepo,
+		childRepo:    childRepo,
+		profileRepo:  profileRepo,
+		auditRepo:    auditRepo,
+		events:       events,
+		access:       access,
+	}
+}
+
+// Start abre a chamada de emergência do local com as crianças presentes
+// naquele momento e avisa o painel de cada sala envolvida.
+func (s *rollCallService) Start(ctx context.Context, locationID, startedBy string) (*models.RollCallSummary, error) {
+	if locationID == "" {
+		return nil, invalid("location_id is required")
+	}
+	if _, err := s.locationRepo.GetByID(ctx, locationID); err != nil {
+		return nil, err
+	}
+
+	rollCall := &models.RollCall{
+		LocationID: locationID,
+		Status:     models.RollCallOpen,
+		StartedBy:  startedBy,
+	}
+	if err := s.repo.Start(ctx, rollCall); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.Summary(ctx, rollCall.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, summary, models.GroupEventRollCallStarted)
+	return summary, nil
+}
+
+func (s *rollCallService) List(ctx context.Context, status string, page, pageSize int) ([]*models.RollCall, error) {
+	offset := (page - 1) * pageSize
+	return s.repo.List(ctx, status, pageSize, offset)
+}
+
+// Summary monta a visão ao vivo: totais por sala e as crianças ainda não
+// localizadas. Com includeMedical, que cabe apenas aos papéis com acesso à
+// ficha médica, as crianças vêm com alergias e dados da ficha, e o acesso é
+// registrado.
+func (s *rollCallService) Summary(ctx context.Context, id string, includeMedical bool) (*models.RollCallSummary, error) {
+	rollCall, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListEntries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.RollCallSummary{
+		RollCall:    *rollCall,
+		Groups:      []models.RollCallGroupSummary{},
+		Unaccounted: []models.UnaccountedChild{},
+	}
+
+	groups := make(map[string]int)
+	for _, entry := range entries {
+		index, ok := groups[entry.GroupID]
+		if !ok {
+			index = len(summary.Groups)
+			groups[entry.GroupID] = index
+			summary.Groups = append(summary.Groups, models.RollCallGroupSummary{
+				GroupID:   entry.GroupID,
+				GroupName: entry.GroupName,
+			})
+		}
+		group := &summary.Groups[index]
+
+		summary.Total++
+		group.Total++
+		if entry.AccountedAt != nil {
+			summary.Accounted++
+			group.Accounted++
+			continue
+		}
+		group.Unaccounted++
+
+		if !includeMedical {
+			summary.Unaccounted = append(summary.Unaccounted, models.UnaccountedChild{RollCallEntry: *entry})
+			continue
+		}
+		missing, err := s.unaccountedChild(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		summary.Unaccounted = append(summary.Unaccounted, *missing)
+	}
+
+	if !includeMedical {
+		return summary, nil
+	}
+
+	// As crianças não localizadas são exibidas com os dados da ficha médica
+	childIDs := make([]string, 0, len(summary.Unaccounted))
+	for _, missing := range summary.Unaccounted {
+		childIDs = append(childIDs, missing.ChildID)
+	}
+	if err := s.access.RecordAccess(ctx, models.AccessMedicalProfile, models.AccessEntityChild, childIDs...); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (s *rollCallService) unaccountedChild(ctx context.Context, entry *models.RollCallEntry) (*models.UnaccountedChild, error) {
+	missing := &models.UnaccountedChild{RollCallEntry: *entry}
+
+	child, err := s.childRepo.GetByID(ctx, entry.ChildID)
+	switch {
+	case err == nil:
+		missing.Allergies = child.Allergies
+	case !errors.Is(err, repository.ErrNotFound):
+		return nil, err
+	}
+
+	profile, err := s.profileRepo.Get(ctx, entry.ChildID)
+	if err != nil {
+		return nil, err
+	}
+	missing.BloodType = profile.BloodType
+	missing.Conditions = profile.Conditions
+	missing.DietaryRestrictions = profile.DietaryRestrictions
+
+	return missing, nil
+}
+
+func (s *rollCallService) MarkAccounted(ctx context.Context, id, childID, accountedBy string) (*models.RollCallEntry, error) {
+	rollCall, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rollCall.Status != models.RollCallOpen {
+		return nil, ErrRollCallClosed
+	}
+
+	return s.repo.MarkAccounted(ctx, id, childID, accountedBy)
+}
+
+// Close encerra a chamada e registra na auditoria quem encerrou e quais
+// crianças ainda não tinham sido localizadas.
+func (s *rollCallService) Close(ctx context.Context, id, closedBy string) (*models.RollCallSummary, error) {
+	rollCall, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rollCall.Status != models.RollCallOpen {
+		return nil, ErrRollCallClosed
+	}
+
+	rollCall.ClosedBy = closedBy
+	if err := s.repo.Close(ctx, rollCall); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRollCallClosed
+		}
+		return nil, err
+	}
+
+	summary, err := s.Summary(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	unaccounted := make([]string, 0, len(summary.Unaccounted))
+	for _, child := range summary.Unaccounted {
+		unaccounted = append(unaccounted, child.ChildID)
+	}
+	details := map[string]interface{}{
+		"location_id":          summary.LocationID,
+		"started_by":           summary.StartedBy,
+		"started_at":           summary.StartedAt,
+		"total":                summary.Total,
+		"accounted":            summary.Accounted,
+		"unaccounted_children": unaccounted,
+	}
+	if err := recordAudit(ctx, s.auditRepo, closedBy, AuditRollCallClosed, AuditEntityRollCall, id, details); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, summary, models.GroupEventRollCallClosed)
+	return summary, nil
+}
+
+func (s *rollCallService) publish(ctx context.Context, summary *models.RollCallSummary, eventType string) {
+	for _, group := range summary.Groups {
+		if group.GroupID == "" {
+			continue
+		}
+		s.events.Publish(ctx, group.GroupID, eventType, map[string]interface{}{
+			"roll_call_id": summary.ID,
+			"total":        group.Total,
+			"accounted":    group.Accounted,
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eduardohass/kids-api/internal/models"
+	"github.com/eduardohass/kids-api/internal/repository"
+)
+
+const (
+	minSearchTermLength = 2
+	maxSearchLimit      = 50
+)
+
+type SearchService interface {
+	Search(ctx context.Context, term string, limit int) ([]*models.SearchResult, error)
+}
+
+type searchService struct {
+	repo   repository.SearchRepository
+	access AccessLogService
+}
+
+func NewSearchService(repo repository.SearchRepository, access AccessLogService) SearchService {
+	return &searchService{
+		repo:   repo,
+		access: access,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, term string, limit int) ([]*models.SearchResult, error) {
+	term = strings.TrimSpace(term)
+	if utf8.RuneCountInString(term) < minSearchTermLength {
+		return nil, invalid("search term must have at least 2 characters")
+	}
+
+	if limit <= 0 || limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	results, err := s.repo.Search(ctx, term, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// O detalhe dos responsáveis traz o e-mail ou o telefone
+	var caretakerIDs []string
+	for _, result := range results {
+		if result.Type == models.SearchTypeCaretaker && result.Detail != "" {
+			caretakerIDs = append(caretakerIDs, result.ID)
+		}
+	}
+	if err := s.access.RecordAccess(ctx, models.AccessContactDetails, models.AccessEntityCaretaker, caretakerIDs...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
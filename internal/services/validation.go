@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eduardohass/kids-api/internal/models"
+)
+
+// ErrInvalidInput is returned when an entity fails business validation.
+var ErrInvalidInput = errors.New("invalid input")
+
+func invalid(message string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidInput, message)
+}
+
+// uuidPattern aceita o formato textual de UUID que o Postgres converte.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func isUUID(id string) bool {
+	return uuidPattern.MatchString(id)
+}
+
+func validateChild(child *models.Child) error {
+	if strings.TrimSpace(child.Name) == "" {
+		return invalid("child name is required")
+	}
+
+	if child.BirthDate.IsZero() {
+		return invalid("birth date is required")
+	}
+
+	if child.Gender == "" {
+		return invalid("gender is required")
+	}
+
+	return nil
+}
+
+func validateCaretaker(caretaker *models.Caretaker) error {
+	if strings.TrimSpace(caretaker.Name) == "" {
+		return invalid("caretaker name is required")
+	}
+	return nil
+}
+
+func validateVolunteer(volunteer *models.Volunteer) error {
+	if strings.TrimSpace(volunteer.Name) == "" {
+		return invalid("volunteer name is required")
+	}
+	return nil
+}
+
+func validateGroup(group *models.Group) error {
+	if strings.TrimSpace(group.Name) == "" {
+		return invalid("group name is required")
+	}
+
+	if group.Capacity < 0 {
+		return invalid("capacity must not be negative")
+	}
+
+	return nil
+}
+
+func validateHousehold(household *models.Household) error {
+	if strings.TrimSpace(household.Name) == "" {
+		return invalid("household name is required")
+	}
+	return nil
+}
+
+func validatePickupGrant(grant *models.PickupGrant) error {
+	if strings.TrimSpace(grant.Name) == "" {
+		return invalid("pickup person name is required")
+	}
+
+	if grant.GrantedByCaretakerID == "" {
+		return invalid("granting caretaker is required")
+	}
+
+	if grant.EventID != "" {
+		return nil
+	}
+
+	if grant.ValidFrom == nil || grant.ValidUntil == nil {
+		return invalid("event_id or valid_from/valid_until is required")
+	}
+
+	if !grant.ValidUntil.After(*grant.ValidFrom) {
+		return invalid("valid_until must be after valid_from")
+	}
+
+	return nil
+}
+
+func validateIncident(incident *models.Incident) error {
+	if incident.ChildID == "" {
+		return invalid("child_id is required")
+	}
+
+	switch incident.Type {
+	case models.IncidentInjury, models.IncidentIllness, models.IncidentBehavior, models.IncidentOther:
+	default:
+		return invalid("type must be one of injury, illness, behavior, other")
+	}
+
+	if strings.TrimSpace(incident.Description) == "" {
+		return invalid("description is required")
+	}
+
+	if incident.OccurredAt.IsZero() {
+		return invalid("occurred_at is required")
+	}
+
+	if incident.OccurredAt.After(time.Now().Add(time.Minute)) {
+		return invalid("occurred_at must not be in the future")
+	}
+
+	return nil
+}
+
+func validateMedicationOrder(order *models.MedicationOrder) error {
+	if strings.TrimSpace(order.Drug) == "" {
+		return invalid("drug is required")
+	}
+
+	if strings.TrimSpace(order.Dose) == "" {
+		return invalid("dose is required")
+	}
+
+	if strings.TrimSpace(order.Schedule) == "" {
+		return invalid("schedule is required")
+	}
+
+	if strings.TrimSpace(order.ConsentDocumentURL) == "" {
+		return invalid("consent_document_url is required")
+	}
+
+	if !order.ConsentExpiresAt.After(time.Now()) {
+		return invalid("consent_expires_at must be in the future")
+	}
+
+	return nil
+}
+
+func validateMedicalProfile(profile *models.MedicalProfile) error {
+	if profile.BloodType != "" {
+		valid := false
+		for _, bloodType := range models.BloodTypes {
+			if profile.BloodType == bloodType {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return invalid("invalid blood type " + profile.BloodType)
+		}
+	}
+
+	for _, contact := range profile.EmergencyContacts {
+		if contact.CaretakerID == "" && (strings.TrimSpace(contact.Name) == "" || strings.TrimSpace(string(contact.Phone)) == "") {
+			return invalid("emergency contacts need a caretaker_id or a name and phone")
+		}
+	}
+
+	return nil
+}
+
+func validateLocation(location *models.Location) error {
+	if strings.TrimSpace(location.Name) == "" {
+		return invalid("location name is required")
+	}
+	return nil
+}
+
+func validateRoom(room *models.Room) error {
+	if strings.TrimSpace(room.Name) == "" {
+		return invalid("room name is required")
+	}
+	if room.Capacity < 0 {
+		return invalid("room capacity cannot be negative")
+	}
+	if room.ChildrenPerVolunteer < 0 {
+		return invalid("children_per_volunteer cannot be negative")
+	}
+	return nil
+}
+
+func validateDataSubjectRequest(request *models.DataSubjectRequest) error {
+	if request.CaretakerID == "" {
+		return invalid("caretaker_id is required")
+	}
+	switch request.Type {
+	case models.DataRequestExport:
+	case models.DataRequestErasure:
+		if !request.Confirm {
+			return invalid("erasure is irreversible and must be confirmed")
+		}
+	default:
+		return invalid("type must be export or erasure")
+	}
+	return nil
+}
+
+func validateRetentionPolicy(policy *models.RetentionPolicy) error {
+	known := false
+	for _, defaults := range models.RetentionRuleDefaults {
+		if policy.Rule == defaults.Rule {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return invalid("unknown retention rule " + policy.Rule)
+	}
+	if policy.AfterDays < minRetentionDays {
+		return invalid(fmt.Sprintf("after_days must be at least %d", minRetentionDays))
+	}
+	return nil
+}
+
+func validateConsentDocument(document *models.ConsentDocument) error {
+	known := false
+	for _, kind := range models.ConsentKinds {
+		if document.Kind == kind {
+			known = true
+		}
+	}
+	if !known {
+		return invalid("kind must be one of " + strings.Join(models.ConsentKinds, ", "))
+	}
+	if strings.TrimSpace(document.Title) == "" || strings.TrimSpace(document.Body) == "" {
+		return invalid("title and body are required")
+	}
+	return nil
+}
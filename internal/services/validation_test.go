@@ -0,0 +1,16 @@
+package services
+
+import "testing"
+
+func TestIsUUID(t *testing.T) {
+	for _, id := range []string{"6f1c2a34-0000-4000-8000-000000000001", "6F1C2A34-ABCD-4000-8000-00000000000F"} {
+		if !isUUID(id) {
+			t.Errorf("isUUID(%q) = false, want true", id)
+		}
+	}
+	for _, id := range []string{"", "12", "not-a-uuid", "6f1c2a34-0000-4000-8000-00000000000g", "6f1c2a34-0000-4000-8000-000000000001'; --"} {
+		if isUUID(id) {
+			t.Errorf("isUUID(%q) = true, want false", id)
+		}
+	}
+}
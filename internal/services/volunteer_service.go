@@ -3,6 +3,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/eduardohass/kids-api/internal/models"
 	"github.com/eduardohass/kids-api/internal/repository"
@@ -14,6 +15,8 @@ type VolunteerService interface {
 	GetVolunteer(ctx context.Context, id string) (*models.Volunteer, error)
 	UpdateVolunteer(ctx context.Context, volunteer *models.Volunteer) error
 	DeleteVolunteer(ctx context.Context, id string) error
+	RestoreVolunteer(ctx context.Context, id string) error
+	PurgeVolunteer(ctx context.Context, id string, retention time.Duration) error
 	ListVolunteers(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Volunteer, error)
 }
 
@@ -29,6 +32,9 @@ func NewVolunteerService(repo repository.VolunteerRepository) VolunteerService {
 }
 
 func (s *volunteerService) CreateVolunteer(ctx context.Context, volunteer *models.Volunteer) error {
+	if err := validateVolunteer(volunteer); err != nil {
+		return err
+	}
 	return s.repo.Create(ctx, volunteer)
 }
 
@@ -37,6 +43,9 @@ func (s *volunteerService) GetVolunteer(ctx context.Context, id string) (*models
 }
 
 func (s *volunteerService) UpdateVolunteer(ctx context.Context, volunteer *models.Volunteer) error {
+	if err := validateVolunteer(volunteer); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, volunteer)
 }
 
@@ -44,6 +53,16 @@ func (s *volunteerService) DeleteVolunteer(ctx context.Context, id string) error
 	return s.repo.Delete(ctx, id)
 }
 
+func (s *volunteerService) RestoreVolunteer(ctx context.Context, id string) error {
+	return s.repo.Restore(ctx, id)
+}
+
+// PurgeVolunteer remove definitivamente o registro, respeitando o período de retenção
+// contado a partir da exclusão lógica.
+func (s *volunteerService) PurgeVolunteer(ctx context.Context, id string, retention time.Duration) error {
+	return s.repo.Purge(ctx, id, time.Now().Add(-retention))
+}
+
 func (s *volunteerService) ListVolunteers(ctx context.Context, filter map[string]interface{}, page, pageSize int) ([]*models.Volunteer, error) {
 	return s.repo.List(ctx, filter, page, pageSize)
 }
@@ -0,0 +1,160 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// setTenantQuery define o tenant apenas para a transação corrente; as
+// políticas de row-level security do Postgres filtram por esse valor.
+const setTenantQuery = `SELECT set_config('app.tenant_id', $1, true)`
+
+// DB envolve o *sqlx.DB para que toda consulta feita com um contexto de tenant
+// rode em uma transação com app.tenant_id definido. Sem tenant no contexto, as
+// tabelas protegidas por RLS não retornam nem aceitam linhas.
+type DB struct {
+	db      *sqlx.DB
+	observe Observer
+}
+
+// Observer recebe a duração de cada consulta feita pelo DB e o erro devolvido
+// ao repositório. sql.ErrNoRows não é informado como erro.
+type Observer func(elapsed time.Duration, err error)
+
+// NewDB cria um DB com escopo de tenant sobre a conexão informada.
+func NewDB(db *sqlx.DB) *DB {
+	return &DB{db: db}
+}
+
+// WithObserver retorna um DB sobre a mesma conexão que informa a duração das
+// consultas a observe. Consultas feitas em transações abertas com BeginTxx não
+// são medidas.
+func (d *DB) WithObserver(observe Observer) *DB {
+	return &DB{db: d.db, observe: observe}
+}
+
+func (d *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	defer d.observed(time.Now(), &err)
+	return d.scoped(ctx, func(q sqlx.ExtContext) error {
+		return sqlx.GetContext(ctx, q, dest, query, args...)
+	})
+}
+
+func (d *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	defer d.observed(time.Now(), &err)
+	return d.scoped(ctx, func(q sqlx.ExtContext) error {
+		return sqlx.SelectContext(ctx, q, dest, query, args...)
+	})
+}
+
+func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (result sql.Result, err error) {
+	defer d.observed(time.Now(), &err)
+	err = d.scoped(ctx, func(q sqlx.ExtContext) error {
+		var err error
+		result, err = q.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// QueryRowxContext retorna uma Row cuja transação é concluída no Scan.
+func (d *DB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *Row {
+	row := &Row{db: d, start: time.Now()}
+
+	id, ok := FromContext(ctx)
+	if !ok {
+		row.row = d.db.QueryRowxContext(ctx, query, args...)
+		return row
+	}
+
+	tx, err := d.begin(ctx, id, nil)
+	if err != nil {
+		row.err = err
+		return row
+	}
+	row.row = tx.QueryRowxContext(ctx, query, args...)
+	row.tx = tx
+	return row
+}
+
+// BeginTxx inicia uma transação já restrita ao tenant do contexto.
+func (d *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return d.db.BeginTxx(ctx, opts)
+	}
+	return d.begin(ctx, id, opts)
+}
+
+func (d *DB) scoped(ctx context.Context, fn func(q sqlx.ExtContext) error) error {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return fn(d.db)
+	}
+
+	tx, err := d.begin(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// observed informa a duração da consulta iniciada em start, se houver Observer.
+func (d *DB) observed(start time.Time, err *error) {
+	if d.observe == nil {
+		return
+	}
+	if errors.Is(*err, sql.ErrNoRows) {
+		d.observe(time.Since(start), nil)
+		return
+	}
+	d.observe(time.Since(start), *err)
+}
+
+func (d *DB) begin(ctx context.Context, id string, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	tx, err := d.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, setTenantQuery, id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Row é o resultado de QueryRowxContext. Scan lê a linha e encerra a
+// transação aberta para a consulta.
+type Row struct {
+	row   *sqlx.Row
+	tx    *sqlx.Tx
+	err   error
+	db    *DB
+	start time.Time
+}
+
+func (r *Row) Scan(dest ...interface{}) (err error) {
+	defer r.db.observed(r.start, &err)
+	if r.err != nil {
+		return r.err
+	}
+	if r.tx == nil {
+		return r.row.Scan(dest...)
+	}
+
+	if err := r.row.Scan(dest...); err != nil {
+		r.tx.Rollback()
+		return err
+	}
+	return r.tx.Commit()
+}
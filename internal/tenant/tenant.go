@@ -0,0 +1,23 @@
+// Package tenant isola os dados de cada campus (tenant) que compartilha a
+// mesma instalação da API.
+package tenant
+
+import (
+	"context"
+)
+
+// DefaultID é o tenant que recebeu os dados existentes antes da separação por campus.
+const DefaultID = "00000000-0000-0000-0000-000000000001"
+
+type contextKey struct{}
+
+// WithID retorna um contexto cujas consultas ficam restritas ao tenant informado.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext retorna o tenant da requisição, se houver.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}